@@ -0,0 +1,58 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+	"github.com/namsral/flag"
+
+	"github.com/transcom/mymove/pkg/awardqueue"
+)
+
+const dateFormat = "2006-01-02"
+
+func main() {
+	config := flag.String("config-dir", "config", "The location of server config files")
+	env := flag.String("env", "development", "The environment to run in, which configures the database.")
+	tdlID := flag.String("tdl-id", "", "ID of the traffic distribution list to report on")
+	startDate := flag.String("start-date", "", "First book date of shipments to include, as YYYY-MM-DD")
+	endDate := flag.String("end-date", "", "Last book date of shipments to include, as YYYY-MM-DD")
+	flag.Parse()
+
+	id, err := uuid.FromString(*tdlID)
+	if err != nil {
+		log.Fatal("-tdl-id must be a UUID")
+	}
+	start, err := time.Parse(dateFormat, *startDate)
+	if err != nil {
+		log.Fatalf("-start-date must be a date formatted as %s", dateFormat)
+	}
+	end, err := time.Parse(dateFormat, *endDate)
+	if err != nil {
+		log.Fatalf("-end-date must be a date formatted as %s", dateFormat)
+	}
+	if end.Before(start) {
+		log.Fatal("-end-date must not be before -start-date")
+	}
+
+	// DB connection
+	err = pop.AddLookupPaths(*config)
+	if err != nil {
+		log.Panic(err)
+	}
+	dbConnection, err := pop.Connect(*env)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	report, err := awardqueue.NewFairnessReport(dbConnection, id, start, end)
+	if err != nil {
+		log.Panic(err)
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		log.Panic(err)
+	}
+}
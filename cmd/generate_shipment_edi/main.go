@@ -1,11 +1,8 @@
 package main
 
 import (
-	"bytes"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
@@ -14,7 +11,6 @@ import (
 	"github.com/facebookgo/clock"
 	"github.com/gobuffalo/pop"
 	"github.com/gofrs/uuid"
-	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -95,7 +91,11 @@ func main() {
 
 	var sendToGexHTTP services.GexSender
 	if sendToGex {
-		certificates, rootCAs, err := initDODCertificates(v, logger)
+		certificates, rootCAs, err := server.LoadDODCertificates(
+			v.GetString("move-mil-dod-tls-cert"),
+			v.GetString("move-mil-dod-ca-cert"),
+			v.GetString("move-mil-dod-tls-key"),
+			v.GetString("dod-ca-package"))
 		if certificates == nil || rootCAs == nil || err != nil {
 			log.Fatal("Error in getting tls certs", err)
 		}
@@ -176,71 +176,6 @@ func processInvoice(db *pop.Connection, shipment models.Shipment, invoiceModel m
 		fmt.Printf("status code: %v, error: %v\n", resp.StatusCode, err)
 	}
 	ediWriter := edi.NewWriter(os.Stdout)
-	err = ediWriter.WriteAll(invoice858C.Segments())
+	err = invoice858C.Write(ediWriter)
 	return nil, err
 }
-
-//TODO: Infra will work to refactor and reduce duplication (also found in webserver/main.go)
-func initDODCertificates(v *viper.Viper, logger *zap.Logger) ([]tls.Certificate, *x509.CertPool, error) {
-
-	tlsCert := v.GetString("move-mil-dod-tls-cert")
-	if len(tlsCert) == 0 {
-		return make([]tls.Certificate, 0), nil, errors.Errorf("%s is missing", "move-mil-dod-tls-cert")
-	}
-
-	caCert := v.GetString("move-mil-dod-ca-cert")
-	if len(caCert) == 0 {
-		return make([]tls.Certificate, 0), nil, errors.Errorf("%s is missing", "move-mil-dod-ca-cert")
-	}
-
-	//Append move.mil cert with CA certificate chain
-	cert := bytes.Join(
-		[][]byte{
-			[]byte(tlsCert),
-			[]byte(caCert),
-		},
-		[]byte("\n"),
-	)
-
-	key := []byte(v.GetString("move-mil-dod-tls-key"))
-	if len(key) == 0 {
-		return make([]tls.Certificate, 0), nil, errors.Errorf("%s is missing", "move-mil-dod-tls-key")
-	}
-
-	keyPair, err := tls.X509KeyPair(cert, key)
-	if err != nil {
-		return make([]tls.Certificate, 0), nil, errors.Wrap(err, "failed to parse DOD keypair for server")
-	}
-
-	pathToPackage := v.GetString("dod-ca-package")
-	if len(pathToPackage) == 0 {
-		return make([]tls.Certificate, 0), nil, errors.Wrap(&errInvalidPKCS7{Path: pathToPackage}, fmt.Sprintf("%s is missing", "dod-ca-package"))
-	}
-
-	pkcs7Package, err := ioutil.ReadFile(pathToPackage) // #nosec
-	if err != nil {
-		return make([]tls.Certificate, 0), nil, errors.Wrap(err, fmt.Sprintf("%s is invalid", "dod-ca-package"))
-	}
-
-	if len(pkcs7Package) == 0 {
-		return make([]tls.Certificate, 0), nil, errors.Wrap(&errInvalidPKCS7{Path: pathToPackage}, fmt.Sprintf("%s is an empty file", "dod-ca-package"))
-	}
-
-	dodCACertPool, err := server.LoadCertPoolFromPkcs7Package(pkcs7Package)
-	if err != nil {
-		return make([]tls.Certificate, 0), dodCACertPool, errors.Wrap(err, "Failed to parse DoD CA certificate package")
-	}
-
-	return []tls.Certificate{keyPair}, dodCACertPool, nil
-
-}
-
-//TODO: Infra will refactor to reduce duplication
-type errInvalidPKCS7 struct {
-	Path string
-}
-
-//TODO: Infra will refactor to reduce duplication
-func (e *errInvalidPKCS7) Error() string {
-	return fmt.Sprintf("invalid DER encoded PKCS7 package: %s", e.Path)
-}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/gobuffalo/pop"
+	"github.com/namsral/flag"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/internal/pkg/tariff400ngloader"
+)
+
+func main() {
+	config := flag.String("config-dir", "config", "The location of server config files")
+	verbose := flag.Bool("verbose", false, "Sets debug logging level")
+	env := flag.String("env", "development", "The environment to run in, which configures the database.")
+	validate := flag.Bool("validate", false, "Only validate the files and print the diff")
+	output := flag.String("output", "", "Where to output the migration file")
+	load := flag.Bool("load", false, "Load the rates directly into the database in a single transaction")
+	paths := map[string]*string{}
+	for _, name := range tariff400ngloader.TableNames() {
+		flagName := strings.Replace(name, "_", "-", -1)
+		paths[name] = flag.String(flagName, "", fmt.Sprintf("Input CSV or XLSX file for %s", name))
+	}
+	flag.Parse()
+
+	zapConfig := zap.NewDevelopmentConfig()
+	logger, _ := zapConfig.Build()
+
+	zapConfig.Level.SetLevel(zap.InfoLevel)
+	if *verbose {
+		zapConfig.Level.SetLevel(zap.DebugLevel)
+	}
+
+	if !*validate && !*load && *output == "" {
+		log.Fatal("One of -validate, -load or -output is required")
+	}
+
+	//DB connection
+	err := pop.AddLookupPaths(*config)
+	if err != nil {
+		logger.Panic("Error initializing db connection", zap.Error(err))
+	}
+	db, err := pop.Connect(*env)
+	if err != nil {
+		logger.Panic("Error initializing db connection", zap.Error(err))
+	}
+
+	loader := tariff400ngloader.NewLoader(db, logger)
+
+	var diffs []tariff400ngloader.TableDiff
+	var inputs []string
+	hasProblems := false
+	for _, name := range tariff400ngloader.TableNames() {
+		path := *paths[name]
+		if path == "" {
+			continue
+		}
+
+		diff, err := loader.Diff(name, path)
+		if err != nil {
+			logger.Panic("Error comparing rate table", zap.String("table", name), zap.Error(err))
+		}
+		diff.WriteSummary(os.Stdout)
+		hasProblems = hasProblems || diff.HasProblems()
+
+		diffs = append(diffs, diff)
+		inputs = append(inputs, fmt.Sprintf("-- %s file: %v\n", diff.Table(), path))
+	}
+
+	if len(diffs) == 0 {
+		log.Fatal("No input files given")
+	}
+	if hasProblems {
+		log.Fatal("Effective date problems found, nothing was written")
+	}
+
+	// If we just want to validate files we can exit
+	if *validate {
+		os.Exit(0)
+	}
+
+	if *load {
+		err = loader.Load(diffs)
+		if err != nil {
+			logger.Panic("Error loading rate tables", zap.Error(err))
+		}
+		fmt.Println("Complete! Rates loaded")
+	}
+
+	if *output != "" {
+		var migration strings.Builder
+		migration.WriteString("-- Migration generated using cmd/load_tariff400ng\n")
+		for _, input := range inputs {
+			migration.WriteString(input)
+		}
+		for _, diff := range diffs {
+			migration.WriteString("\n")
+			for _, statement := range diff.Statements() {
+				migration.WriteString(statement)
+				migration.WriteString("\n")
+			}
+		}
+
+		f, err := os.OpenFile(*output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Panic(err)
+		}
+		defer f.Close()
+		_, err = f.WriteString(migration.String())
+		if err != nil {
+			log.Panic(err)
+		}
+
+		fmt.Printf("Complete! Migration written to %v\n", *output)
+	}
+}
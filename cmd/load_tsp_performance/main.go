@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/namsral/flag"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/internal/pkg/tspperformanceloader"
+)
+
+const dateFormat = "2006-01-02"
+
+func main() {
+	config := flag.String("config-dir", "config", "The location of server config files")
+	verbose := flag.Bool("verbose", false, "Sets debug logging level")
+	env := flag.String("env", "development", "The environment to run in, which configures the database.")
+	validate := flag.Bool("validate", false, "Only validate the file and print the performances that would be added")
+	file := flag.String("file", "", "Input CSV or XLSX best value score file from SDDC")
+	periodStart := flag.String("performance-period-start", "", "First day of the performance period, as YYYY-MM-DD")
+	periodEnd := flag.String("performance-period-end", "", "Last day of the performance period, as YYYY-MM-DD")
+	flag.Parse()
+
+	zapConfig := zap.NewDevelopmentConfig()
+	logger, _ := zapConfig.Build()
+
+	zapConfig.Level.SetLevel(zap.InfoLevel)
+	if *verbose {
+		zapConfig.Level.SetLevel(zap.DebugLevel)
+	}
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+	start, err := time.Parse(dateFormat, *periodStart)
+	if err != nil {
+		log.Fatalf("-performance-period-start must be a date formatted as %s", dateFormat)
+	}
+	end, err := time.Parse(dateFormat, *periodEnd)
+	if err != nil {
+		log.Fatalf("-performance-period-end must be a date formatted as %s", dateFormat)
+	}
+
+	//DB connection
+	err = pop.AddLookupPaths(*config)
+	if err != nil {
+		logger.Panic("Error initializing db connection", zap.Error(err))
+	}
+	db, err := pop.Connect(*env)
+	if err != nil {
+		logger.Panic("Error initializing db connection", zap.Error(err))
+	}
+
+	loader := tspperformanceloader.NewLoader(db, logger)
+
+	period, err := loader.Read(*file, start, end)
+	if err != nil {
+		logger.Panic("Error reading best value score file", zap.String("file", *file), zap.Error(err))
+	}
+	period.WriteSummary(os.Stdout)
+	if period.HasProblems() {
+		log.Fatal("Problems found, nothing was written")
+	}
+
+	// If we just want to validate the file we can exit
+	if *validate {
+		os.Exit(0)
+	}
+
+	err = loader.Load(context.Background(), period)
+	if err != nil {
+		logger.Panic("Error loading TSP performances", zap.Error(err))
+	}
+	fmt.Println("Complete! TSP performances loaded and assigned quality bands")
+}
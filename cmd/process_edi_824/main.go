@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/gobuffalo/pop"
+	"github.com/namsral/flag"
+
+	ediapplicationadvice "github.com/transcom/mymove/pkg/edi/applicationadvice"
+	"github.com/transcom/mymove/pkg/services/invoice"
+)
+
+// Call this from command line with go run cmd/process_edi_824/main.go -edi <path to 824>
+// Marks each invoice rejected in the 824 as rejected, with Syncada's notes on why
+func main() {
+	config := flag.String("config-dir", "config", "The location of server config files")
+	env := flag.String("env", "development", "The environment to run in, which configures the database.")
+	ediFile := flag.String("edi", "", "The filepath to an 824 received from Syncada")
+	flag.Parse()
+
+	if *ediFile == "" {
+		log.Fatal("Usage: go run cmd/process_edi_824/main.go -edi <path to 824>")
+	}
+
+	file, err := os.Open(*ediFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	advice, err := ediapplicationadvice.Parse824(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// DB connection
+	err = pop.AddLookupPaths(*config)
+	if err != nil {
+		log.Panic(err)
+	}
+	dbConnection, err := pop.Connect(*env)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	invoices, verrs, err := invoice.ProcessApplicationAdvice{DB: dbConnection}.Call(advice)
+	if err != nil || verrs.HasAny() {
+		log.Fatalf("Could not apply 824: %v %v", err, verrs)
+	}
+	for _, inv := range invoices {
+		fmt.Printf("%s\t%s\n", inv.InvoiceNumber, inv.Status)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/facebookgo/clock"
+	"github.com/gobuffalo/pop"
+	"github.com/namsral/flag"
+
+	ediacknowledgement "github.com/transcom/mymove/pkg/edi/acknowledgement"
+	"github.com/transcom/mymove/pkg/services/invoice"
+)
+
+// Call this from command line with go run cmd/process_edi_997/main.go -edi <path to 997>
+// Marks each invoice acknowledged in the 997 as accepted or rejected
+func main() {
+	config := flag.String("config-dir", "config", "The location of server config files")
+	env := flag.String("env", "development", "The environment to run in, which configures the database.")
+	ediFile := flag.String("edi", "", "The filepath to a 997 received from Syncada")
+	flag.Parse()
+
+	if *ediFile == "" {
+		log.Fatal("Usage: go run cmd/process_edi_997/main.go -edi <path to 997>")
+	}
+
+	file, err := os.Open(*ediFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	ack, err := ediacknowledgement.Parse997(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// DB connection
+	err = pop.AddLookupPaths(*config)
+	if err != nil {
+		log.Panic(err)
+	}
+	dbConnection, err := pop.Connect(*env)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	invoices, verrs, err := invoice.ProcessFunctionalAcknowledgement{DB: dbConnection, Clock: clock.New()}.Call(ack)
+	if err != nil || verrs.HasAny() {
+		log.Fatalf("Could not apply 997: %v %v", err, verrs)
+	}
+	for _, inv := range invoices {
+		fmt.Printf("%s\t%s\n", inv.InvoiceNumber, inv.Status)
+	}
+}
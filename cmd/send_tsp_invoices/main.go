@@ -0,0 +1,198 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awssession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/facebookgo/clock"
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/db/sequence"
+	ediinvoice "github.com/transcom/mymove/pkg/edi/invoice"
+	"github.com/transcom/mymove/pkg/logging"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/server"
+	"github.com/transcom/mymove/pkg/services"
+	"github.com/transcom/mymove/pkg/services/invoice"
+	"github.com/transcom/mymove/pkg/storage"
+)
+
+// Call this from command line with go run cmd/send_tsp_invoices/main.go --tspID <UUID> --approver <email>
+// Invoices every shipment the TSP is ready to be paid for in a single 858C, and stores the EDI for each invoice
+func main() {
+	flag := pflag.CommandLine
+	flag.String("tspID", "", "The ID of the TSP whose shipments to invoice")
+	flag.String("approver", "", "The office approver e-mail")
+	flag.Bool("gex", false, "Choose to send the invoices to gex")
+	flag.String("env", "development", "The environment to run in, which configures the database.")
+
+	// EDI Invoice Config
+	flag.String("gex-basic-auth-username", "", "GEX api auth username")
+	flag.String("gex-basic-auth-password", "", "GEX api auth password")
+	flag.String("gex-url", "", "URL for sending an HTTP POST request to GEX")
+
+	flag.String("dod-ca-package", "", "Path to PKCS#7 package containing certificates of all DoD root and intermediate CAs")
+	flag.String("move-mil-dod-ca-cert", "", "The DoD CA certificate used to sign the move.mil TLS certificate.")
+	flag.String("move-mil-dod-tls-cert", "", "The DoD-signed TLS certificate for various move.mil services.")
+	flag.String("move-mil-dod-tls-key", "", "The private key for the DoD-signed TLS certificate for various move.mil services.")
+
+	// Storage for the invoices' EDI
+	flag.String("storage-backend", "local", "Storage backend to use, either local or s3.")
+	flag.String("aws-s3-bucket-name", "", "S3 bucket used for file storage")
+	flag.String("aws-s3-region", "", "AWS region used for S3 file storage")
+	flag.String("aws-s3-key-namespace", "", "Key prefix for all objects written to S3")
+	flag.Parse(os.Args[1:])
+
+	v := viper.New()
+	v.BindPFlags(flag)
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	v.AutomaticEnv()
+
+	logger, err := logging.Config("development", true)
+	if err != nil {
+		log.Fatalf("Failed to initialize Zap logging due to %v", err)
+	}
+	tspIDString := v.GetString("tspID")
+	approverEmail := v.GetString("approver")
+	sendToGex := v.GetBool("gex")
+	if tspIDString == "" || approverEmail == "" {
+		logger.Fatal("Usage: go run cmd/send_tsp_invoices/main.go --tspID <29cb984e-c70d-46f0-926d-cd89e07a6ec3> --approver <officeuser1@example.com> --gex false")
+	}
+
+	db, err := pop.Connect(v.GetString("env"))
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	tspID := uuid.Must(uuid.FromString(tspIDString))
+	shipments, err := invoice.FetchShipmentsToInvoiceForTSP{DB: db}.Call(tspID)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	if len(shipments) == 0 {
+		fmt.Println("No shipments are ready to invoice")
+		return
+	}
+
+	approver, err := models.FetchOfficeUserByEmail(db, approverEmail)
+	if err != nil {
+		logger.Fatal("Could not fetch office user with e-mail", zap.String("email", approverEmail), zap.Error(err))
+	}
+	if approver.UserID == nil {
+		logger.Fatal("Office user has not logged in yet", zap.String("email", approverEmail))
+	}
+
+	// before processing the invoices, save them in an in process state
+	invoices := make(models.Invoices, len(shipments))
+	for i, shipment := range shipments {
+		verrs, err := invoice.CreateInvoice{DB: db, Clock: clock.New()}.Call(*approver, &invoices[i], shipment)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		if verrs.HasAny() {
+			logger.Fatal(verrs.Error())
+		}
+	}
+
+	var gexSender services.GexSender
+	var icnSequencer sequence.Sequencer
+	if sendToGex {
+		certificates, rootCAs, err := server.LoadDODCertificates(
+			v.GetString("move-mil-dod-tls-cert"),
+			v.GetString("move-mil-dod-ca-cert"),
+			v.GetString("move-mil-dod-tls-key"),
+			v.GetString("dod-ca-package"))
+		if certificates == nil || rootCAs == nil || err != nil {
+			log.Fatal("Error in getting tls certs", err)
+		}
+		tlsConfig := &tls.Config{Certificates: certificates, RootCAs: rootCAs}
+		url := v.GetString("gex-url")
+		if len(url) == 0 {
+			log.Fatal("Not sending to GEX because no URL set. Set GEX_URL in your envrc.local.")
+		}
+		gexSender = invoice.NewGexSenderHTTP(
+			url,
+			true,
+			tlsConfig,
+			v.GetString("gex-basic-auth-username"),
+			v.GetString("gex-basic-auth-password"),
+		)
+		icnSequencer, err = sequence.NewRandomSequencer(ediinvoice.ICNRandomMin, ediinvoice.ICNRandomMax)
+		if err != nil {
+			logger.Fatal("Could not create random sequencer for ICN", zap.Error(err))
+		}
+	} else {
+		// this spins up a local test server
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		gexSender = invoice.NewGexSenderHTTP(
+			server.URL,
+			false,
+			&tls.Config{},
+			"",
+			"",
+		)
+		icnSequencer = sequence.NewDatabaseSequencer(db, ediinvoice.ICNSequenceName)
+	}
+
+	batchString, verrs, err := invoice.ProcessInvoiceBatch{
+		DB:                    db,
+		Logger:                logger,
+		GexSender:             gexSender,
+		SendProductionInvoice: false,
+		ICNSequencer:          icnSequencer,
+	}.Call(invoices, shipments)
+	if err != nil || verrs.HasAny() {
+		logger.Fatal("Failed to process invoice batch", zap.Error(err), zap.Error(verrs))
+	}
+
+	// Send the batch's EDI to storage for each invoice in it, as when invoicing a single shipment
+	storer := initStorer(v, logger)
+	for i := range invoices {
+		verrs, err := invoice.StoreInvoice858C{
+			DB:     db,
+			Logger: logger,
+			Storer: &storer,
+		}.Call(*batchString, &invoices[i], *approver.UserID)
+		if verrs.HasAny() {
+			logger.Error("Failed to store invoice record, with validation errors", zap.String("invoice", invoices[i].InvoiceNumber), zap.Error(verrs))
+		}
+		if err != nil {
+			logger.Error("Failed to store invoice record, with error", zap.String("invoice", invoices[i].InvoiceNumber), zap.Error(err))
+		}
+		fmt.Printf("%s\t%s\n", invoices[i].InvoiceNumber, invoices[i].Status)
+	}
+}
+
+func initStorer(v *viper.Viper, logger *zap.Logger) storage.FileStorer {
+	if v.GetString("storage-backend") == "s3" {
+		logger.Info("Using s3 storage backend")
+		s3Bucket := v.GetString("aws-s3-bucket-name")
+		s3Region := v.GetString("aws-s3-region")
+		s3KeyNamespace := v.GetString("aws-s3-key-namespace")
+		if s3Bucket == "" || s3Region == "" || s3KeyNamespace == "" {
+			logger.Fatal("Must provide aws-s3-bucket-name, aws-s3-region and aws-s3-key-namespace parameters")
+		}
+		aws := awssession.Must(awssession.NewSession(&aws.Config{
+			Region: aws.String(s3Region),
+		}))
+		return storage.NewS3(s3Bucket, s3KeyNamespace, logger, aws)
+	}
+
+	logger.Info("Using filesystem storage backend")
+	fsParams := storage.NewFilesystemParams("tmp", "storage", logger)
+	return storage.NewFilesystem(fsParams)
+}
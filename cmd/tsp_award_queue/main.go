@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"os"
 
 	"github.com/gobuffalo/pop"
 	"github.com/namsral/flag"
@@ -18,6 +19,7 @@ func main() {
 	config := flag.String("config-dir", "config", "The location of server config files")
 	env := flag.String("env", "development", "The environment to run in, configures the database, presenetly.")
 	debugLogging := flag.Bool("debug_logging", false, "log messages at the debug level.")
+	dryRun := flag.Bool("dry-run", false, "Preview the offers the award queue would make, then roll back all changes.")
 	flag.Parse()
 
 	// Set up logger for the system
@@ -44,7 +46,22 @@ func main() {
 		log.Panic(err)
 	}
 
-	awardQueue := awardqueue.NewAwardQueue(dbConnection, &honeyZapLogger)
+	awardQueue, err := awardqueue.NewAwardQueue(dbConnection, &honeyZapLogger)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	if *dryRun {
+		report, err := awardQueue.DryRun(context.Background())
+		if err != nil {
+			log.Panic(err)
+		}
+		if err := report.Write(os.Stdout); err != nil {
+			log.Panic(err)
+		}
+		return
+	}
+
 	err = awardQueue.Run(context.Background())
 	if err != nil {
 		log.Panic(err)
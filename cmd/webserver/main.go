@@ -42,6 +42,7 @@ import (
 
 	"github.com/transcom/mymove/pkg/auth"
 	"github.com/transcom/mymove/pkg/auth/authentication"
+	"github.com/transcom/mymove/pkg/awardqueue"
 	"github.com/transcom/mymove/pkg/db/sequence"
 	"github.com/transcom/mymove/pkg/dpsauth"
 	ediinvoice "github.com/transcom/mymove/pkg/edi/invoice"
@@ -52,7 +53,9 @@ import (
 	"github.com/transcom/mymove/pkg/handlers/publicapi"
 	"github.com/transcom/mymove/pkg/iws"
 	"github.com/transcom/mymove/pkg/logging"
+	"github.com/transcom/mymove/pkg/logging/hnyzap"
 	"github.com/transcom/mymove/pkg/notifications"
+	"github.com/transcom/mymove/pkg/rateengine"
 	"github.com/transcom/mymove/pkg/route"
 	"github.com/transcom/mymove/pkg/server"
 	"github.com/transcom/mymove/pkg/services"
@@ -211,6 +214,8 @@ func initFlags(flag *pflag.FlagSet) {
 	flag.String("interface", "", "The interface spec to listen for connections on. Default is all.")
 	flag.String("service-name", "app", "The service name identifies the application for instrumentation.")
 	flag.Duration("graceful-shutdown-timeout", 25*time.Second, "The duration for which the server gracefully wait for existing connections to finish.  AWS ECS only gives you 30 seconds before sending SIGKILL.")
+	flag.Duration("rate-cache-check-interval", rateengine.DefaultRateCacheCheckInterval, "How often cached tariff 400ng rates are checked against the database for newly loaded rates.")
+	flag.Int("rate-cache-size", rateengine.DefaultRateCacheSize, "The number of tariff 400ng rate lookups to cache.")
 
 	flag.String("http-my-server-name", "milmovelocal", "Hostname according to environment.")
 	flag.String("http-office-server-name", "officelocal", "Hostname according to environment.")
@@ -313,6 +318,10 @@ func initFlags(flag *pflag.FlagSet) {
 	// EIA Open Data API
 	flag.String("eia-key", "", "Key for Energy Information Administration (EIA) api")
 	flag.String("eia-url", "", "Url for Energy Information Administration (EIA) api")
+
+	// Award Queue
+	flag.Bool("award-queue-worker", false, "Run the TSP award queue on an interval inside the webserver")
+	flag.Duration("award-queue-interval", awardqueue.DefaultWorkerInterval, "How often the award queue worker offers submitted shipments to TSPs")
 }
 
 func parseCertificates(str string) []string {
@@ -580,6 +589,11 @@ func checkConfig(v *viper.Viper, logger logger) error {
 		return err
 	}
 
+	err = checkAwardQueue(v)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -721,6 +735,13 @@ func checkEIAURL(v *viper.Viper) error {
 	return nil
 }
 
+func checkAwardQueue(v *viper.Viper) error {
+	if v.GetBool("award-queue-worker") && v.GetDuration("award-queue-interval") <= 0 {
+		return fmt.Errorf("invalid award-queue-interval %s, expecting a positive duration", v.GetDuration("award-queue-interval"))
+	}
+	return nil
+}
+
 func checkStorage(v *viper.Viper) error {
 
 	storageBackend := v.GetString("storage-backend")
@@ -940,6 +961,9 @@ func main() {
 	routePlanner := initRoutePlanner(v, logger)
 	handlerContext.SetPlanner(routePlanner)
 
+	// Share one cache of tariff 400ng rates between the rate engines created by handlers
+	handlerContext.SetRateFetcher(rateengine.NewRateCache(rateengine.NewDBRateFetcher(dbConnection), v.GetDuration("rate-cache-check-interval"), v.GetInt("rate-cache-size")))
+
 	// Set SendProductionInvoice for ediinvoice
 	handlerContext.SetSendProductionInvoice(v.GetBool("send-prod-invoice"))
 
@@ -1059,6 +1083,20 @@ func main() {
 		},
 	)
 
+	// Offer submitted shipments to TSPs within minutes, rather than waiting on a scheduled task
+	var awardQueueWorker *awardqueue.Worker
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
+	if v.GetBool("award-queue-worker") {
+		awardQueueWorker = awardqueue.NewWorker(dbConnection, &hnyzap.Logger{Logger: logger}, v.GetDuration("award-queue-interval"))
+		go func() {
+			awardQueueWorker.Start(workerCtx)
+			close(workerDone)
+		}()
+	} else {
+		close(workerDone)
+	}
+
 	// Base routes
 	site := goji.NewMux()
 	// Add middleware: they are evaluated in the reverse order in which they
@@ -1092,6 +1130,10 @@ func main() {
 			data["database"] = dbErr == nil
 		}
 
+		if awardQueueWorker != nil {
+			data["awardQueue"] = awardQueueWorker.Health()
+		}
+
 		err := json.NewEncoder(w).Encode(data)
 		if err != nil {
 			logger.Error("Failed encoding health check response", zap.Error(err))
@@ -1375,6 +1417,17 @@ func main() {
 		wg.Done()
 	}()
 
+	wg.Add(1)
+	go func() {
+		stopWorker()
+		select {
+		case <-workerDone:
+		case <-ctx.Done():
+			logger.Error("Timed out waiting for the award queue worker to stop")
+		}
+		wg.Done()
+	}()
+
 	wg.Wait()
 	logger.Info("All listeners are shutdown")
 	logger.Sync()
@@ -142,6 +142,10 @@ func (suite *webServerSuite) TestConfigStorage() {
 	suite.Nil(checkStorage(suite.viper))
 }
 
+func (suite *webServerSuite) TestConfigAwardQueue() {
+	suite.Nil(checkAwardQueue(suite.viper))
+}
+
 func (suite *webServerSuite) TestConfigDatabase() {
 	suite.Nil(checkDatabase(suite.viper, suite.logger))
 }
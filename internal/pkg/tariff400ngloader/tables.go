@@ -0,0 +1,162 @@
+package tariff400ngloader
+
+import (
+	"reflect"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// table describes how rows of a tariff 400ng rate table are identified and compared
+type table struct {
+	// name is the database table name
+	name string
+	// model is the pop model a row of the table is loaded into
+	model interface{}
+	// keyColumns identify a rate, apart from the dates it is effective for
+	keyColumns []string
+	// dated is true if rows have an effective_date_lower and effective_date_upper
+	dated bool
+	// fixedValues are columns set by the loader rather than read from input files, for tables that share
+	// a database table with others, such as the unaccompanied baggage linehaul rates
+	fixedValues map[string]string
+}
+
+// Tables lists the tables the loader knows how to load, keyed by the name used on the command line
+var tables = map[string]table{
+	"linehaul_rates": {
+		name:       "tariff400ng_linehaul_rates",
+		model:      models.Tariff400ngLinehaulRate{},
+		keyColumns: []string{"type", "distance_miles_lower", "distance_miles_upper", "weight_lbs_lower", "weight_lbs_upper"},
+		dated:      true,
+	},
+	"ub_linehaul_rates": {
+		name:        "tariff400ng_linehaul_rates",
+		model:       models.Tariff400ngLinehaulRate{},
+		keyColumns:  []string{"type", "distance_miles_lower", "distance_miles_upper", "weight_lbs_lower", "weight_lbs_upper"},
+		dated:       true,
+		fixedValues: map[string]string{"type": quoter(models.LinehaulRateTypeConusUB)},
+	},
+	"shorthaul_rates": {
+		name:       "tariff400ng_shorthaul_rates",
+		model:      models.Tariff400ngShorthaulRate{},
+		keyColumns: []string{"cwt_miles_lower", "cwt_miles_upper"},
+		dated:      true,
+	},
+	"service_areas": {
+		name:       "tariff400ng_service_areas",
+		model:      models.Tariff400ngServiceArea{},
+		keyColumns: []string{"service_area"},
+		dated:      true,
+	},
+	"full_pack_rates": {
+		name:       "tariff400ng_full_pack_rates",
+		model:      models.Tariff400ngFullPackRate{},
+		keyColumns: []string{"schedule", "weight_lbs_lower", "weight_lbs_upper"},
+		dated:      true,
+	},
+	"full_unpack_rates": {
+		name:       "tariff400ng_full_unpack_rates",
+		model:      models.Tariff400ngFullUnpackRate{},
+		keyColumns: []string{"schedule"},
+		dated:      true,
+	},
+	"item_rates": {
+		name:       "tariff400ng_item_rates",
+		model:      models.Tariff400ngItemRate{},
+		keyColumns: []string{"code", "schedule", "weight_lbs_lower", "weight_lbs_upper"},
+		dated:      true,
+	},
+	"international_rates": {
+		name:       "tariff400ng_international_rates",
+		model:      models.Tariff400ngInternationalRate{},
+		keyColumns: []string{"origin_rate_area", "destination_rate_area", "weight_lbs_lower", "weight_lbs_upper"},
+		dated:      true,
+	},
+	"zip3s": {
+		name:       "tariff400ng_zip3s",
+		model:      models.Tariff400ngZip3{},
+		keyColumns: []string{"zip3"},
+	},
+	"zip5_rate_areas": {
+		name:       "tariff400ng_zip5_rate_areas",
+		model:      models.Tariff400ngZip5RateArea{},
+		keyColumns: []string{"zip5"},
+	},
+}
+
+// TableNames returns the names tables can be loaded by, in a stable order
+func TableNames() []string {
+	return []string{
+		"linehaul_rates",
+		"ub_linehaul_rates",
+		"shorthaul_rates",
+		"service_areas",
+		"full_pack_rates",
+		"full_unpack_rates",
+		"item_rates",
+		"international_rates",
+		"zip3s",
+		"zip5_rate_areas",
+	}
+}
+
+// Columns managed by the loader rather than read from input files
+var generatedColumns = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// column is a field of a table's model that is read from input files
+type column struct {
+	name      string
+	fieldType reflect.Type
+	index     int
+}
+
+// columns returns the columns of the table that are read from input files, in model field order
+func (t table) columns() []column {
+	modelType := reflect.TypeOf(t.model)
+
+	var columns []column
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		tag := field.Tag.Get("db")
+		if tag == "" || tag == "-" || generatedColumns[tag] {
+			continue
+		}
+		columns = append(columns, column{name: tag, fieldType: field.Type, index: i})
+	}
+
+	return columns
+}
+
+// inputColumns returns the columns of the table that are read from input files, leaving out fixed values
+func (t table) inputColumns() []column {
+	var columns []column
+	for _, c := range t.columns() {
+		if _, ok := t.fixedValues[c.name]; !ok {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+// hasFixedValues reports whether a row belongs to the table, when it shares a database table with others
+func (t table) hasFixedValues(r row) bool {
+	for name, value := range t.fixedValues {
+		if r.values[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (t table) isKeyColumn(name string) bool {
+	for _, keyColumn := range t.keyColumns {
+		if keyColumn == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,501 @@
+package tariff400ngloader
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/tealeg/xlsx"
+	"go.uber.org/zap"
+)
+
+const dateFormat = "2006-01-02"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Loader reads published tariff 400ng rate tables, checks them against the rows already in the
+// database and builds the statements needed to load them
+type Loader struct {
+	db     *pop.Connection
+	logger *zap.Logger
+}
+
+// NewLoader returns a new instance of a Loader
+func NewLoader(db *pop.Connection, logger *zap.Logger) Loader {
+	return Loader{
+		db,
+		logger,
+	}
+}
+
+// row is a row of a rate table with each column formatted as a SQL literal
+type row struct {
+	id             uuid.UUID
+	values         map[string]string
+	effectiveLower time.Time
+	effectiveUpper time.Time
+}
+
+// effectiveRange is a row considered when looking for gaps, and whether it will be inserted or updated
+type effectiveRange struct {
+	row
+	loaded bool
+}
+
+// change pairs an existing row with the input row that replaces its values
+type change struct {
+	existing row
+	updated  row
+}
+
+// TableDiff is the result of comparing an input file to the rows already in a table
+type TableDiff struct {
+	table     table
+	added     []row
+	changed   []change
+	Unchanged int
+	// Problems are effective date overlaps and duplicates that prevent the table from being loaded
+	Problems []string
+	// Warnings are effective date gaps that may be intended, such as a rate that is being retired
+	Warnings []string
+}
+
+// Table returns the name of the table that was compared
+func (d TableDiff) Table() string {
+	return d.table.name
+}
+
+// Added returns the number of rows that will be inserted
+func (d TableDiff) Added() int {
+	return len(d.added)
+}
+
+// Changed returns the number of existing rows that will be updated
+func (d TableDiff) Changed() int {
+	return len(d.changed)
+}
+
+// HasProblems returns true if the table can't be loaded as is
+func (d TableDiff) HasProblems() bool {
+	return len(d.Problems) > 0
+}
+
+// Wraps a string value in single-quotes to play nice with psql syntax
+func quoter(s string) string {
+	return fmt.Sprintf("'%s'", strings.Replace(s, "'", "''", -1))
+}
+
+// literalFromCell parses a cell from an input file into a SQL literal for a column of the given type
+func literalFromCell(cell string, fieldType reflect.Type) (string, error) {
+	cell = strings.TrimSpace(cell)
+
+	if fieldType == timeType {
+		date, err := time.Parse(dateFormat, cell)
+		if err != nil {
+			return "", errors.Errorf("%q is not a date formatted as %s", cell, dateFormat)
+		}
+		return quoter(date.Format(dateFormat)), nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return quoter(cell), nil
+	case reflect.Int, reflect.Int64:
+		i, err := strconv.Atoi(strings.Replace(cell, ",", "", -1))
+		if err != nil {
+			return "", errors.Errorf("%q is not a whole number", cell)
+		}
+		return strconv.Itoa(i), nil
+	case reflect.Ptr:
+		if cell == "" {
+			return "NULL", nil
+		}
+		return literalFromCell(cell, fieldType.Elem())
+	default:
+		return "", errors.Errorf("Columns of type %s are not supported", fieldType)
+	}
+}
+
+// literalFromValue formats a field of an existing model as a SQL literal
+func literalFromValue(v reflect.Value) string {
+	if v.Type() == timeType {
+		return quoter(v.Interface().(time.Time).UTC().Format(dateFormat))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return quoter(v.String())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "NULL"
+		}
+		return literalFromValue(v.Elem())
+	default:
+		return ""
+	}
+}
+
+// effectiveDates sets the effective date range of a row of a dated table from its values
+func (r *row) effectiveDates() error {
+	var err error
+	r.effectiveLower, err = time.Parse(dateFormat, strings.Trim(r.values["effective_date_lower"], "'"))
+	if err != nil {
+		return err
+	}
+	r.effectiveUpper, err = time.Parse(dateFormat, strings.Trim(r.values["effective_date_upper"], "'"))
+	if err != nil {
+		return err
+	}
+	if !r.effectiveLower.Before(r.effectiveUpper) {
+		return errors.Errorf("effective_date_lower %s is not before effective_date_upper %s",
+			r.effectiveLower.Format(dateFormat), r.effectiveUpper.Format(dateFormat))
+	}
+	return nil
+}
+
+func (r row) overlaps(other row) bool {
+	return r.effectiveLower.Before(other.effectiveUpper) && other.effectiveLower.Before(r.effectiveUpper)
+}
+
+func (r row) sameDates(other row) bool {
+	return r.effectiveLower.Equal(other.effectiveLower) && r.effectiveUpper.Equal(other.effectiveUpper)
+}
+
+func (t table) key(r row) string {
+	parts := make([]string, len(t.keyColumns))
+	for i, name := range t.keyColumns {
+		parts[i] = fmt.Sprintf("%s=%s", name, r.values[name])
+	}
+	return strings.Join(parts, " ")
+}
+
+func (t table) describe(r row) string {
+	if !t.dated {
+		return t.key(r)
+	}
+	return fmt.Sprintf("%s [%s, %s)", t.key(r), r.effectiveLower.Format(dateFormat), r.effectiveUpper.Format(dateFormat))
+}
+
+// ReadRecords reads every row of a CSV file, or of the first sheet of an XLSX file
+func ReadRecords(path string) ([][]string, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".xlsx" {
+		xlFile, err := xlsx.OpenFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(xlFile.Sheets) == 0 {
+			return nil, errors.Errorf("%s has no sheets", path)
+		}
+
+		var records [][]string
+		for _, xlRow := range xlFile.Sheets[0].Rows {
+			record := make([]string, len(xlRow.Cells))
+			for i, cell := range xlRow.Cells {
+				record[i] = cell.String()
+			}
+			records = append(records, record)
+		}
+		return records, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return csv.NewReader(f).ReadAll()
+}
+
+// parseRows reads the rows of an input file, whose header row names the table's columns
+func (l *Loader) parseRows(t table, path string) ([]row, error) {
+	records, err := ReadRecords(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.Errorf("%s is empty", path)
+	}
+
+	positions := map[string]int{}
+	for i, name := range records[0] {
+		positions[strings.TrimSpace(name)] = i
+	}
+	columns := t.inputColumns()
+	for _, c := range columns {
+		if _, ok := positions[c.name]; !ok {
+			return nil, errors.Errorf("%s is missing the %s column", path, c.name)
+		}
+	}
+	if len(positions) != len(columns) {
+		return nil, errors.Errorf("%s has %d columns, expected %d for %s", path, len(positions), len(columns), t.name)
+	}
+
+	var rows []row
+	for i, record := range records[1:] {
+		r := row{values: map[string]string{}}
+		for name, value := range t.fixedValues {
+			r.values[name] = value
+		}
+		for _, c := range columns {
+			cell := ""
+			if positions[c.name] < len(record) {
+				cell = record[positions[c.name]]
+			}
+			r.values[c.name], err = literalFromCell(cell, c.fieldType)
+			if err != nil {
+				// Line numbers count the header row
+				return nil, errors.Wrapf(err, "%s line %d, column %s", path, i+2, c.name)
+			}
+		}
+		if t.dated {
+			if err := r.effectiveDates(); err != nil {
+				return nil, errors.Wrapf(err, "%s line %d", path, i+2)
+			}
+		}
+		rows = append(rows, r)
+	}
+
+	return rows, nil
+}
+
+// fetchRows returns the rows already in the table, leaving out those belonging to another table that shares it
+func (l *Loader) fetchRows(t table) ([]row, error) {
+	existing := reflect.New(reflect.SliceOf(reflect.TypeOf(t.model)))
+	err := l.db.All(existing.Interface())
+	if err != nil {
+		return nil, errors.Wrapf(err, "Fetching existing %s", t.name)
+	}
+
+	columns := t.columns()
+	var rows []row
+	for i := 0; i < existing.Elem().Len(); i++ {
+		model := existing.Elem().Index(i)
+		r := row{
+			id:     model.FieldByName("ID").Interface().(uuid.UUID),
+			values: map[string]string{},
+		}
+		for _, c := range columns {
+			r.values[c.name] = literalFromValue(model.Field(c.index))
+		}
+		if !t.hasFixedValues(r) {
+			continue
+		}
+		if t.dated {
+			if err := r.effectiveDates(); err != nil {
+				return nil, errors.Wrapf(err, "Existing %s row %s", t.name, r.id)
+			}
+		}
+		rows = append(rows, r)
+	}
+
+	return rows, nil
+}
+
+// Diff compares the rows of an input file to those already in the named table
+func (l *Loader) Diff(tableName string, path string) (TableDiff, error) {
+	t, ok := tables[tableName]
+	if !ok {
+		return TableDiff{}, errors.Errorf("Unknown table %s", tableName)
+	}
+
+	inputRows, err := l.parseRows(t, path)
+	if err != nil {
+		return TableDiff{}, err
+	}
+	existingRows, err := l.fetchRows(t)
+	if err != nil {
+		return TableDiff{}, err
+	}
+
+	diff := compareRows(t, existingRows, inputRows)
+	l.logger.Info("Compared rate table",
+		zap.String("table", t.name),
+		zap.Int("added", diff.Added()),
+		zap.Int("changed", diff.Changed()),
+		zap.Int("unchanged", diff.Unchanged),
+		zap.Int("problems", len(diff.Problems)),
+		zap.Int("warnings", len(diff.Warnings)),
+	)
+
+	return diff, nil
+}
+
+// compareRows works out which input rows are new or change an existing row, and checks the
+// effective dates of each rate for overlaps and gaps
+func compareRows(t table, existingRows []row, inputRows []row) TableDiff {
+	diff := TableDiff{table: t}
+
+	existingByKey := map[string][]row{}
+	for _, r := range existingRows {
+		existingByKey[t.key(r)] = append(existingByKey[t.key(r)], r)
+	}
+	inputByKey := map[string][]row{}
+	var keys []string
+	for _, r := range inputRows {
+		key := t.key(r)
+		if _, ok := inputByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		inputByKey[key] = append(inputByKey[key], r)
+	}
+
+	for _, key := range keys {
+		inputs := inputByKey[key]
+		existing := existingByKey[key]
+
+		// Input rows for the same rate must not overlap each other
+		for i := range inputs {
+			for j := i + 1; j < len(inputs); j++ {
+				if !t.dated || inputs[i].overlaps(inputs[j]) {
+					diff.Problems = append(diff.Problems, fmt.Sprintf("%s: %s is duplicated by %s in the input",
+						t.name, t.describe(inputs[i]), t.describe(inputs[j])))
+				}
+			}
+		}
+
+		// Ranges in effect once the input is loaded, used to look for gaps
+		var ranges []effectiveRange
+		replaced := map[uuid.UUID]bool{}
+		for _, input := range inputs {
+			var match *row
+			for i, e := range existing {
+				if !t.dated || input.sameDates(e) {
+					match = &existing[i]
+					break
+				}
+				if input.overlaps(e) {
+					diff.Problems = append(diff.Problems, fmt.Sprintf("%s: %s overlaps existing row %s",
+						t.name, t.describe(input), t.describe(e)))
+				}
+			}
+
+			loaded := true
+			if match == nil {
+				input.id = uuid.Must(uuid.NewV4())
+				diff.added = append(diff.added, input)
+			} else if reflect.DeepEqual(match.values, input.values) {
+				diff.Unchanged++
+				loaded = false
+			} else {
+				input.id = match.id
+				diff.changed = append(diff.changed, change{existing: *match, updated: input})
+			}
+			if match != nil {
+				replaced[match.id] = true
+			}
+			ranges = append(ranges, effectiveRange{input, loaded})
+		}
+
+		if !t.dated {
+			continue
+		}
+		for _, e := range existing {
+			if !replaced[e.id] {
+				ranges = append(ranges, effectiveRange{e, false})
+			}
+		}
+		sort.Slice(ranges, func(i, j int) bool {
+			return ranges[i].effectiveLower.Before(ranges[j].effectiveLower)
+		})
+		for i := 1; i < len(ranges); i++ {
+			prev, next := ranges[i-1], ranges[i]
+			// Only gaps next to a loaded row are reported, older gaps aren't the input's concern
+			if (prev.loaded || next.loaded) && prev.effectiveUpper.Before(next.effectiveLower) {
+				diff.Warnings = append(diff.Warnings, fmt.Sprintf("%s: %s has no rate from %s to %s",
+					t.name, key, prev.effectiveUpper.Format(dateFormat), next.effectiveLower.Format(dateFormat)))
+			}
+		}
+	}
+
+	return diff
+}
+
+// WriteSummary prints the rows that will be added and the values that will change
+func (d TableDiff) WriteSummary(w io.Writer) {
+	fmt.Fprintf(w, "%s: %d added, %d changed, %d unchanged\n", d.table.name, d.Added(), d.Changed(), d.Unchanged)
+	for _, r := range d.added {
+		fmt.Fprintf(w, "  + %s\n", d.table.describe(r))
+	}
+	for _, c := range d.changed {
+		var changes []string
+		for _, col := range d.table.columns() {
+			before, after := c.existing.values[col.name], c.updated.values[col.name]
+			if before != after {
+				changes = append(changes, fmt.Sprintf("%s %s -> %s", col.name, before, after))
+			}
+		}
+		fmt.Fprintf(w, "  ~ %s: %s\n", d.table.describe(c.updated), strings.Join(changes, ", "))
+	}
+	for _, problem := range d.Problems {
+		fmt.Fprintf(w, "  ! %s\n", problem)
+	}
+	for _, warning := range d.Warnings {
+		fmt.Fprintf(w, "  ? %s\n", warning)
+	}
+}
+
+// Statements returns the INSERT and UPDATE statements that load the diff
+func (d TableDiff) Statements() []string {
+	columns := d.table.columns()
+
+	var statements []string
+	for _, r := range d.added {
+		names := []string{"id"}
+		values := []string{quoter(r.id.String())}
+		for _, c := range columns {
+			names = append(names, c.name)
+			values = append(values, r.values[c.name])
+		}
+		names = append(names, "created_at", "updated_at")
+		values = append(values, "now()", "now()")
+
+		// Values here should only come from trusted rate tables, and migration should be inspected after before being run
+		// #nosec G201
+		statements = append(statements, fmt.Sprintf("INSERT into %s (%s) VALUES (%s);",
+			d.table.name, strings.Join(names, ", "), strings.Join(values, ", ")))
+	}
+	for _, c := range d.changed {
+		var sets []string
+		for _, col := range columns {
+			if c.existing.values[col.name] != c.updated.values[col.name] {
+				sets = append(sets, fmt.Sprintf("%s = %s", col.name, c.updated.values[col.name]))
+			}
+		}
+		sets = append(sets, "updated_at = now()")
+
+		// #nosec G201
+		statements = append(statements, fmt.Sprintf("UPDATE %s SET %s WHERE id = %s;",
+			d.table.name, strings.Join(sets, ", "), quoter(c.updated.id.String())))
+	}
+
+	return statements
+}
+
+// Load runs the statements for every diff in a single transaction
+func (l *Loader) Load(diffs []TableDiff) error {
+	return l.db.Transaction(func(tx *pop.Connection) error {
+		for _, diff := range diffs {
+			for _, statement := range diff.Statements() {
+				if err := tx.RawQuery(statement).Exec(); err != nil {
+					return errors.Wrapf(err, "Loading %s", diff.Table())
+				}
+			}
+			l.logger.Info("Loaded rate table", zap.String("table", diff.Table()),
+				zap.Int("added", diff.Added()), zap.Int("changed", diff.Changed()))
+		}
+		return nil
+	})
+}
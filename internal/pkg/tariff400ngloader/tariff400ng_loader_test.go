@@ -0,0 +1,254 @@
+package tariff400ngloader
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+type Tariff400ngLoaderSuite struct {
+	suite.Suite
+	db     *pop.Connection
+	logger *zap.Logger
+}
+
+func (suite *Tariff400ngLoaderSuite) SetupTest() {
+	suite.db.TruncateAll()
+}
+
+func (suite *Tariff400ngLoaderSuite) mustSave(model interface{}) {
+	t := suite.T()
+	t.Helper()
+
+	verrs, err := suite.db.ValidateAndSave(model)
+	if err != nil {
+		suite.T().Errorf("Errors encountered saving %v: %v", model, err)
+	}
+	if verrs.HasAny() {
+		suite.T().Errorf("Validation errors encountered saving %v: %v", model, verrs)
+	}
+}
+
+func TestTariff400ngLoaderSuite(t *testing.T) {
+	configLocation := "../../../config"
+	pop.AddLookupPaths(configLocation)
+	db, err := pop.Connect("test")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	hs := &Tariff400ngLoaderSuite{
+		db:     db,
+		logger: logger,
+	}
+
+	suite.Run(t, hs)
+}
+
+func (suite *Tariff400ngLoaderSuite) TestLiteralFromCell() {
+	schedule := 1
+	cases := []struct {
+		cell     string
+		value    interface{}
+		expected string
+	}{
+		{"Gulfport, MS", "", "'Gulfport, MS'"},
+		{"O'Hare", "", "'O''Hare'"},
+		{"1,402", unit.Cents(0), "1402"},
+		{" 53 ", 0, "53"},
+		{"", &schedule, "NULL"},
+		{"3", &schedule, "3"},
+		{"2019-05-15", time.Time{}, "'2019-05-15'"},
+	}
+	for _, c := range cases {
+		literal, err := literalFromCell(c.cell, reflect.TypeOf(c.value))
+		if suite.NoError(err) {
+			suite.Equal(c.expected, literal)
+		}
+	}
+
+	_, err := literalFromCell("05/15/2019", reflect.TypeOf(time.Time{}))
+	suite.Error(err)
+	_, err = literalFromCell("12.5", reflect.TypeOf(0))
+	suite.Error(err)
+}
+
+func (suite *Tariff400ngLoaderSuite) TestParseRows() {
+	loader := NewLoader(suite.db, suite.logger)
+
+	rows, err := loader.parseRows(tables["service_areas"], "./testdata/service_areas.csv")
+	if suite.NoError(err) && suite.Len(rows, 2) {
+		suite.Equal("'Gulfport, MS'", rows[0].values["name"])
+		suite.Equal("60", rows[0].values["linehaul_factor"])
+		suite.Equal(time.Date(2019, time.May, 15, 0, 0, 0, 0, time.UTC), rows[0].effectiveLower)
+		suite.Equal(time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC), rows[0].effectiveUpper)
+	}
+
+	// A file for another table is missing columns
+	_, err = loader.parseRows(tables["shorthaul_rates"], "./testdata/service_areas.csv")
+	suite.Error(err)
+}
+
+func (suite *Tariff400ngLoaderSuite) serviceAreaRow(serviceArea string, linehaulFactor string, lower string, upper string) row {
+	r := row{values: map[string]string{
+		"service_area":         quoter(serviceArea),
+		"linehaul_factor":      linehaulFactor,
+		"effective_date_lower": quoter(lower),
+		"effective_date_upper": quoter(upper),
+	}}
+	suite.NoError(r.effectiveDates())
+	return r
+}
+
+func (suite *Tariff400ngLoaderSuite) TestCompareRows() {
+	t := tables["service_areas"]
+	existing := suite.serviceAreaRow("428", "57", "2018-05-15", "2019-05-15")
+	existing.id = uuid.Must(uuid.NewV4())
+	unchanged := suite.serviceAreaRow("4", "54", "2018-05-15", "2019-05-15")
+	unchanged.id = uuid.Must(uuid.NewV4())
+
+	suite.T().Run("added, changed and unchanged", func(t2 *testing.T) {
+		diff := compareRows(t, []row{existing, unchanged}, []row{
+			suite.serviceAreaRow("428", "60", "2018-05-15", "2019-05-15"),
+			suite.serviceAreaRow("428", "61", "2019-05-15", "2020-05-15"),
+			suite.serviceAreaRow("4", "54", "2018-05-15", "2019-05-15"),
+		})
+		suite.Equal(1, diff.Added())
+		suite.Equal(1, diff.Changed())
+		suite.Equal(1, diff.Unchanged)
+		suite.Empty(diff.Problems)
+		suite.Empty(diff.Warnings)
+
+		var summary bytes.Buffer
+		diff.WriteSummary(&summary)
+		suite.Contains(summary.String(), "linehaul_factor 57 -> 60")
+	})
+
+	suite.T().Run("overlap with existing row", func(t2 *testing.T) {
+		diff := compareRows(t, []row{existing}, []row{
+			suite.serviceAreaRow("428", "60", "2019-01-01", "2020-05-15"),
+		})
+		suite.Len(diff.Problems, 1)
+		suite.True(diff.HasProblems())
+	})
+
+	suite.T().Run("overlap within input", func(t2 *testing.T) {
+		diff := compareRows(t, []row{}, []row{
+			suite.serviceAreaRow("428", "60", "2019-05-15", "2020-05-15"),
+			suite.serviceAreaRow("428", "61", "2019-06-01", "2020-05-15"),
+		})
+		suite.Len(diff.Problems, 1)
+	})
+
+	suite.T().Run("gap after existing row", func(t2 *testing.T) {
+		diff := compareRows(t, []row{existing}, []row{
+			suite.serviceAreaRow("428", "60", "2019-06-01", "2020-05-15"),
+		})
+		suite.Empty(diff.Problems)
+		if suite.Len(diff.Warnings, 1) {
+			suite.Contains(diff.Warnings[0], "from 2019-05-15 to 2019-06-01")
+		}
+	})
+}
+
+func (suite *Tariff400ngLoaderSuite) TestDiffAndLoad() {
+	existing := models.Tariff400ngServiceArea{
+		Name:               "Gulfport, MS",
+		ServiceArea:        "428",
+		ServicesSchedule:   1,
+		LinehaulFactor:     57,
+		ServiceChargeCents: 350,
+		EffectiveDateLower: time.Date(2019, time.May, 15, 0, 0, 0, 0, time.UTC),
+		EffectiveDateUpper: time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC),
+		SIT185ARateCents:   1402,
+		SIT185BRateCents:   53,
+		SITPDSchedule:      1,
+	}
+	suite.mustSave(&existing)
+
+	loader := NewLoader(suite.db, suite.logger)
+	diff, err := loader.Diff("service_areas", "./testdata/service_areas.csv")
+	suite.NoError(err)
+	suite.Equal(1, diff.Added())
+	suite.Equal(1, diff.Changed())
+	suite.False(diff.HasProblems())
+
+	statements := diff.Statements()
+	if suite.Len(statements, 2) {
+		suite.Contains(statements[0], "INSERT into tariff400ng_service_areas")
+		suite.Equal("UPDATE tariff400ng_service_areas SET linehaul_factor = 60, updated_at = now() WHERE id = '"+existing.ID.String()+"';", statements[1])
+	}
+
+	suite.NoError(loader.Load([]TableDiff{diff}))
+
+	var serviceAreas []models.Tariff400ngServiceArea
+	suite.NoError(suite.db.Order("service_area").All(&serviceAreas))
+	if suite.Len(serviceAreas, 2) {
+		suite.Equal("4", serviceAreas[0].ServiceArea)
+		suite.Equal(unit.Cents(60), serviceAreas[1].LinehaulFactor)
+	}
+
+	// Loading the same file again changes nothing
+	diff, err = loader.Diff("service_areas", "./testdata/service_areas.csv")
+	suite.NoError(err)
+	suite.Equal(0, diff.Added())
+	suite.Equal(0, diff.Changed())
+	suite.Equal(2, diff.Unchanged)
+}
+
+func (suite *Tariff400ngLoaderSuite) TestDiffAndLoadUBLinehaulRates() {
+	// An HHG rate for the same band is left alone, since UB rates share its table
+	hhgRate := models.Tariff400ngLinehaulRate{
+		DistanceMilesLower: 1001,
+		DistanceMilesUpper: 1101,
+		WeightLbsLower:     1000,
+		WeightLbsUpper:     1100,
+		RateCents:          1402,
+		Type:               models.LinehaulRateTypeConus,
+		EffectiveDateLower: time.Date(2019, time.May, 15, 0, 0, 0, 0, time.UTC),
+		EffectiveDateUpper: time.Date(2020, time.May, 15, 0, 0, 0, 0, time.UTC),
+	}
+	suite.mustSave(&hhgRate)
+
+	loader := NewLoader(suite.db, suite.logger)
+	diff, err := loader.Diff("ub_linehaul_rates", "./testdata/ub_linehaul_rates.csv")
+	suite.NoError(err)
+	suite.Equal(2, diff.Added())
+	suite.Equal(0, diff.Changed())
+	suite.Equal(0, diff.Unchanged)
+	suite.False(diff.HasProblems())
+
+	suite.NoError(loader.Load([]TableDiff{diff}))
+
+	var ubRates []models.Tariff400ngLinehaulRate
+	suite.NoError(suite.db.Where("type = ?", models.LinehaulRateTypeConusUB).Order("weight_lbs_lower").All(&ubRates))
+	if suite.Len(ubRates, 2) {
+		suite.Equal(unit.Cents(2140), ubRates[0].RateCents)
+		suite.Equal(unit.Pound(1100), ubRates[1].WeightLbsLower)
+	}
+
+	var fetchedHHGRate models.Tariff400ngLinehaulRate
+	suite.NoError(suite.db.Find(&fetchedHHGRate, hhgRate.ID))
+	suite.Equal(hhgRate.RateCents, fetchedHHGRate.RateCents)
+
+	// Loading the same file again changes nothing
+	diff, err = loader.Diff("ub_linehaul_rates", "./testdata/ub_linehaul_rates.csv")
+	suite.NoError(err)
+	suite.Equal(0, diff.Added())
+	suite.Equal(2, diff.Unchanged)
+}
@@ -0,0 +1,291 @@
+package tspperformanceloader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/internal/pkg/tariff400ngloader"
+	"github.com/transcom/mymove/pkg/awardqueue"
+	"github.com/transcom/mymove/pkg/logging/hnyzap"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+const dateFormat = "2006-01-02"
+
+// columns are the columns of the quarterly best value score file, in the order they are reported
+var columns = []string{
+	"scac",
+	"source_rate_area",
+	"destination_region",
+	"code_of_service",
+	"best_value_score",
+	"linehaul_rate",
+	"sit_rate",
+}
+
+// Loader reads the quarterly best value score and discount file published by SDDC, checks it
+// against the TDLs and TSPs in the database and saves it as a new performance period
+type Loader struct {
+	db     *pop.Connection
+	logger *zap.Logger
+}
+
+// NewLoader returns a new instance of a Loader
+func NewLoader(db *pop.Connection, logger *zap.Logger) Loader {
+	return Loader{
+		db,
+		logger,
+	}
+}
+
+// PerformancePeriod is the result of reading a best value score file for one performance period
+type PerformancePeriod struct {
+	Start          time.Time
+	End            time.Time
+	RateCycleStart time.Time
+	RateCycleEnd   time.Time
+	performances   models.TransportationServiceProviderPerformances
+	// descriptions describe each performance by SCAC and TDL, for the summary
+	descriptions []string
+	// Problems are rows that can't be matched to a TSP or TDL, or that duplicate an existing performance
+	Problems []string
+}
+
+// Added returns the number of TSP performances that will be created
+func (p PerformancePeriod) Added() int {
+	return len(p.performances)
+}
+
+// HasProblems returns true if the performance period can't be loaded as is
+func (p PerformancePeriod) HasProblems() bool {
+	return len(p.Problems) > 0
+}
+
+// WriteSummary prints the TSP performances that will be created and any problems found
+func (p PerformancePeriod) WriteSummary(w io.Writer) {
+	fmt.Fprintf(w, "Performance period %s to %s (rate cycle %s to %s): %d added\n",
+		p.Start.Format(dateFormat), p.End.Format(dateFormat),
+		p.RateCycleStart.Format(dateFormat), p.RateCycleEnd.Format(dateFormat), p.Added())
+	for i, description := range p.descriptions {
+		perf := p.performances[i]
+		fmt.Fprintf(w, "  + %s: bvs %.4f, linehaul %.4f, sit %.4f\n",
+			description, perf.BestValueScore, perf.LinehaulRate.Float64(), perf.SITRate.Float64())
+	}
+	for _, problem := range p.Problems {
+		fmt.Fprintf(w, "  ! %s\n", problem)
+	}
+}
+
+// rateCycleFor returns the rate cycle that a performance period starting on date falls in. The peak
+// rate cycle runs from May 15 to September 30; the non-peak cycle runs from October 1 to May 14.
+func rateCycleFor(date time.Time) (time.Time, time.Time) {
+	peakStart, peakEnd := models.GetRateCycle(date.Year(), true)
+	if !date.Before(peakStart) && !date.After(peakEnd) {
+		return peakStart, peakEnd
+	}
+	if date.Before(peakStart) {
+		return models.GetRateCycle(date.Year()-1, false)
+	}
+	return models.GetRateCycle(date.Year(), false)
+}
+
+func tdlKey(rateArea string, region string, codeOfService string) string {
+	return fmt.Sprintf("%s %s %s", rateArea, region, codeOfService)
+}
+
+// parsePercent parses a percentage from the file, which may include a trailing percent sign
+func parsePercent(cell string) (float64, error) {
+	cell = strings.TrimSuffix(strings.TrimSpace(cell), "%")
+	value, err := strconv.ParseFloat(cell, 64)
+	if err != nil {
+		return 0, errors.Errorf("%q is not a number", cell)
+	}
+	if value < 0 || value > 100 {
+		return 0, errors.Errorf("%s is not between 0 and 100", cell)
+	}
+	return value, nil
+}
+
+// Read parses a CSV or XLSX best value score file and checks each row against the TDLs, TSPs and
+// TSP performances already in the database. Rows that can't be loaded are reported as problems.
+func (l *Loader) Read(path string, start time.Time, end time.Time) (PerformancePeriod, error) {
+	if !start.Before(end) {
+		return PerformancePeriod{}, errors.Errorf("performance period start %s is not before its end %s",
+			start.Format(dateFormat), end.Format(dateFormat))
+	}
+	period := PerformancePeriod{Start: start, End: end}
+	period.RateCycleStart, period.RateCycleEnd = rateCycleFor(start)
+	if end.After(period.RateCycleEnd) {
+		return PerformancePeriod{}, errors.Errorf("performance period %s to %s spans more than one rate cycle",
+			start.Format(dateFormat), end.Format(dateFormat))
+	}
+
+	records, err := tariff400ngloader.ReadRecords(path)
+	if err != nil {
+		return PerformancePeriod{}, err
+	}
+	if len(records) == 0 {
+		return PerformancePeriod{}, errors.Errorf("%s is empty", path)
+	}
+
+	positions := map[string]int{}
+	for i, name := range records[0] {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range columns {
+		if _, ok := positions[name]; !ok {
+			return PerformancePeriod{}, errors.Errorf("%s is missing the %s column", path, name)
+		}
+	}
+
+	var tsps []models.TransportationServiceProvider
+	if err := l.db.All(&tsps); err != nil {
+		return PerformancePeriod{}, errors.Wrap(err, "Fetching TSPs")
+	}
+	tspsBySCAC := map[string]models.TransportationServiceProvider{}
+	for _, tsp := range tsps {
+		tspsBySCAC[tsp.StandardCarrierAlphaCode] = tsp
+	}
+
+	var tdls models.TrafficDistributionLists
+	if err := l.db.All(&tdls); err != nil {
+		return PerformancePeriod{}, errors.Wrap(err, "Fetching TDLs")
+	}
+	tdlsByKey := map[string]models.TrafficDistributionList{}
+	for _, tdl := range tdls {
+		tdlsByKey[tdlKey(tdl.SourceRateArea, tdl.DestinationRegion, tdl.CodeOfService)] = tdl
+	}
+
+	// Performances for the same TSP and TDL can't overlap, whether they are already loaded or in the file
+	var overlapping models.TransportationServiceProviderPerformances
+	err = l.db.
+		Where("performance_period_start <= ?", end).
+		Where("performance_period_end >= ?", start).
+		All(&overlapping)
+	if err != nil {
+		return PerformancePeriod{}, errors.Wrap(err, "Fetching existing TSP performances")
+	}
+	existing := map[string]models.TransportationServiceProviderPerformance{}
+	for _, perf := range overlapping {
+		existing[perf.TransportationServiceProviderID.String()+perf.TrafficDistributionListID.String()] = perf
+	}
+	seen := map[string]int{}
+
+	for i, record := range records[1:] {
+		// Line numbers count the header row
+		line := i + 2
+		cell := func(name string) string {
+			if positions[name] < len(record) {
+				return strings.TrimSpace(record[positions[name]])
+			}
+			return ""
+		}
+
+		scac := strings.ToUpper(cell("scac"))
+		// SDDC files sometimes give the destination as "REGION 1" rather than the "1" used by TDLs
+		region := strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(cell("destination_region")), "REGION"))
+		key := tdlKey(strings.ToUpper(cell("source_rate_area")), region, strings.ToUpper(cell("code_of_service")))
+		description := fmt.Sprintf("%s in TDL %s", scac, key)
+
+		tsp, ok := tspsBySCAC[scac]
+		if !ok {
+			period.Problems = append(period.Problems, fmt.Sprintf("line %d: no TSP has the SCAC %q", line, scac))
+			continue
+		}
+		tdl, ok := tdlsByKey[key]
+		if !ok {
+			period.Problems = append(period.Problems, fmt.Sprintf("line %d: TDL %s does not exist", line, key))
+			continue
+		}
+
+		var values [3]float64
+		valid := true
+		for j, name := range []string{"best_value_score", "linehaul_rate", "sit_rate"} {
+			values[j], err = parsePercent(cell(name))
+			if err != nil {
+				period.Problems = append(period.Problems, fmt.Sprintf("line %d: %s %s", line, name, err))
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		perfKey := tsp.ID.String() + tdl.ID.String()
+		if other, ok := seen[perfKey]; ok {
+			period.Problems = append(period.Problems, fmt.Sprintf("line %d: %s is duplicated by line %d", line, description, other))
+			continue
+		}
+		seen[perfKey] = line
+		if perf, ok := existing[perfKey]; ok {
+			period.Problems = append(period.Problems, fmt.Sprintf("line %d: %s already has a performance from %s to %s",
+				line, description, perf.PerformancePeriodStart.Format(dateFormat), perf.PerformancePeriodEnd.Format(dateFormat)))
+			continue
+		}
+
+		period.performances = append(period.performances, models.TransportationServiceProviderPerformance{
+			PerformancePeriodStart:          start,
+			PerformancePeriodEnd:            end,
+			RateCycleStart:                  period.RateCycleStart,
+			RateCycleEnd:                    period.RateCycleEnd,
+			TrafficDistributionListID:       tdl.ID,
+			TransportationServiceProviderID: tsp.ID,
+			BestValueScore:                  values[0],
+			LinehaulRate:                    unit.NewDiscountRateFromPercent(values[1]),
+			SITRate:                         unit.NewDiscountRateFromPercent(values[2]),
+		})
+		period.descriptions = append(period.descriptions, description)
+	}
+
+	l.logger.Info("Read best value score file",
+		zap.String("path", path),
+		zap.Int("added", period.Added()),
+		zap.Int("problems", len(period.Problems)),
+	)
+
+	return period, nil
+}
+
+// Load saves the TSP performances in a single transaction, then has the award queue assign them
+// quality bands so they are used for the next shipment offered in their TDL. If banding fails the
+// performances stay saved, and the award queue bands them at the start of its next run.
+func (l *Loader) Load(ctx context.Context, period PerformancePeriod) error {
+	if period.HasProblems() {
+		return errors.New("Performance period has problems and can't be loaded")
+	}
+
+	err := l.db.Transaction(func(tx *pop.Connection) error {
+		for i := range period.performances {
+			verrs, err := tx.ValidateAndCreate(&period.performances[i])
+			if err != nil {
+				return errors.Wrapf(err, "Saving %s", period.descriptions[i])
+			}
+			if verrs.HasAny() {
+				return errors.Errorf("Saving %s: %s", period.descriptions[i], verrs)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	l.logger.Info("Loaded TSP performances",
+		zap.String("performance_period_start", period.Start.Format(dateFormat)),
+		zap.String("performance_period_end", period.End.Format(dateFormat)),
+		zap.Int("added", period.Added()))
+
+	aq, err := awardqueue.NewAwardQueue(l.db, &hnyzap.Logger{Logger: l.logger})
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(aq.AssignPerformanceBands(ctx), "Assigning quality bands")
+}
@@ -0,0 +1,149 @@
+package tspperformanceloader
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+type TSPPerformanceLoaderSuite struct {
+	suite.Suite
+	db     *pop.Connection
+	logger *zap.Logger
+}
+
+func (suite *TSPPerformanceLoaderSuite) SetupTest() {
+	suite.db.TruncateAll()
+}
+
+func TestTSPPerformanceLoaderSuite(t *testing.T) {
+	configLocation := "../../../config"
+	pop.AddLookupPaths(configLocation)
+	db, err := pop.Connect("test")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	logger, err := zap.NewDevelopment()
+	if err != nil {
+		log.Panic(err)
+	}
+
+	hs := &TSPPerformanceLoaderSuite{
+		db:     db,
+		logger: logger,
+	}
+
+	suite.Run(t, hs)
+}
+
+func (suite *TSPPerformanceLoaderSuite) makeTSPs(scacs ...string) {
+	for _, scac := range scacs {
+		testdatagen.MakeTSP(suite.db, testdatagen.Assertions{
+			TransportationServiceProvider: models.TransportationServiceProvider{
+				StandardCarrierAlphaCode: scac,
+				Enrolled:                 true,
+			},
+		})
+	}
+}
+
+func (suite *TSPPerformanceLoaderSuite) TestRateCycleFor() {
+	cases := []struct {
+		date  time.Time
+		start time.Time
+		end   time.Time
+	}{
+		{time.Date(2019, time.May, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2019, time.May, 15, 0, 0, 0, 0, time.UTC), time.Date(2019, time.September, 30, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2019, time.October, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2019, time.October, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, time.May, 14, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2019, time.October, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, time.May, 14, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		start, end := rateCycleFor(c.date)
+		suite.Equal(c.start, start, "rate cycle start for %s", c.date)
+		suite.Equal(c.end, end, "rate cycle end for %s", c.date)
+	}
+}
+
+func (suite *TSPPerformanceLoaderSuite) TestReadReportsProblems() {
+	testdatagen.MakeDefaultTDL(suite.db)
+	suite.makeTSPs("BVS1", "BVS2", "BVS3")
+	loader := NewLoader(suite.db, suite.logger)
+
+	period, err := loader.Read("testdata/performances_with_problems.csv",
+		testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+
+	suite.Equal(1, period.Added())
+	suite.Equal([]string{
+		`line 3: no TSP has the SCAC "NOPE"`,
+		"line 4: TDL US99 6 D does not exist",
+		"line 5: best_value_score 107 is not between 0 and 100",
+		"line 6: BVS1 in TDL US87 6 D is duplicated by line 2",
+	}, period.Problems)
+
+	err = loader.Load(context.Background(), period)
+	suite.Error(err)
+}
+
+func (suite *TSPPerformanceLoaderSuite) TestReadRejectsPeriodSpanningRateCycles() {
+	loader := NewLoader(suite.db, suite.logger)
+
+	_, err := loader.Read("testdata/performances.csv",
+		testdatagen.PerformancePeriodStart, testdatagen.NonPeakRateCycleStart.AddDate(0, 1, 0))
+	suite.Error(err)
+}
+
+func (suite *TSPPerformanceLoaderSuite) TestLoadAssignsQualityBands() {
+	tdl := testdatagen.MakeDefaultTDL(suite.db)
+	suite.makeTSPs("BVS1", "BVS2", "BVS3", "BVS4")
+	loader := NewLoader(suite.db, suite.logger)
+
+	period, err := loader.Read("testdata/performances.csv",
+		testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+	suite.Empty(period.Problems)
+	suite.Equal(4, period.Added())
+	suite.Equal(testdatagen.PeakRateCycleStart, period.RateCycleStart)
+	suite.Equal(testdatagen.PeakRateCycleEnd, period.RateCycleEnd)
+
+	err = loader.Load(context.Background(), period)
+	suite.NoError(err)
+
+	var perfs models.TransportationServiceProviderPerformances
+	err = suite.db.Eager("TransportationServiceProvider").
+		Where("traffic_distribution_list_id = ?", tdl.ID).
+		Order("best_value_score DESC").
+		All(&perfs)
+	suite.NoError(err)
+	suite.Len(perfs, 4)
+
+	// With four TSPs, each of the four quality bands gets one, in BVS order
+	for i, perf := range perfs {
+		if suite.NotNil(perf.QualityBand) {
+			suite.Equal(i+1, *perf.QualityBand)
+		}
+	}
+	suite.Equal("BVS1", perfs[0].TransportationServiceProvider.StandardCarrierAlphaCode)
+	suite.Equal(91.5, perfs[0].BestValueScore)
+	suite.Equal(unit.DiscountRate(.67), perfs[0].LinehaulRate)
+	suite.Equal(unit.DiscountRate(.45), perfs[3].SITRate)
+
+	// Loading the same file again would duplicate the performance period
+	period, err = loader.Read("testdata/performances.csv",
+		testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+	suite.Len(period.Problems, 4)
+}
@@ -0,0 +1,4 @@
+ALTER TABLE shipments ADD COLUMN shipment_type varchar(255) NOT NULL DEFAULT 'HHG';
+
+-- Unaccompanied baggage is priced with its own linehaul line item in place of LHS
+INSERT INTO tariff400ng_items (id,code,discount_type,allowed_location,item,measurement_unit_1,measurement_unit_2,rate_ref_code,created_at,updated_at) VALUES ('4ad1ea33-a54b-4c22-9d17-f6b4b0e18a37','UBL','HHG','ORIGIN','Unaccompanied Baggage Linehaul Transportation','FR','NONE','SE',now(),now());
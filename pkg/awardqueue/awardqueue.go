@@ -11,6 +11,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/gobuffalo/pop"
 	"github.com/gofrs/uuid"
@@ -22,18 +23,44 @@ import (
 )
 
 const awardQueueLockID = 1
-const numQualBands = 4
 
-// Minimum Performance Score (MPS) is the lowest BVS a TSP can have and still be assigned shipments.
-// TODO: This will eventually need to be configurable; implement as something other than a constant.
-//       Setting to zero for now to make sure that no TSPs are accidentally excluded.
-const mps = 0
+// ErrLockNotAcquired is returned when another award queue is already running
+var ErrLockNotAcquired = errors.New("award queue lock is held by another process")
+
+// RunStats counts what the award queue did during a run
+type RunStats struct {
+	ExpiredOffers      int `json:"expired_offers"`
+	ShipmentsOffered   int `json:"shipments_offered"`
+	ShipmentsUnoffered int `json:"shipments_unoffered"`
+}
 
 // AwardQueue encapsulates the TSP award queue process
 type AwardQueue struct {
 	db *pop.Connection
 	//logger *hnyzap.Logger
-	logger Logger
+	logger   Logger
+	policies models.AwardQueuePolicies
+	// report collects the outcome of a dry run, and is nil when the award queue runs for real
+	report *DryRunReport
+}
+
+// policyFields returns the log fields that identify the award queue policy in effect
+func policyFields(policy models.AwardQueuePolicy) []zap.Field {
+	policyID := "default"
+	if !policy.IsDefault() {
+		policyID = policy.ID.String()
+	}
+	fields := []zap.Field{
+		zap.String("award_queue_policy_id", policyID),
+		zap.Int("award_queue_policy_version", policy.Version),
+		zap.Float64("minimum_performance_score", policy.MinimumPerformanceScore),
+		zap.Int("num_quality_bands", policy.NumQualityBands),
+		zap.String("band_distribution", string(policy.BandDistribution)),
+	}
+	if policy.MaxOffersPerTSP != nil {
+		fields = append(fields, zap.Int("max_offers_per_tsp", *policy.MaxOffersPerTSP))
+	}
+	return fields
 }
 
 func (aq *AwardQueue) findAllUnassignedShipments() (models.Shipments, error) {
@@ -42,12 +69,35 @@ func (aq *AwardQueue) findAllUnassignedShipments() (models.Shipments, error) {
 }
 
 // attemptShipmentOffer will attempt to take the given Shipment and award it to
-// a TSP.
-// TODO: refactor this method to ensure the transaction is wrapping what it needs to
+// a TSP. The decision is recorded whether or not the shipment is offered.
 func (aq *AwardQueue) attemptShipmentOffer(ctx context.Context, shipment models.Shipment) (*models.ShipmentOffer, error) {
+	batch, err := newAwardBatch(aq.db, models.Shipments{shipment})
+	if err != nil {
+		return nil, err
+	}
+	return aq.attemptBatchedShipmentOffer(ctx, batch, shipment)
+}
+
+// attemptBatchedShipmentOffer is attemptShipmentOffer for a shipment whose TDL, TSP performances and
+// blackout dates have already been loaded in batch.
+func (aq *AwardQueue) attemptBatchedShipmentOffer(ctx context.Context, batch *awardBatch, shipment models.Shipment) (*models.ShipmentOffer, error) {
 	ctx, span := beeline.StartSpan(ctx, "attemptShipmentOffer")
 	defer span.Send()
 
+	decision := models.AwardQueueDecision{
+		ShipmentID:                shipment.ID,
+		TrafficDistributionListID: shipment.TrafficDistributionListID,
+	}
+	shipmentOffer, err := aq.offerShipment(ctx, batch, shipment, &decision)
+	aq.recordDecision(ctx, &decision, shipmentOffer, err)
+
+	return shipmentOffer, err
+}
+
+// offerShipment offers the shipment to the next TSP in the rotation, noting the TSP performances it
+// considers in decision.
+// TODO: refactor this method to ensure the transaction is wrapping what it needs to
+func (aq *AwardQueue) offerShipment(ctx context.Context, batch *awardBatch, shipment models.Shipment, decision *models.AwardQueueDecision) (*models.ShipmentOffer, error) {
 	// Validate that the shipment has all required data. Do this before touching
 	// the shipment, even for logging.
 	if err := validateShipmentForAward(shipment); err != nil {
@@ -56,14 +106,48 @@ func (aq *AwardQueue) attemptShipmentOffer(ctx context.Context, shipment models.
 
 	aq.logger.TraceInfo(ctx, "Attempting to offer shipment",
 		zap.String("shipment_id", shipment.ID.String()),
+		zap.String("shipment_type", string(shipment.ShipmentType)),
 		zap.String("traffic_distribution_list_id", shipment.TrafficDistributionListID.String()))
 
-	// Query the shipment's TDL
-	tdl := models.TrafficDistributionList{}
-	err := aq.db.Find(&tdl, shipment.TrafficDistributionListID)
-
+	tdl, err := batch.tdl(*shipment.TrafficDistributionListID)
 	if err != nil {
-		return nil, errors.Wrap(err, "Cannot find TDL in database")
+		return nil, err
+	}
+
+	// TSP performances are grouped by the rate cycle of the requested pickup date, so that picks the policy too
+	policy := aq.policies.ForDate(*shipment.RequestedPickupDate)
+	aq.logger.TraceInfo(ctx, "Using award queue policy", policyFields(policy)...)
+	if !policy.IsDefault() {
+		decision.AwardQueuePolicyID = &policy.ID
+	}
+
+	// nextTSPPerformance picks the next TSP performance in the rotation, noting the candidate from each
+	// quality band in the decision. It returns the index of the selected candidate.
+	nextTSPPerformance := func(attempt int) (models.TransportationServiceProviderPerformance, int, error) {
+		tspPerformances, err := batch.gatherNextEligibleTSPPerformances(tdl.ID, shipment, policy)
+		if err != nil {
+			return models.TransportationServiceProviderPerformance{}, -1, err
+		}
+		selected := models.SelectNextTSPPerformance(tspPerformances)
+		selectedIndex := -1
+		for band := 1; band <= policy.NumQualityBands; band++ {
+			tspPerformance, ok := tspPerformances[band]
+			if !ok {
+				continue
+			}
+			if tspPerformance.ID == selected.ID {
+				selectedIndex = len(decision.Candidates)
+			}
+			decision.Candidates = append(decision.Candidates, models.AwardQueueDecisionCandidate{
+				Attempt:     attempt,
+				QualityBand: band,
+				TransportationServiceProviderPerformanceID: tspPerformance.ID,
+				TransportationServiceProviderID:            tspPerformance.TransportationServiceProviderID,
+				OfferCount:                                 tspPerformance.OfferCount,
+				Selected:                                   tspPerformance.ID == selected.ID,
+			})
+		}
+		return selected, selectedIndex, nil
 	}
 
 	var shipmentOffer *models.ShipmentOffer
@@ -72,8 +156,7 @@ func (aq *AwardQueue) attemptShipmentOffer(ctx context.Context, shipment models.
 	// We _also_ want to watch out for infinite loops, because if all the TSPs in the selection
 	// have blackout dates (imagine a 1-TSP-TDL, with a blackout date) we will keep awarding
 	// administrative shipments forever.
-	firstEligibleTSPPerformance, err := models.NextEligibleTSPPerformance(aq.db, tdl.ID, *shipment.BookDate,
-		*shipment.RequestedPickupDate)
+	firstEligibleTSPPerformance, selectedIndex, err := nextTSPPerformance(1)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +165,7 @@ func (aq *AwardQueue) attemptShipmentOffer(ctx context.Context, shipment models.
 	tspPerformance := firstEligibleTSPPerformance
 	foundAvailableTSP := false
 	loopCount := 0
+	administrativeOffers := 0
 
 	for !foundAvailableTSP {
 
@@ -90,51 +174,61 @@ func (aq *AwardQueue) attemptShipmentOffer(ctx context.Context, shipment models.
 		}
 		loopCount++
 
-		tsp := models.TransportationServiceProvider{}
-		if err := aq.db.Find(&tsp, tspPerformance.TransportationServiceProviderID); err == nil {
-			aq.logger.TraceInfo(ctx, "Attempting to offer to TSP", zap.String("tsp_id", tsp.ID.String()))
-
-			isAdministrativeShipment, err := aq.ShipmentWithinBlackoutDates(tsp.ID, shipment)
-			if err != nil {
-				aq.logger.TraceError(ctx, "Failed to determine if shipment is within TSP blackout dates", zap.Error(err))
-				return nil, err
-			}
+		tsp := tspPerformance.TransportationServiceProvider
+		aq.logger.TraceInfo(ctx, "Attempting to offer to TSP", zap.String("tsp_id", tsp.ID.String()))
+
+		isAdministrativeShipment := batch.shipmentWithinBlackoutDates(tsp.ID, shipment)
+		candidate := &decision.Candidates[selectedIndex]
+		candidate.WithinBlackout = &isAdministrativeShipment
+
+		shipmentOffer, err = models.CreateShipmentOffer(aq.db, shipment.ID, tsp.ID, tspPerformance.ID, isAdministrativeShipment, policy)
+		if err == nil {
+			candidate.ShipmentOfferID = &shipmentOffer.ID
+			offerCount := tspPerformance.OfferCount
+			if tspPerformance, err = models.IncrementTSPPerformanceOfferCount(aq.db, tspPerformance.ID); err == nil {
+				batch.recordOffer(tspPerformance)
+				aq.report.addOffer(shipment, tsp, tspPerformance, isAdministrativeShipment)
+				if isAdministrativeShipment == true {
+					administrativeOffers++
+					aq.logger.TraceInfo(ctx, "Shipment pickup date is during a blackout period. Awarding Administrative Shipment to TSP.",
+						policyFields(policy)...)
+				} else {
+					qb := -1
+					if tspPerformance.QualityBand != nil {
+						qb = *tspPerformance.QualityBand
+					}
 
-			shipmentOffer, err = models.CreateShipmentOffer(aq.db, shipment.ID, tsp.ID, tspPerformance.ID, isAdministrativeShipment)
-			if err == nil {
-				if tspPerformance, err = models.IncrementTSPPerformanceOfferCount(aq.db, tspPerformance.ID); err == nil {
-					if isAdministrativeShipment == true {
-						aq.logger.TraceInfo(ctx, "Shipment pickup date is during a blackout period. Awarding Administrative Shipment to TSP.")
-					} else {
-						qb := -1
-						if tspPerformance.QualityBand != nil {
-							qb = *tspPerformance.QualityBand
-						}
-
-						aq.logger.TraceInfo(ctx, "Shipment offered to TSP!",
+					aq.logger.TraceInfo(ctx, "Shipment offered to TSP!",
+						append([]zap.Field{
+							zap.String("shipment_offer_id", shipmentOffer.ID.String()),
 							zap.Int("quality_band", qb),
-							zap.Int("offer_count", tspPerformance.OfferCount))
-						foundAvailableTSP = true
-
-						// Award the shipment
-						if err := models.AwardShipment(aq.db, shipment.ID); err != nil {
-							aq.logger.TraceError(ctx, "Failed to set shipment as awarded", zap.Error(err))
-							return nil, err
-						}
+							zap.Int("offer_count", tspPerformance.OfferCount),
+						}, policyFields(policy)...)...)
+					foundAvailableTSP = true
+					decision.Reason = fmt.Sprintf("Quality band %d was next in the rotation, and its next TSP had been offered %d shipments",
+						qb, offerCount)
+					if administrativeOffers > 0 {
+						decision.Reason += fmt.Sprintf("; %d TSPs selected before it had blackout dates and were given administrative offers",
+							administrativeOffers)
+					}
+
+					// Award the shipment
+					if err := models.AwardShipment(aq.db, shipment.ID); err != nil {
+						aq.logger.TraceError(ctx, "Failed to set shipment as awarded", zap.Error(err))
+						return nil, err
 					}
-				} else {
-					aq.logger.TraceError(ctx, "Failed to increment offer count", zap.Error(err))
 				}
 			} else {
-				aq.logger.TraceError(ctx, "Failed to offer to TSP", zap.Error(err))
+				aq.logger.TraceError(ctx, "Failed to increment offer count", zap.Error(err))
 			}
+		} else {
+			aq.logger.TraceError(ctx, "Failed to offer to TSP", zap.Error(err))
 		}
 
 		if !foundAvailableTSP {
 			aq.logger.TraceInfo(ctx, "Selected TSP has blackouts. Checking for another TSP.")
 
-			tspPerformance, err = models.NextEligibleTSPPerformance(aq.db, tdl.ID, *shipment.BookDate,
-				*shipment.RequestedPickupDate)
+			tspPerformance, selectedIndex, err = nextTSPPerformance(loopCount + 1)
 			if err != nil {
 				return nil, err
 			}
@@ -148,21 +242,91 @@ func (aq *AwardQueue) attemptShipmentOffer(ctx context.Context, shipment models.
 	return shipmentOffer, err
 }
 
+// recordDecision saves the award queue's decision about a shipment. A decision that can't be saved is
+// logged, but doesn't stop the shipment being offered.
+func (aq *AwardQueue) recordDecision(ctx context.Context, decision *models.AwardQueueDecision, shipmentOffer *models.ShipmentOffer, offerErr error) {
+	if offerErr == nil && shipmentOffer != nil {
+		decision.Outcome = models.AwardQueueDecisionOutcomeOFFERED
+		decision.TransportationServiceProviderPerformanceID = &shipmentOffer.TransportationServiceProviderPerformanceID
+		decision.TransportationServiceProviderID = &shipmentOffer.TransportationServiceProviderID
+		decision.ShipmentOfferID = &shipmentOffer.ID
+	} else {
+		decision.Outcome = models.AwardQueueDecisionOutcomeNOTOFFERED
+		if offerErr != nil {
+			decision.Reason = offerErr.Error()
+		}
+	}
+	if decision.Reason == "" {
+		decision.Reason = "No reason was given"
+	}
+
+	verrs, err := models.CreateAwardQueueDecision(aq.db, decision)
+	if err != nil || verrs.HasAny() {
+		aq.logger.TraceError(ctx, "Failed to record award queue decision",
+			zap.String("shipment_id", decision.ShipmentID.String()),
+			zap.String("validation_errors", verrs.String()),
+			zap.Error(err))
+	}
+}
+
+// expireOffers expires offers for awarded shipments that the TSP didn't accept in time, which puts
+// those shipments back in the queue to be offered to another TSP.
+func (aq *AwardQueue) expireOffers(ctx context.Context) (int, error) {
+	ctx, span := beeline.StartSpan(ctx, "expireOffers")
+	defer span.Send()
+
+	now := time.Now()
+	offers, err := models.FetchExpiredShipmentOffers(aq.db, now)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, offer := range offers {
+		verrs, err := models.ExpireShipmentOffer(aq.db, &offer, now)
+		if err == nil && verrs.HasAny() {
+			err = fmt.Errorf("Validation failure: %s", verrs)
+		}
+		if err != nil {
+			aq.logger.TraceError(ctx, "Failed to expire shipment offer",
+				zap.String("shipment_offer_id", offer.ID.String()),
+				zap.Error(err))
+			return 0, err
+		}
+		aq.logger.TraceInfo(ctx, "Shipment offer expired; returning shipment to the queue",
+			zap.String("shipment_offer_id", offer.ID.String()),
+			zap.String("shipment_id", offer.ShipmentID.String()),
+			zap.String("tsp_id", offer.TransportationServiceProviderID.String()))
+		aq.report.addExpiredOffer(offer)
+	}
+
+	return len(offers), nil
+}
+
 // assignShipments searches for all shipments that haven't been offered
-// yet to a TSP, and attempts to generate offers for each of them.
-func (aq *AwardQueue) assignShipments(ctx context.Context) {
+// yet to a TSP, and attempts to generate offers for each of them. It returns
+// the number of shipments that were and weren't offered.
+func (aq *AwardQueue) assignShipments(ctx context.Context) (awardedCount int, unawardedCount int) {
 	ctx, span := beeline.StartSpan(ctx, "assignShipments")
 	defer span.Send()
 	aq.logger.Info("TSP Award Queue running.")
 
 	shipments, err := aq.findAllUnassignedShipments()
+	var batch *awardBatch
 	if err == nil {
-		awardedCount := 0
-		unawardedCount := 0
+		batch, err = newAwardBatch(aq.db, shipments)
+	}
+	if err == nil {
+		aq.logger.TraceInfo(ctx, "Loaded TDLs, TSP performances and blackout dates for shipments",
+			zap.Int("shipments", len(shipments)),
+			zap.Int("traffic_distribution_lists", len(batch.tdls)))
+
+		// Offer the shipments in each TDL together, so that TSPs in a TDL are offered its shipments in turn
+		sortShipmentsByTDL(shipments)
 		for _, shipment := range shipments {
-			_, err = aq.attemptShipmentOffer(ctx, shipment)
+			_, err = aq.attemptBatchedShipmentOffer(ctx, batch, shipment)
 			if err != nil {
 				aq.logger.TraceError(ctx, "Failed to offer shipment", zap.Error(err))
+				aq.report.addUnofferedShipment(shipment, err)
 				unawardedCount++
 			} else {
 				awardedCount++
@@ -174,14 +338,16 @@ func (aq *AwardQueue) assignShipments(ctx context.Context) {
 	} else {
 		aq.logger.TraceError(ctx, "Failed to query for shipments", zap.Error(err))
 	}
+	return awardedCount, unawardedCount
 }
 
 // getTSPsPerBand determines how many TSPs should be assigned to each Quality Band
-// If the number of TSPs in the TDL does not divide evenly into 4 bands, the remainder
-// is divided from the top band down.
+// If the number of TSPs in the TDL does not divide evenly into the policy's bands, the
+// remainder is divided according to the policy's band distribution.
 //
 // count is the number of TSPs to distribute.
-func getTSPsPerBand(count int) []int {
+func getTSPsPerBand(count int, policy models.AwardQueuePolicy) []int {
+	numQualBands := policy.NumQualityBands
 	bands := make([]int, numQualBands)
 	base := int(math.Floor(float64(count) / float64(numQualBands)))
 	for i := range bands {
@@ -189,7 +355,11 @@ func getTSPsPerBand(count int) []int {
 	}
 
 	for i := 0; i < count%numQualBands; i++ {
-		bands[i]++
+		if policy.BandDistribution == models.BandDistributionBOTTOMUP {
+			bands[numQualBands-1-i]++
+		} else {
+			bands[i]++
+		}
 	}
 	return bands
 }
@@ -229,13 +399,16 @@ func (aq *AwardQueue) assignPerformanceBandsForTSPPerformanceGroup(ctx context.C
 		zap.String("rate_cycle_end", perfGroup.RateCycleEnd.String()),
 	)
 
-	perfs, err := models.FetchTSPPerformancesForQualityBandAssignment(aq.db, perfGroup, mps)
+	policy := aq.policies.ForDate(perfGroup.RateCycleStart)
+	aq.logger.TraceInfo(ctx, "Using award queue policy", policyFields(policy)...)
+
+	perfs, err := models.FetchTSPPerformancesForQualityBandAssignment(aq.db, perfGroup, policy.MinimumPerformanceScore)
 	if err != nil {
 		return err
 	}
 
 	perfsIndex := 0
-	bands := getTSPsPerBand(len(perfs))
+	bands := getTSPsPerBand(len(perfs), policy)
 	for band, count := range bands {
 		for i := 0; i < count; i++ {
 			performance := perfs[perfsIndex]
@@ -268,11 +441,63 @@ func (aq *AwardQueue) Run(ctx context.Context) error {
 	ctx, span := beeline.StartSpan(ctx, "awardqueue")
 	defer span.Send()
 
-	originalDB := aq.db
-	defer func() { aq.db = originalDB }()
+	return aq.db.Transaction(func(tx *pop.Connection) error {
+		_, err := aq.runInTransaction(ctx, tx, false)
+		return err
+	})
+}
+
+// tryRun executes the award queue algorithm like Run, unless another award queue is already running,
+// in which case it returns ErrLockNotAcquired rather than waiting for it to finish.
+func (aq *AwardQueue) tryRun(ctx context.Context) (RunStats, error) {
+	var stats RunStats
+	err := aq.db.Transaction(func(tx *pop.Connection) error {
+		var err error
+		stats, err = aq.runInTransaction(ctx, tx, true)
+		return err
+	})
+	if errors.Cause(err) == ErrLockNotAcquired {
+		return stats, ErrLockNotAcquired
+	}
+	return stats, err
+}
+
+// DryRun executes the award queue algorithm inside a transaction that is always rolled back,
+// and reports the offers that Run would have made.
+func (aq *AwardQueue) DryRun(ctx context.Context) (*DryRunReport, error) {
+	ctx, span := beeline.StartSpan(ctx, "awardqueue_dry_run")
+	defer span.Send()
+
+	aq.report = newDryRunReport()
+	defer func() { aq.report = nil }()
+
+	var runErr error
+	err := aq.db.Rollback(func(tx *pop.Connection) {
+		_, runErr = aq.runInTransaction(ctx, tx, false)
+	})
+	if runErr != nil {
+		return nil, runErr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not roll back award queue dry run")
+	}
+	aq.logger.Info("Award queue dry run complete; all changes were rolled back.",
+		zap.Int("shipments_offered", len(aq.report.Offers)),
+		zap.Int("shipments_unoffered", len(aq.report.UnofferedShipments)))
+
+	return aq.report, nil
+}
+
+// AssignPerformanceBands assigns quality bands to any TSP performances that don't have one yet,
+// without offering shipments. Run does the same before offering shipments; this lets newly loaded
+// performance periods be banded as soon as they are saved.
+func (aq *AwardQueue) AssignPerformanceBands(ctx context.Context) error {
+	ctx, span := beeline.StartSpan(ctx, "awardqueue_assign_performance_bands")
+	defer span.Send()
 
 	return aq.db.Transaction(func(tx *pop.Connection) error {
-		// ensure that all parts of the AQ run inside the transaction
+		originalDB := aq.db
+		defer func() { aq.db = originalDB }()
 		aq.db = tx
 
 		aq.logger.Info("Waiting to acquire advisory lock...")
@@ -282,15 +507,52 @@ func (aq *AwardQueue) Run(ctx context.Context) error {
 		}
 		aq.logger.Info("Acquired pg_advisory_xact_lock")
 
-		if err := aq.assignPerformanceBands(ctx); err != nil {
-			return err
+		return aq.assignPerformanceBands(ctx)
+	})
+}
+
+// runInTransaction assigns performance bands and offers shipments using the given transaction.
+// If tryLock is set it gives up with ErrLockNotAcquired when another award queue holds the lock,
+// rather than waiting for it.
+func (aq *AwardQueue) runInTransaction(ctx context.Context, tx *pop.Connection, tryLock bool) (RunStats, error) {
+	var stats RunStats
+	originalDB := aq.db
+	defer func() { aq.db = originalDB }()
+
+	// ensure that all parts of the AQ run inside the transaction
+	aq.db = tx
+
+	if tryLock {
+		acquired, err := tryForLock(ctx, tx, awardQueueLockID)
+		if err != nil {
+			return stats, err
 		}
+		if !acquired {
+			aq.logger.Info("Advisory lock is held by another award queue; skipping this run")
+			return stats, ErrLockNotAcquired
+		}
+	} else {
+		aq.logger.Info("Waiting to acquire advisory lock...")
+		err := waitForLock(ctx, tx, awardQueueLockID)
+		if err != nil {
+			return stats, err
+		}
+	}
+	aq.logger.Info("Acquired pg_advisory_xact_lock")
 
-		// This method should also return an error
-		aq.assignShipments(ctx)
-		return nil
-	})
+	if err := aq.assignPerformanceBands(ctx); err != nil {
+		return stats, err
+	}
 
+	var err error
+	stats.ExpiredOffers, err = aq.expireOffers(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	// This method should also return an error
+	stats.ShipmentsOffered, stats.ShipmentsUnoffered = aq.assignShipments(ctx)
+	return stats, nil
 }
 
 // waitForLock MUST be called within a transaction!
@@ -303,12 +565,39 @@ func waitForLock(ctx context.Context, db *pop.Connection, id int) error {
 	return db.RawQuery("SELECT pg_advisory_xact_lock($1)", id).Exec()
 }
 
-// NewAwardQueue creates a new AwardQueue
-func NewAwardQueue(db *pop.Connection, logger Logger) *AwardQueue {
-	return &AwardQueue{
-		db:     db,
-		logger: logger,
+// tryForLock obtains the lock like waitForLock, but returns false immediately if it is already held.
+// It MUST be called within a transaction!
+func tryForLock(ctx context.Context, db *pop.Connection, id int) (bool, error) {
+	ctx, span := beeline.StartSpan(ctx, "tryForLock")
+	defer span.Send()
+	span.AddField("try_lock_id", id)
+
+	var acquired bool
+	err := db.RawQuery("SELECT pg_try_advisory_xact_lock($1)", id).First(&acquired)
+	span.AddField("lock_acquired", acquired)
+	return acquired, err
+}
+
+// NewAwardQueue creates a new AwardQueue, loading the award queue policy in effect for each rate cycle
+func NewAwardQueue(db *pop.Connection, logger Logger) (*AwardQueue, error) {
+	policies, err := models.FetchCurrentAwardQueuePolicies(db)
+	if err != nil {
+		return nil, err
 	}
+
+	for _, policy := range policies {
+		logger.Info("Loaded award queue policy",
+			append([]zap.Field{
+				zap.String("rate_cycle_start", policy.RateCycleStart.String()),
+				zap.String("rate_cycle_end", policy.RateCycleEnd.String()),
+			}, policyFields(policy)...)...)
+	}
+
+	return &AwardQueue{
+		db:       db,
+		logger:   logger,
+		policies: policies,
+	}, nil
 }
 
 // validateShipmentForAward ensures that a given shipment has all required
@@ -1,6 +1,7 @@
 package awardqueue
 
 import (
+	"bytes"
 	"context"
 	"log"
 	"strings"
@@ -22,9 +23,12 @@ import (
 	"github.com/transcom/mymove/pkg/unit"
 )
 
+// mps matches the Minimum Performance Score of the default award queue policy
+const mps = 0
+
 func (suite *AwardQueueSuite) Test_CheckAllTSPsBlackedOut() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	tsp := testdatagen.MakeDefaultTSP(suite.DB())
 
@@ -78,7 +82,7 @@ func (suite *AwardQueueSuite) Test_CheckAllTSPsBlackedOut() {
 
 func (suite *AwardQueueSuite) Test_CheckShipmentDuringBlackOut() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	tsp := testdatagen.MakeDefaultTSP(suite.DB())
 
@@ -176,7 +180,7 @@ func (suite *AwardQueueSuite) Test_CheckShipmentDuringBlackOut() {
 
 func (suite *AwardQueueSuite) Test_ShipmentWithinBlackoutDates() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 	// Creates a TSP with a blackout date connected to both.
 	testTSP1 := testdatagen.MakeDefaultTSP(suite.DB())
 
@@ -261,7 +265,7 @@ func (suite *AwardQueueSuite) Test_ShipmentWithinBlackoutDates() {
 
 func (suite *AwardQueueSuite) Test_FindAllUnassignedShipments() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 	_, err := queue.findAllUnassignedShipments()
 
 	if err != nil {
@@ -273,7 +277,7 @@ func (suite *AwardQueueSuite) Test_FindAllUnassignedShipments() {
 // it actually gets offered.
 func (suite *AwardQueueSuite) Test_OfferSingleShipment() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	// Make a shipment
 	calendar := dates.NewUSCalendar()
@@ -326,7 +330,7 @@ func (suite *AwardQueueSuite) Test_OfferSingleShipment() {
 // any enabled TSPs.
 func (suite *AwardQueueSuite) Test_FailOfferingSingleShipment() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	// Make a shipment in a new TDL, which inherently has no TSPs
 	market := "dHHG"
@@ -376,7 +380,7 @@ func (suite *AwardQueueSuite) Test_FailOfferingSingleShipment() {
 
 func (suite *AwardQueueSuite) TestAssignShipmentsSingleTSP() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	const shipmentsToMake = 10
 
@@ -440,7 +444,7 @@ func (suite *AwardQueueSuite) TestAssignShipmentsToMultipleTSPs() {
 
 	suite.DB().TruncateAll()
 
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	const shipmentsToMake = 17
 
@@ -506,7 +510,7 @@ func (suite *AwardQueueSuite) Test_GetTSPsPerBandWithRemainder() {
 	t := suite.T()
 	// Check bands should expect differing num of TSPs when not divisible by 4
 	// Remaining TSPs should be divided among bands in descending order
-	tspPerBandList := getTSPsPerBand(10)
+	tspPerBandList := getTSPsPerBand(10, models.DefaultAwardQueuePolicy())
 	expectedBandList := []int{3, 3, 2, 2}
 	if !equalSlice(tspPerBandList, expectedBandList) {
 		t.Errorf("Failed to correctly divide TSP counts. Expected to find %d, found %d", expectedBandList, tspPerBandList)
@@ -516,16 +520,28 @@ func (suite *AwardQueueSuite) Test_GetTSPsPerBandWithRemainder() {
 func (suite *AwardQueueSuite) Test_GetTSPsPerBandNoRemainder() {
 	t := suite.T()
 	// Check bands should expect correct num of TSPs when num of TSPs is divisible by 4
-	tspPerBandList := getTSPsPerBand(8)
+	tspPerBandList := getTSPsPerBand(8, models.DefaultAwardQueuePolicy())
 	expectedBandList := []int{2, 2, 2, 2}
 	if !equalSlice(tspPerBandList, expectedBandList) {
 		t.Errorf("Failed to correctly divide TSP counts. Expected to find %d, found %d", expectedBandList, tspPerBandList)
 	}
 }
 
+func (suite *AwardQueueSuite) Test_GetTSPsPerBandFromPolicy() {
+	// The remainder goes to the bottom bands when the policy says so
+	policy := models.AwardQueuePolicy{
+		NumQualityBands:  3,
+		BandDistribution: models.BandDistributionBOTTOMUP,
+	}
+	suite.Equal([]int{3, 4, 4}, getTSPsPerBand(11, policy))
+
+	policy.BandDistribution = models.BandDistributionTOPDOWN
+	suite.Equal([]int{4, 4, 3}, getTSPsPerBand(11, policy))
+}
+
 func (suite *AwardQueueSuite) Test_AssignTSPsToBands() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 	tspsToMake := 5
 
 	tdl := testdatagen.MakeDefaultTDL(suite.DB())
@@ -578,7 +594,7 @@ func (suite *AwardQueueSuite) Test_AssignTSPsToBands() {
 // rate cycles get awarded shipments appropriately
 func (suite *AwardQueueSuite) Test_AwardTSPsInDifferentRateCycles() {
 	t := suite.T()
-	queue := NewAwardQueue(suite.DB(), suite.logger)
+	queue := suite.newAwardQueue()
 
 	sm := testdatagen.MakeDefaultServiceMember(suite.DB())
 	twoMonths, _ := time.ParseDuration("2 months")
@@ -675,6 +691,282 @@ func (suite *AwardQueueSuite) Test_AwardTSPsInDifferentRateCycles() {
 	}
 }
 
+func (suite *AwardQueueSuite) TestAssignShipmentsHHGAndUBOnSameMove() {
+	queue := suite.newAwardQueue()
+
+	hhgShipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			ShipmentType:        models.ShipmentTypeHHG,
+			RequestedPickupDate: &testdatagen.DateInsidePeakRateCycle,
+			ActualPickupDate:    &testdatagen.DateInsidePeakRateCycle,
+			BookDate:            &testdatagen.PerformancePeriodStart,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+	ubShipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			ShipmentType:        models.ShipmentTypeUB,
+			RequestedPickupDate: &testdatagen.DateInsidePeakRateCycle,
+			ActualPickupDate:    &testdatagen.DateInsidePeakRateCycle,
+			BookDate:            &testdatagen.PerformancePeriodStart,
+			Status:              models.ShipmentStatusSUBMITTED,
+			MoveID:              hhgShipment.MoveID,
+			Move:                hhgShipment.Move,
+			ServiceMemberID:     hhgShipment.ServiceMemberID,
+			ServiceMember:       hhgShipment.ServiceMember,
+		},
+	})
+
+	// Each shipment is awarded from the TDL for its own code of service
+	suite.Equal("D", hhgShipment.TrafficDistributionList.CodeOfService)
+	suite.Equal("T", ubShipment.TrafficDistributionList.CodeOfService)
+
+	hhgTSP := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), hhgTSP, *hhgShipment.TrafficDistributionList, swag.Int(1), mps+1, 0, .3, .3)
+	ubTSP := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), ubTSP, *ubShipment.TrafficDistributionList, swag.Int(1), mps+1, 0, .3, .3)
+
+	queue.assignShipments(context.Background())
+
+	suite.verifyOfferCount(hhgTSP, 1)
+	suite.verifyOfferCount(ubTSP, 1)
+
+	for _, shipment := range []models.Shipment{hhgShipment, ubShipment} {
+		suite.NoError(suite.DB().Find(&shipment, shipment.ID))
+		suite.Equal(models.ShipmentStatusAWARDED, shipment.Status, "%s shipment was not awarded", shipment.ShipmentType)
+	}
+}
+
+func (suite *AwardQueueSuite) Test_AssignTSPsToBandsUsesPolicy() {
+	testdatagen.MakeAwardQueuePolicy(suite.DB(), testdatagen.Assertions{
+		AwardQueuePolicy: models.AwardQueuePolicy{
+			MinimumPerformanceScore: 2,
+			NumQualityBands:         2,
+		},
+	})
+	queue := suite.newAwardQueue()
+	tdl := testdatagen.MakeDefaultTDL(suite.DB())
+
+	var perfs []models.TransportationServiceProviderPerformance
+	for score := 1; score <= 5; score++ {
+		tsp := testdatagen.MakeDefaultTSP(suite.DB())
+		perf, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, tdl, nil, float64(score), 0, .3, .3)
+		suite.NoError(err)
+		perfs = append(perfs, perf)
+	}
+
+	suite.NoError(queue.assignPerformanceBands(context.Background()))
+
+	// TSPs at or below the policy's MPS are left out, and the rest are split into the policy's two bands
+	expectedBands := []*int{nil, nil, swag.Int(2), swag.Int(1), swag.Int(1)}
+	for i, perf := range perfs {
+		suite.NoError(suite.DB().Find(&perf, perf.ID))
+		suite.Equal(expectedBands[i], perf.QualityBand, "wrong band for TSP with score %v", perf.BestValueScore)
+	}
+}
+
+func (suite *AwardQueueSuite) Test_OfferShipmentsUsesPolicyOfferLimit() {
+	policy := testdatagen.MakeAwardQueuePolicy(suite.DB(), testdatagen.Assertions{
+		AwardQueuePolicy: models.AwardQueuePolicy{
+			MaxOffersPerTSP: swag.Int(1),
+		},
+	})
+	queue := suite.newAwardQueue()
+
+	var shipments []models.Shipment
+	for i := 0; i < 3; i++ {
+		shipments = append(shipments, testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+			Shipment: models.Shipment{
+				RequestedPickupDate: &testdatagen.DateInsidePeakRateCycle,
+				ActualPickupDate:    &testdatagen.DateInsidePeakRateCycle,
+				BookDate:            &testdatagen.PerformancePeriodStart,
+				Status:              models.ShipmentStatusSUBMITTED,
+			},
+		}))
+	}
+
+	tdl := *shipments[0].TrafficDistributionList
+	tsp1 := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp1, tdl, swag.Int(1), mps+2, 0, .3, .3)
+	tsp2 := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp2, tdl, swag.Int(1), mps+1, 0, .3, .3)
+
+	// Each TSP can only be offered one shipment, so the third can't be offered
+	for _, shipment := range shipments[:2] {
+		offer, err := queue.attemptShipmentOffer(context.Background(), shipment)
+		suite.NoError(err)
+		suite.Equal(policy.ID, *offer.AwardQueuePolicyID)
+	}
+	_, err := queue.attemptShipmentOffer(context.Background(), shipments[2])
+	suite.Error(err)
+
+	suite.verifyOfferCount(tsp1, 1)
+	suite.verifyOfferCount(tsp2, 1)
+}
+
+func (suite *AwardQueueSuite) TestDryRunRollsBack() {
+	queue := suite.newAwardQueue()
+
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &testdatagen.DateInsidePeakRateCycle,
+			ActualPickupDate:    &testdatagen.DateInsidePeakRateCycle,
+			BookDate:            &testdatagen.PerformancePeriodStart,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	tspp, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, *shipment.TrafficDistributionList, nil, mps+1, 0, .3, .3)
+	suite.NoError(err)
+
+	report, err := queue.DryRun(context.Background())
+	suite.NoError(err)
+
+	// The report shows the TSP being banded and offered the shipment
+	suite.Len(report.Offers, 1)
+	suite.Equal(shipment.ID, report.Offers[0].ShipmentID)
+	suite.Equal(tsp.ID, report.Offers[0].TSPID)
+	suite.Equal(tsp.StandardCarrierAlphaCode, report.Offers[0].SCAC)
+	suite.False(report.Offers[0].AdministrativeShipment)
+	suite.Equal(map[int]int{1: 1}, report.OffersPerBand)
+	suite.Equal(0, report.AdministrativeOffers)
+	suite.Empty(report.UnofferedShipments)
+
+	var output bytes.Buffer
+	suite.NoError(report.Write(&output))
+	suite.Contains(output.String(), shipment.ID.String())
+
+	// But none of it was saved
+	suite.NoError(suite.DB().Find(&shipment, shipment.ID))
+	suite.Equal(models.ShipmentStatusSUBMITTED, shipment.Status)
+	suite.NoError(suite.DB().Find(&tspp, tspp.ID))
+	suite.Nil(tspp.QualityBand)
+	suite.Equal(0, tspp.OfferCount)
+	count, err := suite.DB().Count(&models.ShipmentOffer{})
+	suite.NoError(err)
+	suite.Equal(0, count)
+
+	// Running for real afterwards makes the same offer
+	suite.NoError(queue.Run(context.Background()))
+	suite.verifyOfferCount(tsp, 1)
+}
+
+// setUpReoffer makes a shipment with two TSPs that can handle it. The first TSP is offered the
+// shipment first, and would be again if it weren't excluded.
+func (suite *AwardQueueSuite) setUpReoffer() (models.Shipment, models.TransportationServiceProvider, models.TransportationServiceProvider) {
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &testdatagen.DateInsidePeakRateCycle,
+			ActualPickupDate:    &testdatagen.DateInsidePeakRateCycle,
+			BookDate:            &testdatagen.PerformancePeriodStart,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+	tdl := *shipment.TrafficDistributionList
+
+	firstTSP := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), firstTSP, tdl, swag.Int(1), mps+2, 0, .3, .3)
+	secondTSP := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), secondTSP, tdl, swag.Int(1), mps+1, 5, .3, .3)
+
+	return shipment, firstTSP, secondTSP
+}
+
+func (suite *AwardQueueSuite) TestRejectedShipmentIsOfferedToAnotherTSP() {
+	queue := suite.newAwardQueue()
+	shipment, firstTSP, secondTSP := suite.setUpReoffer()
+
+	offer, err := queue.attemptShipmentOffer(context.Background(), shipment)
+	suite.NoError(err)
+	suite.Equal(firstTSP.ID, offer.TransportationServiceProviderID)
+	suite.NotNil(offer.AcceptBy)
+
+	_, _, verrs, err := models.RejectShipmentForTSP(suite.DB(), firstTSP.ID, shipment.ID, "No capacity")
+	suite.NoError(err)
+	suite.NoVerrs(verrs)
+
+	// The rejected shipment goes back in the queue, and is offered to the other TSP
+	queue.assignShipments(context.Background())
+
+	var offers models.ShipmentOffers
+	suite.NoError(suite.DB().Where("shipment_id = ?", shipment.ID).Order("created_at").All(&offers))
+	suite.Len(offers, 2)
+	suite.Equal(secondTSP.ID, offers[1].TransportationServiceProviderID)
+	suite.NoError(suite.DB().Find(&shipment, shipment.ID))
+	suite.Equal(models.ShipmentStatusAWARDED, shipment.Status)
+
+	// The first TSP keeps the offer in its count, and the rejection is on its performance record
+	var tspp models.TransportationServiceProviderPerformance
+	suite.NoError(suite.DB().Find(&tspp, offers[0].TransportationServiceProviderPerformanceID))
+	suite.Equal(1, tspp.OfferCount)
+	suite.Equal(1, tspp.RejectedOfferCount)
+}
+
+func (suite *AwardQueueSuite) TestExpiredOfferIsOfferedToAnotherTSP() {
+	queue := suite.newAwardQueue()
+	shipment, firstTSP, secondTSP := suite.setUpReoffer()
+
+	offer, err := queue.attemptShipmentOffer(context.Background(), shipment)
+	suite.NoError(err)
+	suite.Equal(firstTSP.ID, offer.TransportationServiceProviderID)
+
+	// The first TSP doesn't accept the offer in time
+	deadline := time.Now().Add(-time.Minute)
+	offer.AcceptBy = &deadline
+	suite.MustSave(offer)
+
+	suite.NoError(queue.Run(context.Background()))
+
+	suite.NoError(suite.DB().Find(offer, offer.ID))
+	suite.False(*offer.Accepted)
+	suite.NotNil(offer.ExpiredAt)
+
+	var reoffer models.ShipmentOffer
+	suite.NoError(suite.DB().Where("shipment_id = ? AND accepted IS NULL", shipment.ID).First(&reoffer))
+	suite.Equal(secondTSP.ID, reoffer.TransportationServiceProviderID)
+
+	var tspp models.TransportationServiceProviderPerformance
+	suite.NoError(suite.DB().Find(&tspp, offer.TransportationServiceProviderPerformanceID))
+	suite.Equal(1, tspp.ExpiredOfferCount)
+}
+
+func (suite *AwardQueueSuite) TestDryRunRollsBackExpiredOffers() {
+	queue := suite.newAwardQueue()
+	shipment, firstTSP, _ := suite.setUpReoffer()
+
+	offer, err := queue.attemptShipmentOffer(context.Background(), shipment)
+	suite.NoError(err)
+	suite.Equal(firstTSP.ID, offer.TransportationServiceProviderID)
+
+	deadline := time.Now().Add(-time.Minute)
+	offer.AcceptBy = &deadline
+	suite.MustSave(offer)
+
+	report, err := queue.DryRun(context.Background())
+	suite.NoError(err)
+	suite.Len(report.ExpiredOffers, 1)
+	suite.Len(report.Offers, 1)
+
+	// Expiring the offer mustn't commit the dry run's transaction
+	suite.NoError(suite.DB().Find(offer, offer.ID))
+	suite.Nil(offer.Accepted)
+	suite.Nil(offer.ExpiredAt)
+	suite.NoError(suite.DB().Find(&shipment, shipment.ID))
+	suite.Equal(models.ShipmentStatusAWARDED, shipment.Status)
+	var tspp models.TransportationServiceProviderPerformance
+	suite.NoError(suite.DB().Find(&tspp, offer.TransportationServiceProviderPerformanceID))
+	suite.Equal(0, tspp.ExpiredOfferCount)
+	count, err := suite.DB().Count(&models.ShipmentOffer{})
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *AwardQueueSuite) newAwardQueue() *AwardQueue {
+	queue, err := NewAwardQueue(suite.DB(), suite.logger)
+	suite.NoError(err)
+	return queue
+}
+
 func (suite *AwardQueueSuite) verifyOfferCount(tsp models.TransportationServiceProvider, expectedCount int) {
 	t := suite.T()
 	t.Helper()
@@ -798,3 +1090,96 @@ func TestAwardQueueSuite(t *testing.T) {
 	}
 	suite.Run(t, hs)
 }
+
+func (suite *AwardQueueSuite) TestAttemptShipmentOfferRecordsDecision() {
+	queue := suite.newAwardQueue()
+
+	market := testdatagen.DefaultMarket
+	sourceGBLOC := testdatagen.DefaultSrcGBLOC
+	pickupDate := testdatagen.DateInsidePeakRateCycle
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &pickupDate,
+			ActualPickupDate:    &pickupDate,
+			SourceGBLOC:         &sourceGBLOC,
+			Market:              &market,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+	tdl := *shipment.TrafficDistributionList
+
+	// The band 1 TSP is blacked out, so the shipment goes to the band 2 TSP
+	blackedOutTSP := testdatagen.MakeDefaultTSP(suite.DB())
+	_, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), blackedOutTSP, tdl, swag.Int(1), mps+2, 0, .3, .3)
+	suite.NoError(err)
+	testdatagen.MakeBlackoutDate(suite.DB(), testdatagen.Assertions{
+		BlackoutDate: models.BlackoutDate{
+			TransportationServiceProviderID: blackedOutTSP.ID,
+			StartBlackoutDate:               pickupDate.AddDate(0, 0, -1),
+			EndBlackoutDate:                 pickupDate.AddDate(0, 0, 1),
+			TrafficDistributionListID:       &tdl.ID,
+			SourceGBLOC:                     &sourceGBLOC,
+			Market:                          &market,
+		},
+	})
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	tspp, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, tdl, swag.Int(2), mps+1, 0, .3, .3)
+	suite.NoError(err)
+
+	offer, err := queue.attemptShipmentOffer(context.Background(), shipment)
+	suite.NoError(err)
+
+	decisions, err := models.FetchAwardQueueDecisionsForShipment(suite.DB(), shipment.ID)
+	suite.NoError(err)
+	suite.Len(decisions, 1)
+	decision := decisions[0]
+	suite.Equal(models.AwardQueueDecisionOutcomeOFFERED, decision.Outcome)
+	suite.Equal(tdl.ID, *decision.TrafficDistributionListID)
+	suite.Equal(tsp.ID, *decision.TransportationServiceProviderID)
+	suite.Equal(tspp.ID, *decision.TransportationServiceProviderPerformanceID)
+	suite.Equal(offer.ID, *decision.ShipmentOfferID)
+	suite.Contains(decision.Reason, "1 TSPs selected before it had blackout dates")
+
+	// Each attempt considers a TSP from each band, and notes the blackout evaluation for the one it selects
+	suite.Len(decision.Candidates, 4)
+	var selected []models.AwardQueueDecisionCandidate
+	for _, candidate := range decision.Candidates {
+		if candidate.Selected {
+			selected = append(selected, candidate)
+		} else {
+			suite.Nil(candidate.WithinBlackout)
+		}
+	}
+	suite.Len(selected, 2)
+	suite.Equal(blackedOutTSP.ID, selected[0].TransportationServiceProviderID)
+	suite.True(*selected[0].WithinBlackout)
+	suite.NotNil(selected[0].ShipmentOfferID)
+	suite.Equal(tsp.ID, selected[1].TransportationServiceProviderID)
+	suite.False(*selected[1].WithinBlackout)
+	suite.Equal(offer.ID, *selected[1].ShipmentOfferID)
+}
+
+func (suite *AwardQueueSuite) TestAttemptShipmentOfferRecordsDecisionWhenNotOffered() {
+	queue := suite.newAwardQueue()
+
+	pickupDate := testdatagen.DateInsidePeakRateCycle
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &pickupDate,
+			ActualPickupDate:    &pickupDate,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+
+	// No TSPs are in the shipment's TDL
+	_, err := queue.attemptShipmentOffer(context.Background(), shipment)
+	suite.Error(err)
+
+	decisions, err := models.FetchAwardQueueDecisionsForShipment(suite.DB(), shipment.ID)
+	suite.NoError(err)
+	suite.Len(decisions, 1)
+	suite.Equal(models.AwardQueueDecisionOutcomeNOTOFFERED, decisions[0].Outcome)
+	suite.Contains(decisions[0].Reason, "Could not find any TSPs")
+	suite.Nil(decisions[0].ShipmentOfferID)
+	suite.Empty(decisions[0].Candidates)
+}
@@ -0,0 +1,214 @@
+package awardqueue
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// awardBatch holds everything the award queue reads while offering a batch of shipments, loaded in a handful
+// of queries up front. Offering a shipment then only writes to the database, so a backlog of thousands of
+// shipments doesn't take thousands of queries for TDLs, TSP performances and blackout dates.
+//
+// The batch is only valid while the award queue holds its lock, since it keeps offer counts up to date itself
+// rather than reading them back.
+type awardBatch struct {
+	tdls map[uuid.UUID]models.TrafficDistributionList
+	// performances holds the banded performances of enrolled TSPs in each TDL, in the order the award queue
+	// offers them shipments
+	performances map[uuid.UUID][]*models.TransportationServiceProviderPerformance
+	// blackouts holds each TSP's blackout dates
+	blackouts map[uuid.UUID]models.BlackoutDates
+	// declined holds, for each shipment, the TSPs that rejected it or let its offer expire
+	declined map[uuid.UUID]map[uuid.UUID]bool
+}
+
+// newAwardBatch loads what the award queue needs to offer shipments
+func newAwardBatch(db *pop.Connection, shipments models.Shipments) (*awardBatch, error) {
+	batch := awardBatch{
+		tdls:         make(map[uuid.UUID]models.TrafficDistributionList),
+		performances: make(map[uuid.UUID][]*models.TransportationServiceProviderPerformance),
+		blackouts:    make(map[uuid.UUID]models.BlackoutDates),
+		declined:     make(map[uuid.UUID]map[uuid.UUID]bool),
+	}
+
+	var tdlIDs, shipmentIDs []uuid.UUID
+	seenTDLs := make(map[uuid.UUID]bool)
+	var firstBookDate, lastBookDate, firstPickupDate, lastPickupDate *time.Time
+	for _, shipment := range shipments {
+		shipmentIDs = append(shipmentIDs, shipment.ID)
+		if shipment.TrafficDistributionListID != nil && !seenTDLs[*shipment.TrafficDistributionListID] {
+			seenTDLs[*shipment.TrafficDistributionListID] = true
+			tdlIDs = append(tdlIDs, *shipment.TrafficDistributionListID)
+		}
+		firstBookDate, lastBookDate = widenDateRange(firstBookDate, lastBookDate, shipment.BookDate)
+		firstPickupDate, lastPickupDate = widenDateRange(firstPickupDate, lastPickupDate, shipment.RequestedPickupDate)
+	}
+
+	tdls, err := models.FetchTDLsByIDs(db, tdlIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, tdl := range tdls {
+		batch.tdls[tdl.ID] = tdl
+	}
+
+	var tspIDs []uuid.UUID
+	if firstBookDate != nil {
+		perfs, err := models.FetchTSPPerformancesForAwardQueue(db, tdlIDs, *firstBookDate, *lastBookDate)
+		if err != nil {
+			return nil, err
+		}
+		seenTSPs := make(map[uuid.UUID]bool)
+		for i := range perfs {
+			perf := &perfs[i]
+			batch.performances[perf.TrafficDistributionListID] = append(batch.performances[perf.TrafficDistributionListID], perf)
+			if !seenTSPs[perf.TransportationServiceProviderID] {
+				seenTSPs[perf.TransportationServiceProviderID] = true
+				tspIDs = append(tspIDs, perf.TransportationServiceProviderID)
+			}
+		}
+	}
+
+	if firstPickupDate != nil {
+		blackouts, err := models.FetchBlackoutDatesForTSPs(db, tspIDs, *firstPickupDate, *lastPickupDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, blackout := range blackouts {
+			batch.blackouts[blackout.TransportationServiceProviderID] = append(batch.blackouts[blackout.TransportationServiceProviderID], blackout)
+		}
+	}
+
+	declinedOffers, err := models.FetchDeclinedShipmentOffers(db, shipmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, offer := range declinedOffers {
+		if batch.declined[offer.ShipmentID] == nil {
+			batch.declined[offer.ShipmentID] = make(map[uuid.UUID]bool)
+		}
+		batch.declined[offer.ShipmentID][offer.TransportationServiceProviderID] = true
+	}
+
+	return &batch, nil
+}
+
+// widenDateRange extends first to last to include date, if it is set
+func widenDateRange(first *time.Time, last *time.Time, date *time.Time) (*time.Time, *time.Time) {
+	if date == nil {
+		return first, last
+	}
+	if first == nil || date.Before(*first) {
+		first = date
+	}
+	if last == nil || date.After(*last) {
+		last = date
+	}
+	return first, last
+}
+
+// sortShipmentsByTDL orders shipments so that those in the same TDL are offered one after another, keeping
+// their order otherwise
+func sortShipmentsByTDL(shipments models.Shipments) {
+	tdlOrder := make(map[uuid.UUID]int)
+	for _, shipment := range shipments {
+		if shipment.TrafficDistributionListID == nil {
+			continue
+		}
+		if _, ok := tdlOrder[*shipment.TrafficDistributionListID]; !ok {
+			tdlOrder[*shipment.TrafficDistributionListID] = len(tdlOrder)
+		}
+	}
+	position := func(shipment models.Shipment) int {
+		if shipment.TrafficDistributionListID == nil {
+			return len(tdlOrder)
+		}
+		return tdlOrder[*shipment.TrafficDistributionListID]
+	}
+	sort.SliceStable(shipments, func(i, j int) bool {
+		return position(shipments[i]) < position(shipments[j])
+	})
+}
+
+// tdl returns the TDL with the given ID
+func (b *awardBatch) tdl(id uuid.UUID) (models.TrafficDistributionList, error) {
+	tdl, ok := b.tdls[id]
+	if !ok {
+		return tdl, errors.Errorf("Cannot find TDL %s in database", id)
+	}
+	return tdl, nil
+}
+
+// gatherNextEligibleTSPPerformances returns the next TSP performance in each quality band of the shipment's TDL,
+// like models.GatherNextEligibleTSPPerformances
+func (b *awardBatch) gatherNextEligibleTSPPerformances(tdlID uuid.UUID, shipment models.Shipment, policy models.AwardQueuePolicy) (map[int]models.TransportationServiceProviderPerformance, error) {
+	tspPerformances := make(map[int]models.TransportationServiceProviderPerformance)
+	for _, perf := range b.performances[tdlID] {
+		band := *perf.QualityBand
+		if band < 1 || band > policy.NumQualityBands {
+			continue
+		}
+		if _, ok := tspPerformances[band]; ok {
+			continue
+		}
+		if b.eligible(*perf, shipment, policy) {
+			tspPerformances[band] = *perf
+		}
+	}
+	if len(tspPerformances) == 0 {
+		return tspPerformances, fmt.Errorf("Could not find any TSPs to fill quality bands in TDL: %s", tdlID)
+	}
+	return tspPerformances, nil
+}
+
+// eligible reports whether the shipment can be offered to the TSP performance, using the same rules as
+// models.NextTSPPerformanceInQualityBand
+func (b *awardBatch) eligible(perf models.TransportationServiceProviderPerformance, shipment models.Shipment, policy models.AwardQueuePolicy) bool {
+	bookDate := *shipment.BookDate
+	if bookDate.Before(perf.PerformancePeriodStart) || bookDate.After(perf.PerformancePeriodEnd) {
+		return false
+	}
+	pickupDate := *shipment.RequestedPickupDate
+	if pickupDate.Before(perf.RateCycleStart) || pickupDate.After(perf.RateCycleEnd) {
+		return false
+	}
+	if policy.MaxOffersPerTSP != nil && perf.OfferCount >= *policy.MaxOffersPerTSP {
+		return false
+	}
+	return !b.declined[shipment.ID][perf.TransportationServiceProviderID]
+}
+
+// shipmentWithinBlackoutDates reports whether the TSP has a blackout date that applies to the shipment,
+// like AwardQueue.ShipmentWithinBlackoutDates
+func (b *awardBatch) shipmentWithinBlackoutDates(tspID uuid.UUID, shipment models.Shipment) bool {
+	for _, blackout := range b.blackouts[tspID] {
+		if blackout.AppliesTo(shipment) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordOffer counts an offer against the TSP performance, keeping it in the order the award queue offers
+// shipments
+func (b *awardBatch) recordOffer(perf models.TransportationServiceProviderPerformance) {
+	perfs := b.performances[perf.TrafficDistributionListID]
+	for _, p := range perfs {
+		if p.ID == perf.ID {
+			p.OfferCount = perf.OfferCount
+		}
+	}
+	sort.SliceStable(perfs, func(i, j int) bool {
+		if perfs[i].OfferCount != perfs[j].OfferCount {
+			return perfs[i].OfferCount < perfs[j].OfferCount
+		}
+		return perfs[i].BestValueScore > perfs[j].BestValueScore
+	})
+}
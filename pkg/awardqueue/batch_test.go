@@ -0,0 +1,108 @@
+package awardqueue
+
+import (
+	"context"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *AwardQueueSuite) TestAssignShipmentsBatchesShipmentsAcrossTDLs() {
+	shipments := suite.makeFairnessReportShipments(4, nil)
+	tdl := *shipments[0].TrafficDistributionList
+	otherTDL := testdatagen.MakeTDL(suite.DB(), testdatagen.Assertions{
+		TrafficDistributionList: models.TrafficDistributionList{
+			DestinationRegion: "5",
+		},
+	})
+	shipments = append(shipments, suite.makeFairnessReportShipments(4, &otherTDL)...)
+
+	var tsps []models.TransportationServiceProvider
+	for _, t := range []models.TrafficDistributionList{tdl, otherTDL} {
+		for i := 0; i < 2; i++ {
+			tsp := testdatagen.MakeDefaultTSP(suite.DB())
+			_, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, t, swag.Int(1), mps+float64(2-i), 0, .3, .3)
+			suite.NoError(err)
+			tsps = append(tsps, tsp)
+		}
+	}
+
+	offered, unoffered := suite.newAwardQueue().assignShipments(context.Background())
+	suite.Equal(8, offered)
+	suite.Equal(0, unoffered)
+
+	// Offer counts are kept up to date between shipments, so each TDL's shipments are shared between its TSPs
+	for _, tsp := range tsps {
+		suite.verifyOfferCount(tsp, 2)
+	}
+	for _, shipment := range shipments {
+		decisions, err := models.FetchAwardQueueDecisionsForShipment(suite.DB(), shipment.ID)
+		suite.NoError(err)
+		suite.Len(decisions, 1)
+		suite.Equal(models.AwardQueueDecisionOutcomeOFFERED, decisions[0].Outcome)
+	}
+}
+
+func (suite *AwardQueueSuite) TestAwardBatchSkipsDeclinedTSPsAndFindsBlackouts() {
+	shipment, firstTSP, secondTSP := suite.setUpReoffer()
+	tdlID := *shipment.TrafficDistributionListID
+	policy := models.DefaultAwardQueuePolicy()
+
+	accepted := false
+	testdatagen.MakeShipmentOffer(suite.DB(), testdatagen.Assertions{
+		ShipmentOffer: models.ShipmentOffer{
+			Shipment:                        shipment,
+			ShipmentID:                      shipment.ID,
+			TransportationServiceProvider:   firstTSP,
+			TransportationServiceProviderID: firstTSP.ID,
+			Accepted:                        &accepted,
+		},
+	})
+	testdatagen.MakeBlackoutDate(suite.DB(), testdatagen.Assertions{
+		BlackoutDate: models.BlackoutDate{
+			TransportationServiceProviderID: secondTSP.ID,
+			StartBlackoutDate:               *shipment.RequestedPickupDate,
+			EndBlackoutDate:                 *shipment.RequestedPickupDate,
+			TrafficDistributionListID:       &tdlID,
+			SourceGBLOC:                     shipment.SourceGBLOC,
+			Market:                          shipment.Market,
+		},
+	})
+
+	batch, err := newAwardBatch(suite.DB(), models.Shipments{shipment})
+	suite.NoError(err)
+
+	tspPerformances, err := batch.gatherNextEligibleTSPPerformances(tdlID, shipment, policy)
+	suite.NoError(err)
+	suite.Equal(secondTSP.ID, tspPerformances[1].TransportationServiceProviderID)
+	suite.True(batch.shipmentWithinBlackoutDates(secondTSP.ID, shipment))
+	suite.False(batch.shipmentWithinBlackoutDates(firstTSP.ID, shipment))
+
+	// The batch agrees with the award queue's queries
+	next, err := models.NextEligibleTSPPerformance(suite.DB(), tdlID, *shipment.BookDate, *shipment.RequestedPickupDate, policy, shipment.ID)
+	suite.NoError(err)
+	suite.Equal(next.ID, tspPerformances[1].ID)
+	withinBlackout, err := suite.newAwardQueue().ShipmentWithinBlackoutDates(secondTSP.ID, shipment)
+	suite.NoError(err)
+	suite.True(withinBlackout)
+}
+
+func (suite *AwardQueueSuite) TestSortShipmentsByTDL() {
+	shipments := suite.makeFairnessReportShipments(2, nil)
+	otherTDL := testdatagen.MakeTDL(suite.DB(), testdatagen.Assertions{
+		TrafficDistributionList: models.TrafficDistributionList{
+			DestinationRegion: "5",
+		},
+	})
+	others := suite.makeFairnessReportShipments(2, &otherTDL)
+	unsorted := models.Shipments{shipments[0], others[0], shipments[1], others[1]}
+
+	sortShipmentsByTDL(unsorted)
+
+	suite.Equal(shipments[0].ID, unsorted[0].ID)
+	suite.Equal(shipments[1].ID, unsorted[1].ID)
+	suite.Equal(others[0].ID, unsorted[2].ID)
+	suite.Equal(others[1].ID, unsorted[3].ID)
+}
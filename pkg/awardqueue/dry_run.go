@@ -0,0 +1,131 @@
+package awardqueue
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// SimulatedOffer is a shipment offer the award queue made during a dry run
+type SimulatedOffer struct {
+	ShipmentID             uuid.UUID
+	TSPID                  uuid.UUID
+	SCAC                   string
+	QualityBand            *int
+	AdministrativeShipment bool
+}
+
+// UnofferedShipment is a shipment the award queue could not offer during a dry run
+type UnofferedShipment struct {
+	ShipmentID uuid.UUID
+	Reason     string
+}
+
+// DryRunReport describes what the award queue would have done, without any of it being saved
+type DryRunReport struct {
+	Offers             []SimulatedOffer
+	UnofferedShipments []UnofferedShipment
+	// OffersPerBand counts the offers that awarded a shipment, keyed by quality band
+	OffersPerBand map[int]int
+	// AdministrativeOffers counts the offers made to TSPs in a blackout period, which don't award the shipment
+	AdministrativeOffers int
+	// ExpiredOffers are the offers that passed their acceptance deadline, whose shipments were offered again
+	ExpiredOffers []models.ShipmentOffer
+}
+
+func newDryRunReport() *DryRunReport {
+	return &DryRunReport{
+		OffersPerBand: map[int]int{},
+	}
+}
+
+// addOffer records an offer. It does nothing outside of a dry run, when the report is nil.
+func (r *DryRunReport) addOffer(shipment models.Shipment, tsp models.TransportationServiceProvider,
+	tspPerformance models.TransportationServiceProviderPerformance, administrativeShipment bool) {
+	if r == nil {
+		return
+	}
+
+	r.Offers = append(r.Offers, SimulatedOffer{
+		ShipmentID:             shipment.ID,
+		TSPID:                  tsp.ID,
+		SCAC:                   tsp.StandardCarrierAlphaCode,
+		QualityBand:            tspPerformance.QualityBand,
+		AdministrativeShipment: administrativeShipment,
+	})
+
+	if administrativeShipment {
+		r.AdministrativeOffers++
+	} else if tspPerformance.QualityBand != nil {
+		r.OffersPerBand[*tspPerformance.QualityBand]++
+	}
+}
+
+// addExpiredOffer records an offer that expired. It does nothing outside of a dry run.
+func (r *DryRunReport) addExpiredOffer(offer models.ShipmentOffer) {
+	if r == nil {
+		return
+	}
+
+	r.ExpiredOffers = append(r.ExpiredOffers, offer)
+}
+
+// addUnofferedShipment records a shipment that could not be offered. It does nothing outside of a dry run.
+func (r *DryRunReport) addUnofferedShipment(shipment models.Shipment, err error) {
+	if r == nil {
+		return
+	}
+
+	r.UnofferedShipments = append(r.UnofferedShipments, UnofferedShipment{
+		ShipmentID: shipment.ID,
+		Reason:     err.Error(),
+	})
+}
+
+// Write prints the report in a human readable form
+func (r *DryRunReport) Write(w io.Writer) error {
+	lines := []string{"Award queue dry run - no changes were saved", "", "Offers:"}
+	for _, offer := range r.Offers {
+		band := "unbanded"
+		if offer.QualityBand != nil {
+			band = fmt.Sprintf("band %d", *offer.QualityBand)
+		}
+		line := fmt.Sprintf("  shipment %s -> TSP %s (%s, %s)", offer.ShipmentID, offer.SCAC, offer.TSPID, band)
+		if offer.AdministrativeShipment {
+			line += " administrative, TSP is in a blackout period"
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", "Offers per quality band:")
+	bands := make([]int, 0, len(r.OffersPerBand))
+	for band := range r.OffersPerBand {
+		bands = append(bands, band)
+	}
+	sort.Ints(bands)
+	for _, band := range bands {
+		lines = append(lines, fmt.Sprintf("  band %d: %d", band, r.OffersPerBand[band]))
+	}
+	lines = append(lines, fmt.Sprintf("  administrative: %d", r.AdministrativeOffers))
+
+	lines = append(lines, "", "Expired offers:")
+	for _, offer := range r.ExpiredOffers {
+		lines = append(lines, fmt.Sprintf("  shipment %s, TSP %s", offer.ShipmentID, offer.TransportationServiceProviderID))
+	}
+
+	lines = append(lines, "", "Shipments not offered:")
+	for _, unoffered := range r.UnofferedShipments {
+		lines = append(lines, fmt.Sprintf("  shipment %s: %s", unoffered.ShipmentID, unoffered.Reason))
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
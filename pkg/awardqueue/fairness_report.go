@@ -0,0 +1,315 @@
+package awardqueue
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+const reportDateFormat = "2006-01-02"
+
+// driftTolerance is how many offers a TSP can be from its expected count before it is flagged.
+// The award queue offers shipments one at a time, so a TSP following the rotation is never more than one offer out.
+const driftTolerance = 1
+
+// TSPFairness compares the offers made to one TSP against the award queue's rotation
+type TSPFairness struct {
+	TSPPerformanceID uuid.UUID
+	TSPID            uuid.UUID
+	SCAC             string
+	QualityBand      *int
+	BestValueScore   float64
+	// OfferCount is the count on the TSP's performance record, covering the whole performance period
+	OfferCount int
+	// Offers counts the offers made to the TSP for shipments booked in the date range, including administrative offers
+	Offers               int
+	AdministrativeOffers int
+	ExpectedOffers       int
+	Drift                bool
+}
+
+// BandFairness compares the TSPs and offers in one quality band against the award queue's rotation
+type BandFairness struct {
+	QualityBand    int
+	TSPs           int
+	ExpectedTSPs   int
+	Offers         int
+	ExpectedOffers int
+	Drift          bool
+}
+
+// PerformancePeriodFairness is the part of a fairness report for one performance period
+type PerformancePeriodFairness struct {
+	PerformancePeriodStart time.Time
+	PerformancePeriodEnd   time.Time
+	RateCycleStart         time.Time
+	RateCycleEnd           time.Time
+	Offers                 int
+	AdministrativeOffers   int
+	Bands                  []BandFairness
+	TSPs                   []TSPFairness
+}
+
+// FairnessReport compares the shipment offers made in a TDL during a date range with the offers the
+// award queue's rotation through the quality bands would be expected to make
+type FairnessReport struct {
+	TrafficDistributionListID uuid.UUID
+	Start                     time.Time
+	End                       time.Time
+	PerformancePeriods        []PerformancePeriodFairness
+	// Flags describe drift from the expected distribution, and administrative offers caused by blackout dates
+	Flags []string
+}
+
+// simulatedTSP is a TSP's place in the rotation while replaying the award queue
+type simulatedTSP struct {
+	index      int
+	offerCount int
+}
+
+// expectedOffers replays the award queue's choices for offerCount offers: each offer goes to the band picked by
+// models.SelectNextTSPPerformance, and within the band to the TSP with the fewest offers, then the highest BVS.
+// bands holds each band's TSPs in BVS order, starting from the offers they had before the date range.
+// It returns the expected number of offers for each TSP, by index.
+func expectedOffers(bands map[int][]*simulatedTSP, offerCount int, policy models.AwardQueuePolicy, tspCount int) []int {
+	expected := make([]int, tspCount)
+	for i := 0; i < offerCount; i++ {
+		next := map[int]*simulatedTSP{}
+		nextPerformances := map[int]models.TransportationServiceProviderPerformance{}
+		for band, tsps := range bands {
+			for _, tsp := range tsps {
+				if policy.MaxOffersPerTSP != nil && tsp.offerCount >= *policy.MaxOffersPerTSP {
+					continue
+				}
+				if next[band] == nil || tsp.offerCount < next[band].offerCount {
+					next[band] = tsp
+				}
+			}
+			if next[band] != nil {
+				qualityBand := band
+				nextPerformances[band] = models.TransportationServiceProviderPerformance{
+					QualityBand: &qualityBand,
+					OfferCount:  next[band].offerCount,
+				}
+			}
+		}
+		if len(nextPerformances) == 0 {
+			// Every TSP has reached the offer limit
+			break
+		}
+
+		selected := next[*models.SelectNextTSPPerformance(nextPerformances).QualityBand]
+		selected.offerCount++
+		expected[selected.index]++
+	}
+	return expected
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// NewFairnessReport builds a fairness report for the offers made in a TDL for shipments booked between start and
+// end, inclusive
+func NewFairnessReport(db *pop.Connection, tdlID uuid.UUID, start time.Time, end time.Time) (*FairnessReport, error) {
+	policies, err := models.FetchCurrentAwardQueuePolicies(db)
+	if err != nil {
+		return nil, err
+	}
+	perfs, err := models.FetchTSPPerformancesForTDL(db, tdlID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	offers, err := models.FetchShipmentOffersForTDL(db, tdlID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	// Offers for shipments booked before the date range set where each TSP starts in the rotation
+	priorOffers, err := models.FetchShipmentOffersForTDL(db, tdlID, time.Time{}, start.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	offersByPerformance := map[uuid.UUID]int{}
+	administrativeOffersByPerformance := map[uuid.UUID]int{}
+	for _, offer := range offers {
+		offersByPerformance[offer.TransportationServiceProviderPerformanceID]++
+		if offer.AdministrativeShipment {
+			administrativeOffersByPerformance[offer.TransportationServiceProviderPerformanceID]++
+		}
+	}
+	priorOffersByPerformance := map[uuid.UUID]int{}
+	for _, offer := range priorOffers {
+		priorOffersByPerformance[offer.TransportationServiceProviderPerformanceID]++
+	}
+
+	report := &FairnessReport{
+		TrafficDistributionListID: tdlID,
+		Start:                     start,
+		End:                       end,
+	}
+
+	// Performances are ordered by performance period, so each period's performances are together
+	var periodPerfs models.TransportationServiceProviderPerformances
+	for i, perf := range perfs {
+		periodPerfs = append(periodPerfs, perf)
+		if i+1 < len(perfs) && perfs[i+1].PerformancePeriodStart.Equal(perf.PerformancePeriodStart) &&
+			perfs[i+1].PerformancePeriodEnd.Equal(perf.PerformancePeriodEnd) {
+			continue
+		}
+		period := report.performancePeriodFairness(periodPerfs, policies.ForDate(perf.RateCycleStart),
+			offersByPerformance, administrativeOffersByPerformance, priorOffersByPerformance)
+		report.PerformancePeriods = append(report.PerformancePeriods, period)
+		periodPerfs = nil
+	}
+
+	return report, nil
+}
+
+// performancePeriodFairness compares the offers made to the TSPs in one performance period with the
+// expected distribution, adding flags to the report for anything out of line
+func (r *FairnessReport) performancePeriodFairness(perfs models.TransportationServiceProviderPerformances,
+	policy models.AwardQueuePolicy, offers map[uuid.UUID]int, administrativeOffers map[uuid.UUID]int,
+	priorOffers map[uuid.UUID]int) PerformancePeriodFairness {
+
+	period := PerformancePeriodFairness{
+		PerformancePeriodStart: perfs[0].PerformancePeriodStart,
+		PerformancePeriodEnd:   perfs[0].PerformancePeriodEnd,
+		RateCycleStart:         perfs[0].RateCycleStart,
+		RateCycleEnd:           perfs[0].RateCycleEnd,
+	}
+	periodName := fmt.Sprintf("Performance period %s to %s", period.PerformancePeriodStart.Format(reportDateFormat),
+		period.PerformancePeriodEnd.Format(reportDateFormat))
+
+	bandedCount := 0
+	bandedOffers := 0
+	simulatedBands := map[int][]*simulatedTSP{}
+	for i, perf := range perfs {
+		tsp := TSPFairness{
+			TSPPerformanceID:     perf.ID,
+			TSPID:                perf.TransportationServiceProviderID,
+			SCAC:                 perf.TransportationServiceProvider.StandardCarrierAlphaCode,
+			QualityBand:          perf.QualityBand,
+			BestValueScore:       perf.BestValueScore,
+			OfferCount:           perf.OfferCount,
+			Offers:               offers[perf.ID],
+			AdministrativeOffers: administrativeOffers[perf.ID],
+		}
+		period.TSPs = append(period.TSPs, tsp)
+		period.Offers += tsp.Offers
+		period.AdministrativeOffers += tsp.AdministrativeOffers
+
+		if perf.QualityBand == nil {
+			if tsp.Offers > 0 {
+				r.Flags = append(r.Flags, fmt.Sprintf("%s: %s received %d offers without a quality band",
+					periodName, tsp.SCAC, tsp.Offers))
+			}
+			continue
+		}
+		bandedCount++
+		bandedOffers += tsp.Offers
+		simulatedBands[*perf.QualityBand] = append(simulatedBands[*perf.QualityBand],
+			&simulatedTSP{index: i, offerCount: priorOffers[perf.ID]})
+	}
+
+	expected := expectedOffers(simulatedBands, bandedOffers, policy, len(perfs))
+	for i := range period.TSPs {
+		tsp := &period.TSPs[i]
+		if tsp.QualityBand == nil {
+			continue
+		}
+		tsp.ExpectedOffers = expected[i]
+		tsp.Drift = abs(tsp.Offers-tsp.ExpectedOffers) > driftTolerance
+		if tsp.Drift {
+			r.Flags = append(r.Flags, fmt.Sprintf("%s: %s in band %d received %d offers, expected %d",
+				periodName, tsp.SCAC, *tsp.QualityBand, tsp.Offers, tsp.ExpectedOffers))
+		}
+		if tsp.AdministrativeOffers > 0 {
+			r.Flags = append(r.Flags, fmt.Sprintf("%s: %s in band %d received %d administrative offers because of blackout dates",
+				periodName, tsp.SCAC, *tsp.QualityBand, tsp.AdministrativeOffers))
+		}
+	}
+
+	expectedTSPs := getTSPsPerBand(bandedCount, policy)
+	for band := 1; band <= policy.NumQualityBands || len(simulatedBands[band]) > 0; band++ {
+		bandFairness := BandFairness{QualityBand: band}
+		if band <= len(expectedTSPs) {
+			bandFairness.ExpectedTSPs = expectedTSPs[band-1]
+		}
+		for _, tsp := range simulatedBands[band] {
+			bandFairness.TSPs++
+			bandFairness.Offers += period.TSPs[tsp.index].Offers
+			bandFairness.ExpectedOffers += period.TSPs[tsp.index].ExpectedOffers
+		}
+
+		// A band is expected to be up to one round of offers out, part way through a round
+		tolerance := models.OffersPerQualityBand[band]
+		if tolerance < driftTolerance {
+			tolerance = driftTolerance
+		}
+		bandFairness.Drift = abs(bandFairness.Offers-bandFairness.ExpectedOffers) > tolerance
+		if bandFairness.Drift {
+			r.Flags = append(r.Flags, fmt.Sprintf("%s: band %d received %d offers, expected %d",
+				periodName, band, bandFairness.Offers, bandFairness.ExpectedOffers))
+		}
+		if bandFairness.TSPs != bandFairness.ExpectedTSPs {
+			r.Flags = append(r.Flags, fmt.Sprintf("%s: band %d has %d TSPs, expected %d",
+				periodName, band, bandFairness.TSPs, bandFairness.ExpectedTSPs))
+		}
+		period.Bands = append(period.Bands, bandFairness)
+	}
+
+	return period
+}
+
+// Write prints the report in a human readable form
+func (r *FairnessReport) Write(w io.Writer) error {
+	lines := []string{fmt.Sprintf("Award queue fairness report for TDL %s, %s to %s",
+		r.TrafficDistributionListID, r.Start.Format(reportDateFormat), r.End.Format(reportDateFormat))}
+
+	for _, period := range r.PerformancePeriods {
+		lines = append(lines, "", fmt.Sprintf("Performance period %s to %s: %d offers, %d administrative",
+			period.PerformancePeriodStart.Format(reportDateFormat), period.PerformancePeriodEnd.Format(reportDateFormat),
+			period.Offers, period.AdministrativeOffers))
+		for _, band := range period.Bands {
+			line := fmt.Sprintf("  band %d: %d TSPs (expected %d), %d offers (expected %d)",
+				band.QualityBand, band.TSPs, band.ExpectedTSPs, band.Offers, band.ExpectedOffers)
+			if band.Drift {
+				line += " DRIFT"
+			}
+			lines = append(lines, line)
+		}
+		for _, tsp := range period.TSPs {
+			band := "unbanded"
+			if tsp.QualityBand != nil {
+				band = fmt.Sprintf("band %d", *tsp.QualityBand)
+			}
+			line := fmt.Sprintf("  %s (%s, bvs %.4f): %d offers (expected %d), %d administrative, offer count %d",
+				tsp.SCAC, band, tsp.BestValueScore, tsp.Offers, tsp.ExpectedOffers, tsp.AdministrativeOffers, tsp.OfferCount)
+			if tsp.Drift {
+				line += " DRIFT"
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	lines = append(lines, "", "Flags:")
+	for _, flag := range r.Flags {
+		lines = append(lines, "  "+flag)
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package awardqueue
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+// makeFairnessReportShipments makes submitted shipments in one TDL, booked inside the performance period
+func (suite *AwardQueueSuite) makeFairnessReportShipments(count int, tdl *models.TrafficDistributionList) []models.Shipment {
+	market := testdatagen.DefaultMarket
+	sourceGBLOC := testdatagen.DefaultSrcGBLOC
+	pickupDate := testdatagen.DateInsidePeakRateCycle
+	deliveryDate := testdatagen.DateInsidePeakRateCycle.Add(time.Hour)
+
+	var shipments []models.Shipment
+	for i := 0; i < count; i++ {
+		assertions := testdatagen.Assertions{
+			Shipment: models.Shipment{
+				RequestedPickupDate: &pickupDate,
+				ActualPickupDate:    &pickupDate,
+				ActualDeliveryDate:  &deliveryDate,
+				SourceGBLOC:         &sourceGBLOC,
+				Market:              &market,
+				Status:              models.ShipmentStatusSUBMITTED,
+			},
+		}
+		if tdl != nil {
+			assertions.Shipment.TrafficDistributionList = tdl
+		}
+		shipments = append(shipments, testdatagen.MakeShipment(suite.DB(), assertions))
+	}
+	return shipments
+}
+
+// makeBandedTSPs makes one TSP in each quality band of tdl
+func (suite *AwardQueueSuite) makeBandedTSPs(tdl models.TrafficDistributionList) []models.TransportationServiceProviderPerformance {
+	var perfs []models.TransportationServiceProviderPerformance
+	for band := 1; band <= 4; band++ {
+		tsp := testdatagen.MakeDefaultTSP(suite.DB())
+		perf, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, tdl, swag.Int(band), mps+float64(5-band), 0, .3, .3)
+		suite.NoError(err)
+		perf.TransportationServiceProvider = tsp
+		perfs = append(perfs, perf)
+	}
+	return perfs
+}
+
+func (suite *AwardQueueSuite) TestFairnessReportFollowsRotation() {
+	shipments := suite.makeFairnessReportShipments(8, nil)
+	tdl := *shipments[0].TrafficDistributionList
+	suite.makeBandedTSPs(tdl)
+
+	suite.newAwardQueue().assignShipments(context.Background())
+
+	report, err := NewFairnessReport(suite.DB(), tdl.ID, testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+	suite.Empty(report.Flags)
+	suite.Len(report.PerformancePeriods, 1)
+
+	period := report.PerformancePeriods[0]
+	suite.Equal(8, period.Offers)
+	suite.Equal(0, period.AdministrativeOffers)
+	suite.Len(period.Bands, 4)
+	suite.Len(period.TSPs, 4)
+	for _, tsp := range period.TSPs {
+		suite.Equal(2, tsp.Offers)
+		suite.Equal(2, tsp.ExpectedOffers)
+		suite.False(tsp.Drift)
+	}
+}
+
+func (suite *AwardQueueSuite) TestFairnessReportFlagsDriftAndAdministrativeOffers() {
+	shipments := suite.makeFairnessReportShipments(8, nil)
+	tdl := *shipments[0].TrafficDistributionList
+	perfs := suite.makeBandedTSPs(tdl)
+
+	suite.newAwardQueue().assignShipments(context.Background())
+
+	// Offer more shipments to the band 4 TSP than the rotation would, one of them administratively
+	band4 := perfs[3]
+	for i, shipment := range suite.makeFairnessReportShipments(3, &tdl) {
+		testdatagen.MakeShipmentOffer(suite.DB(), testdatagen.Assertions{
+			ShipmentOffer: models.ShipmentOffer{
+				Shipment:                                   shipment,
+				ShipmentID:                                 shipment.ID,
+				TransportationServiceProvider:              band4.TransportationServiceProvider,
+				TransportationServiceProviderID:            band4.TransportationServiceProviderID,
+				TransportationServiceProviderPerformance:   band4,
+				TransportationServiceProviderPerformanceID: band4.ID,
+				AdministrativeShipment:                     i == 0,
+			},
+		})
+	}
+
+	report, err := NewFairnessReport(suite.DB(), tdl.ID, testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+	suite.Len(report.PerformancePeriods, 1)
+
+	period := report.PerformancePeriods[0]
+	suite.Equal(11, period.Offers)
+	suite.Equal(1, period.AdministrativeOffers)
+
+	band4Fairness := period.TSPs[3]
+	suite.Equal(band4.ID, band4Fairness.TSPPerformanceID)
+	suite.Equal(5, band4Fairness.Offers)
+	suite.Equal(1, band4Fairness.AdministrativeOffers)
+	suite.True(band4Fairness.Drift)
+	suite.True(period.Bands[3].Drift)
+
+	scac := band4.TransportationServiceProvider.StandardCarrierAlphaCode
+	var driftFlagged, administrativeFlagged bool
+	for _, flag := range report.Flags {
+		if strings.Contains(flag, scac+" in band 4 received 5 offers") {
+			driftFlagged = true
+		}
+		if strings.Contains(flag, scac+" in band 4 received 1 administrative offers") {
+			administrativeFlagged = true
+		}
+	}
+	suite.True(driftFlagged, "expected a drift flag in %v", report.Flags)
+	suite.True(administrativeFlagged, "expected an administrative offer flag in %v", report.Flags)
+
+	// Shipments booked outside the date range are left out
+	report, err = NewFairnessReport(suite.DB(), tdl.ID, testdatagen.PerformancePeriodEnd.AddDate(0, 0, 1),
+		testdatagen.PerformancePeriodEnd.AddDate(0, 0, 30))
+	suite.NoError(err)
+	suite.Empty(report.PerformancePeriods)
+}
+
+func (suite *AwardQueueSuite) TestExpectedOffersRespectsMaxOffersPerTSP() {
+	bands := map[int][]*simulatedTSP{
+		1: {{index: 0}, {index: 1}},
+		2: {{index: 2}},
+	}
+	policy := models.DefaultAwardQueuePolicy()
+
+	suite.Equal([]int{2, 2, 2}, expectedOffers(bands, 6, policy, 3))
+
+	bands = map[int][]*simulatedTSP{
+		1: {{index: 0}, {index: 1}},
+		2: {{index: 2}},
+	}
+	policy.MaxOffersPerTSP = swag.Int(1)
+	suite.Equal([]int{1, 1, 1}, expectedOffers(bands, 6, policy, 3))
+}
@@ -0,0 +1,182 @@
+package awardqueue
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/gobuffalo/pop"
+	beeline "github.com/honeycombio/beeline-go"
+	"go.uber.org/zap"
+)
+
+// DefaultWorkerInterval is how often the award queue worker runs
+const DefaultWorkerInterval = time.Minute
+
+// maxBackoffIntervals caps how many intervals the worker waits after runs that fail or are skipped
+const maxBackoffIntervals = 8
+
+// maxConsecutiveFailures is how many runs in a row can fail before the worker reports itself unhealthy
+const maxConsecutiveFailures = 3
+
+// WorkerHealth describes how the award queue worker is doing, for the /health endpoint
+type WorkerHealth struct {
+	Healthy             bool       `json:"healthy"`
+	Interval            string     `json:"interval"`
+	StartedAt           time.Time  `json:"started_at"`
+	LastRunAt           *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastRunDuration     string     `json:"last_run_duration,omitempty"`
+	LastRun             RunStats   `json:"last_run"`
+	Runs                int        `json:"runs"`
+	SkippedRuns         int        `json:"skipped_runs"`
+	FailedRuns          int        `json:"failed_runs"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+}
+
+// Worker runs the award queue on an interval for as long as its context lives, so that shipments are
+// offered shortly after they are submitted. Several workers can run at once, such as one per webserver
+// instance: each run only proceeds if no other award queue holds the lock, and a worker that finds the
+// lock held backs off before trying again.
+type Worker struct {
+	db       *pop.Connection
+	logger   Logger
+	interval time.Duration
+
+	mutex  sync.RWMutex
+	health WorkerHealth
+	// backoff counts the runs in a row that failed or were skipped
+	backoff int
+}
+
+// NewWorker creates a new Worker that runs the award queue every interval
+func NewWorker(db *pop.Connection, logger Logger, interval time.Duration) *Worker {
+	return &Worker{
+		db:       db,
+		logger:   logger,
+		interval: interval,
+		health: WorkerHealth{
+			Healthy:  true,
+			Interval: interval.String(),
+		},
+	}
+}
+
+// Start runs the award queue until ctx is done. It blocks, so is usually started in a goroutine.
+func (w *Worker) Start(ctx context.Context) {
+	w.mutex.Lock()
+	w.health.StartedAt = time.Now()
+	w.mutex.Unlock()
+
+	w.logger.Info("Starting award queue worker", zap.Duration("interval", w.interval))
+	timer := time.NewTimer(w.nextWait())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Stopping award queue worker")
+			return
+		case <-timer.C:
+			w.runOnce(ctx)
+			timer.Reset(w.nextWait())
+		}
+	}
+}
+
+// nextWait returns how long to wait before the next run: the interval, doubled for each run in a row
+// that failed or was skipped, up to maxBackoffIntervals. Up to a tenth of the interval is added at random
+// so that workers started together don't keep contending for the lock at the same moment.
+func (w *Worker) nextWait() time.Duration {
+	w.mutex.RLock()
+	backoff := w.backoff
+	w.mutex.RUnlock()
+
+	wait := w.interval
+	for i := 0; i < backoff && wait < maxBackoffIntervals*w.interval; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoffIntervals*w.interval {
+		wait = maxBackoffIntervals * w.interval
+	}
+	if jitter := int64(w.interval / 10); jitter > 0 {
+		// #nosec G404 the jitter doesn't need to be cryptographically random
+		wait += time.Duration(rand.Int63n(jitter))
+	}
+	return wait
+}
+
+// runOnce runs the award queue once, unless another award queue is running, and records the outcome
+func (w *Worker) runOnce(ctx context.Context) {
+	ctx, span := beeline.StartSpan(ctx, "awardqueue_worker")
+	defer span.Send()
+
+	start := time.Now()
+	var stats RunStats
+	aq, err := NewAwardQueue(w.db, w.logger)
+	if err == nil {
+		stats, err = aq.tryRun(ctx)
+	}
+	duration := time.Since(start)
+
+	span.AddField("run_duration_ms", duration.Nanoseconds()/int64(time.Millisecond))
+	span.AddField("expired_offers", stats.ExpiredOffers)
+	span.AddField("shipments_offered", stats.ShipmentsOffered)
+	span.AddField("shipments_unoffered", stats.ShipmentsUnoffered)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.health.LastRunAt = &start
+	switch err {
+	case nil:
+		w.health.Runs++
+		w.health.LastSuccessAt = &start
+		w.health.LastError = ""
+		w.health.LastRunDuration = duration.String()
+		w.health.LastRun = stats
+		w.health.ConsecutiveFailures = 0
+		w.backoff = 0
+		w.logger.TraceInfo(ctx, "Award queue run complete",
+			zap.Duration("duration", duration),
+			zap.Int("expired_offers", stats.ExpiredOffers),
+			zap.Int("shipments_offered", stats.ShipmentsOffered),
+			zap.Int("shipments_unoffered", stats.ShipmentsUnoffered))
+	case ErrLockNotAcquired:
+		// Another instance is doing the work, which is as healthy as doing it ourselves
+		w.health.SkippedRuns++
+		w.health.LastSuccessAt = &start
+		w.health.ConsecutiveFailures = 0
+		w.backoff++
+		span.AddField("skipped", true)
+	default:
+		w.health.FailedRuns++
+		w.health.LastError = err.Error()
+		w.health.ConsecutiveFailures++
+		w.backoff++
+		w.logger.TraceError(ctx, "Award queue run failed",
+			zap.Duration("duration", duration),
+			zap.Int("consecutive_failures", w.health.ConsecutiveFailures),
+			zap.Error(err))
+	}
+	w.health.Healthy = w.health.ConsecutiveFailures < maxConsecutiveFailures
+}
+
+// Health returns a snapshot of how the worker is doing. A worker that hasn't completed or skipped a run
+// in twice its longest back-off is reported unhealthy, as it is likely stuck.
+func (w *Worker) Health() WorkerHealth {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	health := w.health
+	lastProgress := health.StartedAt
+	if health.LastSuccessAt != nil {
+		lastProgress = *health.LastSuccessAt
+	}
+	if !health.StartedAt.IsZero() && time.Since(lastProgress) > 2*maxBackoffIntervals*w.interval {
+		health.Healthy = false
+	}
+	return health
+}
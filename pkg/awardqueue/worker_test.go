@@ -0,0 +1,75 @@
+package awardqueue
+
+import (
+	"context"
+	"time"
+
+	"github.com/gobuffalo/pop"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *AwardQueueSuite) TestWorkerOffersShipments() {
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &testdatagen.DateInsidePeakRateCycle,
+			ActualPickupDate:    &testdatagen.DateInsidePeakRateCycle,
+			BookDate:            &testdatagen.PerformancePeriodStart,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	_, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, *shipment.TrafficDistributionList, nil, mps+1, 0, .3, .3)
+	suite.NoError(err)
+
+	worker := NewWorker(suite.DB(), suite.logger, time.Minute)
+	worker.runOnce(context.Background())
+
+	health := worker.Health()
+	suite.True(health.Healthy)
+	suite.Equal(1, health.Runs)
+	suite.Equal(0, health.FailedRuns)
+	suite.Equal(RunStats{ShipmentsOffered: 1}, health.LastRun)
+	suite.NotNil(health.LastSuccessAt)
+	suite.verifyOfferCount(tsp, 1)
+}
+
+func (suite *AwardQueueSuite) TestWorkerSkipsRunWhileAnotherAwardQueueRuns() {
+	worker := NewWorker(suite.DB(), suite.logger, time.Minute)
+
+	// Another award queue holds the lock for the length of its transaction
+	err := suite.DB().Transaction(func(tx *pop.Connection) error {
+		if err := waitForLock(context.Background(), tx, awardQueueLockID); err != nil {
+			return err
+		}
+		worker.runOnce(context.Background())
+		return nil
+	})
+	suite.NoError(err)
+
+	health := worker.Health()
+	suite.True(health.Healthy)
+	suite.Equal(0, health.Runs)
+	suite.Equal(1, health.SkippedRuns)
+	suite.Equal(0, health.FailedRuns)
+
+	// The worker backs off before trying again
+	suite.True(worker.nextWait() >= 2*time.Minute)
+
+	// Once the lock is released the worker runs again at its usual interval
+	worker.runOnce(context.Background())
+	health = worker.Health()
+	suite.Equal(1, health.Runs)
+	wait := worker.nextWait()
+	suite.True(wait >= time.Minute && wait < 2*time.Minute)
+}
+
+func (suite *AwardQueueSuite) TestWorkerBackoffIsCapped() {
+	worker := NewWorker(suite.DB(), suite.logger, time.Minute)
+	worker.backoff = 20
+
+	wait := worker.nextWait()
+	suite.True(wait >= maxBackoffIntervals*time.Minute)
+	suite.True(wait < (maxBackoffIntervals+1)*time.Minute)
+}
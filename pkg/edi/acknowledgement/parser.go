@@ -0,0 +1,260 @@
+package ediacknowledgement
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/edi"
+	edisegment "github.com/transcom/mymove/pkg/edi/segment"
+)
+
+// FunctionalAcknowledgement997 holds the segments of an EDI X12 997 interchange
+type FunctionalAcknowledgement997 struct {
+	ISA              edisegment.ISA
+	FunctionalGroups []FunctionalGroup
+	IEA              edisegment.IEA
+}
+
+// FunctionalGroup is a functional group of 997 transaction sets
+type FunctionalGroup struct {
+	GS               edisegment.GS
+	Acknowledgements []GroupAcknowledgement
+	GE               edisegment.GE
+}
+
+// GroupAcknowledgement is a 997 transaction set, which acknowledges one functional group that was sent to
+// Syncada. Since each 858C we send has one functional group, whose control number is the interchange control
+// number, AK1.GroupControlNumber identifies the invoice being acknowledged.
+type GroupAcknowledgement struct {
+	ST              edisegment.ST
+	AK1             edisegment.AK1
+	TransactionSets []TransactionSetAcknowledgement
+	AK9             edisegment.AK9
+	SE              edisegment.SE
+}
+
+// TransactionSetAcknowledgement acknowledges a transaction set in the functional group, with the errors found in
+// its segments
+type TransactionSetAcknowledgement struct {
+	AK2           edisegment.AK2
+	SegmentErrors []SegmentError
+	AK5           edisegment.AK5
+}
+
+// SegmentError is an error in a segment of an acknowledged transaction set, with the errors found in its elements
+type SegmentError struct {
+	AK3           edisegment.AK3
+	ElementErrors []edisegment.AK4
+}
+
+// GroupAcknowledgements returns the acknowledgements in every functional group of the 997
+func (f FunctionalAcknowledgement997) GroupAcknowledgements() []GroupAcknowledgement {
+	var acks []GroupAcknowledgement
+	for _, group := range f.FunctionalGroups {
+		acks = append(acks, group.Acknowledgements...)
+	}
+	return acks
+}
+
+// Accepted reports whether Syncada accepted the functional group, possibly noting errors
+func (g GroupAcknowledgement) Accepted() bool {
+	code := g.AK9.FunctionalGroupAcknowledgeCode
+	return code == "A" || code == "E"
+}
+
+// Errors describes the errors Syncada reported for the functional group and its transaction sets
+func (g GroupAcknowledgement) Errors() []string {
+	errs := g.groupErrors()
+	for _, transactionSet := range g.TransactionSets {
+		errs = append(errs, transactionSet.Errors()...)
+	}
+	return errs
+}
+
+// TransactionSet returns the acknowledgement of the transaction set with the given control number, if the 997
+// has one
+func (g GroupAcknowledgement) TransactionSet(controlNumber string) (TransactionSetAcknowledgement, bool) {
+	for _, transactionSet := range g.TransactionSets {
+		if transactionSet.AK2.TransactionSetControlNumber == controlNumber {
+			return transactionSet, true
+		}
+	}
+	return TransactionSetAcknowledgement{}, false
+}
+
+// TransactionSetAccepted reports whether Syncada accepted the transaction set with the given control number.
+// A 997 doesn't have to acknowledge each transaction set, so one it leaves out was accepted unless the whole
+// functional group was rejected.
+func (g GroupAcknowledgement) TransactionSetAccepted(controlNumber string) bool {
+	if transactionSet, ok := g.TransactionSet(controlNumber); ok {
+		return transactionSet.Accepted()
+	}
+	return g.Accepted() || g.AK9.FunctionalGroupAcknowledgeCode == "P"
+}
+
+// TransactionSetErrors describes the errors Syncada reported for the functional group and for the transaction
+// set with the given control number
+func (g GroupAcknowledgement) TransactionSetErrors(controlNumber string) []string {
+	errs := g.groupErrors()
+	if transactionSet, ok := g.TransactionSet(controlNumber); ok {
+		errs = append(errs, transactionSet.Errors()...)
+	}
+	return errs
+}
+
+func (g GroupAcknowledgement) groupErrors() []string {
+	var errs []string
+	for _, code := range g.AK9.FunctionalGroupSyntaxErrorCodes {
+		errs = append(errs, fmt.Sprintf("Functional group %d: syntax error code %s", g.AK1.GroupControlNumber, code))
+	}
+	return errs
+}
+
+// Accepted reports whether Syncada accepted the transaction set, possibly noting errors
+func (t TransactionSetAcknowledgement) Accepted() bool {
+	code := t.AK5.TransactionSetAcknowledgmentCode
+	return code == "A" || code == "E"
+}
+
+// Errors describes the errors Syncada reported for the transaction set and its segments
+func (t TransactionSetAcknowledgement) Errors() []string {
+	var errs []string
+	name := fmt.Sprintf("Transaction set %s %s", t.AK2.TransactionSetIdentifierCode, t.AK2.TransactionSetControlNumber)
+	for _, code := range t.AK5.TransactionSetSyntaxErrorCodes {
+		errs = append(errs, fmt.Sprintf("%s: syntax error code %s", name, code))
+	}
+	for _, segmentError := range t.SegmentErrors {
+		segment := fmt.Sprintf("%s: segment %s at position %d", name, segmentError.AK3.SegmentIDCode,
+			segmentError.AK3.SegmentPositionInTransactionSet)
+		if segmentError.AK3.SegmentSyntaxErrorCode != "" {
+			errs = append(errs, fmt.Sprintf("%s: syntax error code %s", segment, segmentError.AK3.SegmentSyntaxErrorCode))
+		}
+		for _, elementError := range segmentError.ElementErrors {
+			message := fmt.Sprintf("%s: element %s: syntax error code %s", segment, elementError.PositionInSegment,
+				elementError.DataElementSyntaxErrorCode)
+			if elementError.CopyOfBadDataElement != "" {
+				message += fmt.Sprintf(" (bad data %q)", elementError.CopyOfBadDataElement)
+			}
+			errs = append(errs, message)
+		}
+	}
+	return errs
+}
+
+// Parse997 reads a 997 interchange, checking that its segments come in the order X12 requires
+func Parse997(r io.Reader) (FunctionalAcknowledgement997, error) {
+	records, err := edi.NewReader(r).ReadAll()
+	if err != nil {
+		return FunctionalAcknowledgement997{}, errors.Wrap(err, "Could not read 997")
+	}
+
+	p := parser{}
+	for i, record := range records {
+		if err := p.parseSegment(record); err != nil {
+			return FunctionalAcknowledgement997{}, errors.Wrapf(err, "997 segment %d", i+1)
+		}
+	}
+	if p.state != stateDone {
+		return FunctionalAcknowledgement997{}, errors.New("997 ended before its IEA segment")
+	}
+	return p.ack, nil
+}
+
+type parserState int
+
+const (
+	stateStart parserState = iota
+	stateInterchange
+	stateGroup
+	stateTransactionSet
+	stateAcknowledgement
+	stateAcknowledgedTransactionSet
+	stateTransactionSetAcknowledged
+	stateGroupAcknowledged
+	stateTransactionSetEnded
+	stateDone
+)
+
+// parser builds a 997 a segment at a time
+type parser struct {
+	ack     FunctionalAcknowledgement997
+	state   parserState
+	fnGroup FunctionalGroup
+	group   GroupAcknowledgement
+	set     TransactionSetAcknowledgement
+}
+
+func (p *parser) parseSegment(record []string) error {
+	id, elements := record[0], record[1:]
+	switch {
+	case id == "ISA" && p.state == stateStart:
+		p.state = stateInterchange
+		return p.ack.ISA.Parse(elements)
+	case id == "GS" && p.state == stateInterchange:
+		p.state = stateGroup
+		p.fnGroup = FunctionalGroup{}
+		return p.fnGroup.GS.Parse(elements)
+	case id == "ST" && (p.state == stateGroup || p.state == stateTransactionSetEnded):
+		p.state = stateTransactionSet
+		p.group = GroupAcknowledgement{}
+		if err := p.group.ST.Parse(elements); err != nil {
+			return err
+		}
+		if p.group.ST.TransactionSetIdentifierCode != "997" {
+			return fmt.Errorf("ST: expected a 997 transaction set, got %s", p.group.ST.TransactionSetIdentifierCode)
+		}
+		return nil
+	case id == "AK1" && p.state == stateTransactionSet:
+		p.state = stateAcknowledgement
+		return p.group.AK1.Parse(elements)
+	case id == "AK2" && (p.state == stateAcknowledgement || p.state == stateTransactionSetAcknowledged):
+		p.state = stateAcknowledgedTransactionSet
+		p.set = TransactionSetAcknowledgement{}
+		return p.set.AK2.Parse(elements)
+	case id == "AK3" && p.state == stateAcknowledgedTransactionSet:
+		var segmentError SegmentError
+		if err := segmentError.AK3.Parse(elements); err != nil {
+			return err
+		}
+		p.set.SegmentErrors = append(p.set.SegmentErrors, segmentError)
+		return nil
+	case id == "AK4" && p.state == stateAcknowledgedTransactionSet && len(p.set.SegmentErrors) > 0:
+		var elementError edisegment.AK4
+		if err := elementError.Parse(elements); err != nil {
+			return err
+		}
+		segmentError := &p.set.SegmentErrors[len(p.set.SegmentErrors)-1]
+		segmentError.ElementErrors = append(segmentError.ElementErrors, elementError)
+		return nil
+	case id == "AK5" && p.state == stateAcknowledgedTransactionSet:
+		p.state = stateTransactionSetAcknowledged
+		if err := p.set.AK5.Parse(elements); err != nil {
+			return err
+		}
+		p.group.TransactionSets = append(p.group.TransactionSets, p.set)
+		return nil
+	case id == "AK9" && (p.state == stateAcknowledgement || p.state == stateTransactionSetAcknowledged):
+		p.state = stateGroupAcknowledged
+		return p.group.AK9.Parse(elements)
+	case id == "SE" && p.state == stateGroupAcknowledged:
+		p.state = stateTransactionSetEnded
+		if err := p.group.SE.Parse(elements); err != nil {
+			return err
+		}
+		p.fnGroup.Acknowledgements = append(p.fnGroup.Acknowledgements, p.group)
+		return nil
+	case id == "GE" && p.state == stateTransactionSetEnded:
+		p.state = stateInterchange
+		if err := p.fnGroup.GE.Parse(elements); err != nil {
+			return err
+		}
+		p.ack.FunctionalGroups = append(p.ack.FunctionalGroups, p.fnGroup)
+		return nil
+	case id == "IEA" && p.state == stateInterchange:
+		p.state = stateDone
+		return p.ack.IEA.Parse(elements)
+	}
+	return fmt.Errorf("unexpected %s segment", id)
+}
@@ -0,0 +1,105 @@
+package ediacknowledgement
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParse997(t *testing.T) {
+	file, err := os.Open("testdata/rejected_invoice.edi.997")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	ack, err := Parse997(file)
+	if err != nil {
+		t.Fatalf("Parse997 failed: %s", err)
+	}
+	if ack.ISA.InterchangeControlNumber != 311 || ack.IEA.InterchangeControlNumber != 311 {
+		t.Errorf("Wrong interchange control numbers: ISA %d, IEA %d", ack.ISA.InterchangeControlNumber, ack.IEA.InterchangeControlNumber)
+	}
+
+	groups := ack.GroupAcknowledgements()
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 group acknowledgements, got %d", len(groups))
+	}
+
+	accepted := groups[0]
+	if accepted.AK1.GroupControlNumber != 1001 || !accepted.Accepted() || len(accepted.Errors()) != 0 {
+		t.Errorf("Expected group 1001 to be accepted without errors, got %+v", accepted)
+	}
+
+	rejected := groups[1]
+	if rejected.AK1.GroupControlNumber != 1002 || rejected.Accepted() {
+		t.Errorf("Expected group 1002 to be rejected, got %+v", rejected)
+	}
+	if len(rejected.TransactionSets) != 1 || len(rejected.TransactionSets[0].SegmentErrors) != 1 ||
+		len(rejected.TransactionSets[0].SegmentErrors[0].ElementErrors) != 2 {
+		t.Fatalf("Expected one segment error with two element errors, got %+v", rejected.TransactionSets)
+	}
+	expectedErrors := []string{
+		"Transaction set 858 0001: syntax error code 5",
+		"Transaction set 858 0001: segment N4 at position 7: syntax error code 8",
+		`Transaction set 858 0001: segment N4 at position 7: element 2: syntax error code 7 (bad data "XXXX")`,
+		"Transaction set 858 0001: segment N4 at position 7: element 3|1: syntax error code 1",
+	}
+	if errs := rejected.Errors(); strings.Join(errs, "\n") != strings.Join(expectedErrors, "\n") {
+		t.Errorf("Wrong errors:\n%s\nexpected:\n%s", strings.Join(errs, "\n"), strings.Join(expectedErrors, "\n"))
+	}
+}
+
+func TestParse997TransactionSetAcknowledgements(t *testing.T) {
+	file, err := os.Open("testdata/partially_accepted_batch.edi.997")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	ack, err := Parse997(file)
+	if err != nil {
+		t.Fatalf("Parse997 failed: %s", err)
+	}
+	groups := ack.GroupAcknowledgements()
+	if len(groups) != 1 {
+		t.Fatalf("Expected 1 group acknowledgement, got %d", len(groups))
+	}
+	group := groups[0]
+	if group.Accepted() {
+		t.Error("Expected the partially accepted group not to be accepted as a whole")
+	}
+
+	tests := []struct {
+		controlNumber string
+		accepted      bool
+		errors        int
+	}{
+		{"0001", true, 0},
+		{"0002", false, 3},
+		// Left out of the 997, so accepted along with the rest of the group
+		{"0003", true, 0},
+	}
+	for _, test := range tests {
+		if accepted := group.TransactionSetAccepted(test.controlNumber); accepted != test.accepted {
+			t.Errorf("Transaction set %s: expected accepted %t, got %t", test.controlNumber, test.accepted, accepted)
+		}
+		if errs := group.TransactionSetErrors(test.controlNumber); len(errs) != test.errors {
+			t.Errorf("Transaction set %s: expected %d errors, got %v", test.controlNumber, test.errors, errs)
+		}
+	}
+}
+
+func TestParse997RejectsSegmentsOutOfOrder(t *testing.T) {
+	tests := map[string]string{
+		"AK4 before AK3": "ISA*00*          *00*          *12*8004171844     *ZZ*MYMOVE         *190425*1132*U*00401*000000311*0*T*|\nGS*FA*8004171844*MYMOVE*20190425*1132*311*X*004010\nST*997*0001\nAK1*SI*1001\nAK2*858*0001\nAK4*2**7\n",
+		"missing IEA":    "ISA*00*          *00*          *12*8004171844     *ZZ*MYMOVE         *190425*1132*U*00401*000000311*0*T*|\n",
+		"not a 997":      "ISA*00*          *00*          *12*8004171844     *ZZ*MYMOVE         *190425*1132*U*00401*000000311*0*T*|\nGS*FA*8004171844*MYMOVE*20190425*1132*311*X*004010\nST*858*0001\n",
+		"no interchange": "GS*FA*8004171844*MYMOVE*20190425*1132*311*X*004010\n",
+	}
+	for name, edi := range tests {
+		if _, err := Parse997(strings.NewReader(edi)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
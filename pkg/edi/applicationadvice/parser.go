@@ -0,0 +1,219 @@
+package ediapplicationadvice
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/edi"
+	edisegment "github.com/transcom/mymove/pkg/edi/segment"
+)
+
+// invoiceNumberQualifier is the reference identification qualifier of an invoice number, which an OTI segment
+// uses to refer to an 858C by the invoice number in its N9 segment
+const invoiceNumberQualifier = "CN"
+
+// ApplicationAdvice824 holds the segments of an EDI X12 824 interchange
+type ApplicationAdvice824 struct {
+	ISA              edisegment.ISA
+	FunctionalGroups []FunctionalGroup
+	IEA              edisegment.IEA
+}
+
+// FunctionalGroup is a functional group of 824 transaction sets
+type FunctionalGroup struct {
+	GS      edisegment.GS
+	Advices []Advice
+	GE      edisegment.GE
+}
+
+// Advice is an 824 transaction set, which reports the outcome of Syncada's business validation of transactions
+// it had already accepted
+type Advice struct {
+	ST                   edisegment.ST
+	BGN                  edisegment.BGN
+	OriginalTransactions []OriginalTransaction
+	SE                   edisegment.SE
+}
+
+// OriginalTransaction reports whether a transaction passed validation, with the errors found in it
+type OriginalTransaction struct {
+	OTI    edisegment.OTI
+	Errors []ApplicationError
+}
+
+// ApplicationError is an error found in a transaction, with notes explaining it
+type ApplicationError struct {
+	TED   edisegment.TED
+	Notes []edisegment.NTE
+}
+
+// OriginalTransactions returns the original transactions reported on in every transaction set of the 824
+func (a ApplicationAdvice824) OriginalTransactions() []OriginalTransaction {
+	var transactions []OriginalTransaction
+	for _, group := range a.FunctionalGroups {
+		for _, advice := range group.Advices {
+			transactions = append(transactions, advice.OriginalTransactions...)
+		}
+	}
+	return transactions
+}
+
+// Rejected reports whether Syncada rejected the transaction, or an item in it
+func (o OriginalTransaction) Rejected() bool {
+	code := o.OTI.ApplicationAcknowledgementCode
+	return code == "TR" || code == "IR"
+}
+
+// InvoiceNumber returns the number of the invoice the transaction refers to
+func (o OriginalTransaction) InvoiceNumber() (string, error) {
+	if o.OTI.ReferenceIdentificationQualifier != invoiceNumberQualifier {
+		return "", errors.Errorf("OTI refers to %s %s rather than an invoice number",
+			o.OTI.ReferenceIdentificationQualifier, o.OTI.ReferenceIdentification)
+	}
+	return o.OTI.ReferenceIdentification, nil
+}
+
+// Notes describes the errors Syncada found in the transaction, with their notes
+func (o OriginalTransaction) Notes() []string {
+	var notes []string
+	for _, applicationError := range o.Errors {
+		ted := applicationError.TED
+		note := fmt.Sprintf("Error code %s", ted.ApplicationErrorConditionCode)
+		if ted.FreeFormMessage != "" {
+			note += ": " + ted.FreeFormMessage
+		}
+		if ted.SegmentIDCode != "" {
+			note += fmt.Sprintf(" (segment %s", ted.SegmentIDCode)
+			if ted.ElementPositionInSegment != "" {
+				note += fmt.Sprintf(", element %s", ted.ElementPositionInSegment)
+			}
+			if ted.CopyOfBadDataElement != "" {
+				note += fmt.Sprintf(", bad data %q", ted.CopyOfBadDataElement)
+			}
+			note += ")"
+		}
+		notes = append(notes, note)
+		for _, nte := range applicationError.Notes {
+			notes = append(notes, nte.Description)
+		}
+	}
+	return notes
+}
+
+// Parse824 reads an 824 interchange, checking that its segments come in the order X12 requires
+func Parse824(r io.Reader) (ApplicationAdvice824, error) {
+	records, err := edi.NewReader(r).ReadAll()
+	if err != nil {
+		return ApplicationAdvice824{}, errors.Wrap(err, "Could not read 824")
+	}
+
+	p := parser{}
+	for i, record := range records {
+		if err := p.parseSegment(record); err != nil {
+			return ApplicationAdvice824{}, errors.Wrapf(err, "824 segment %d", i+1)
+		}
+	}
+	if p.state != stateDone {
+		return ApplicationAdvice824{}, errors.New("824 ended before its IEA segment")
+	}
+	return p.advice, nil
+}
+
+type parserState int
+
+const (
+	stateStart parserState = iota
+	stateInterchange
+	stateGroup
+	stateTransactionSet
+	stateBeginning
+	stateOriginalTransaction
+	stateApplicationError
+	stateTransactionSetEnded
+	stateDone
+)
+
+// parser builds an 824 a segment at a time
+type parser struct {
+	advice  ApplicationAdvice824
+	state   parserState
+	fnGroup FunctionalGroup
+	set     Advice
+}
+
+// lastTransaction returns the original transaction being parsed
+func (p *parser) lastTransaction() *OriginalTransaction {
+	return &p.set.OriginalTransactions[len(p.set.OriginalTransactions)-1]
+}
+
+func (p *parser) parseSegment(record []string) error {
+	id, elements := record[0], record[1:]
+	switch {
+	case id == "ISA" && p.state == stateStart:
+		p.state = stateInterchange
+		return p.advice.ISA.Parse(elements)
+	case id == "GS" && p.state == stateInterchange:
+		p.state = stateGroup
+		p.fnGroup = FunctionalGroup{}
+		return p.fnGroup.GS.Parse(elements)
+	case id == "ST" && (p.state == stateGroup || p.state == stateTransactionSetEnded):
+		p.state = stateTransactionSet
+		p.set = Advice{}
+		if err := p.set.ST.Parse(elements); err != nil {
+			return err
+		}
+		if p.set.ST.TransactionSetIdentifierCode != "824" {
+			return fmt.Errorf("ST: expected an 824 transaction set, got %s", p.set.ST.TransactionSetIdentifierCode)
+		}
+		return nil
+	case id == "BGN" && p.state == stateTransactionSet:
+		p.state = stateBeginning
+		return p.set.BGN.Parse(elements)
+	case id == "OTI" && (p.state == stateBeginning || p.state == stateOriginalTransaction || p.state == stateApplicationError):
+		p.state = stateOriginalTransaction
+		var transaction OriginalTransaction
+		if err := transaction.OTI.Parse(elements); err != nil {
+			return err
+		}
+		p.set.OriginalTransactions = append(p.set.OriginalTransactions, transaction)
+		return nil
+	case id == "TED" && (p.state == stateOriginalTransaction || p.state == stateApplicationError):
+		p.state = stateApplicationError
+		var applicationError ApplicationError
+		if err := applicationError.TED.Parse(elements); err != nil {
+			return err
+		}
+		transaction := p.lastTransaction()
+		transaction.Errors = append(transaction.Errors, applicationError)
+		return nil
+	case id == "NTE" && p.state == stateApplicationError:
+		var note edisegment.NTE
+		if err := note.Parse(elements); err != nil {
+			return err
+		}
+		transaction := p.lastTransaction()
+		applicationError := &transaction.Errors[len(transaction.Errors)-1]
+		applicationError.Notes = append(applicationError.Notes, note)
+		return nil
+	case id == "SE" && (p.state == stateBeginning || p.state == stateOriginalTransaction || p.state == stateApplicationError):
+		p.state = stateTransactionSetEnded
+		if err := p.set.SE.Parse(elements); err != nil {
+			return err
+		}
+		p.fnGroup.Advices = append(p.fnGroup.Advices, p.set)
+		return nil
+	case id == "GE" && p.state == stateTransactionSetEnded:
+		p.state = stateInterchange
+		if err := p.fnGroup.GE.Parse(elements); err != nil {
+			return err
+		}
+		p.advice.FunctionalGroups = append(p.advice.FunctionalGroups, p.fnGroup)
+		return nil
+	case id == "IEA" && p.state == stateInterchange:
+		p.state = stateDone
+		return p.advice.IEA.Parse(elements)
+	}
+	return fmt.Errorf("unexpected %s segment", id)
+}
@@ -0,0 +1,75 @@
+package ediapplicationadvice
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParse824(t *testing.T) {
+	file, err := os.Open("testdata/rejected_invoice.edi.824")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	advice, err := Parse824(file)
+	if err != nil {
+		t.Fatalf("Parse824 failed: %s", err)
+	}
+	if advice.ISA.InterchangeControlNumber != 401 || advice.IEA.InterchangeControlNumber != 401 {
+		t.Errorf("Wrong interchange control numbers: ISA %d, IEA %d", advice.ISA.InterchangeControlNumber, advice.IEA.InterchangeControlNumber)
+	}
+
+	transactions := advice.OriginalTransactions()
+	if len(transactions) != 2 {
+		t.Fatalf("Expected 2 original transactions, got %d", len(transactions))
+	}
+
+	accepted := transactions[0]
+	invoiceNumber, err := accepted.InvoiceNumber()
+	if err != nil || invoiceNumber != "ABBV190001" || accepted.Rejected() || len(accepted.Notes()) != 0 {
+		t.Errorf("Expected invoice ABBV190001 to be accepted without notes, got %+v", accepted)
+	}
+
+	rejected := transactions[1]
+	invoiceNumber, err = rejected.InvoiceNumber()
+	if err != nil || invoiceNumber != "ABBV190002" || !rejected.Rejected() {
+		t.Errorf("Expected invoice ABBV190002 to be rejected, got %+v", rejected)
+	}
+	if rejected.OTI.GroupControlNumber != 1003 || rejected.OTI.TransactionSetControlNumber != "0002" {
+		t.Errorf("Wrong original transaction control numbers: %+v", rejected.OTI)
+	}
+	expectedNotes := []string{
+		`Error code 848: TAC NOT VALID FOR FISCAL YEAR (segment FA2, element 2, bad data "F8E1")`,
+		"Correct the TAC and resubmit",
+		`Error code 006: LINE ITEM CHARGE EXCEEDS TARIFF (segment L1, element 4, bad data "12325")`,
+	}
+	if notes := rejected.Notes(); strings.Join(notes, "\n") != strings.Join(expectedNotes, "\n") {
+		t.Errorf("Wrong notes:\n%s\nexpected:\n%s", strings.Join(notes, "\n"), strings.Join(expectedNotes, "\n"))
+	}
+}
+
+func TestOriginalTransactionInvoiceNumberNeedsInvoiceReference(t *testing.T) {
+	transaction := OriginalTransaction{}
+	transaction.OTI.ReferenceIdentificationQualifier = "BM"
+	transaction.OTI.ReferenceIdentification = "KKFA7000001"
+	if _, err := transaction.InvoiceNumber(); err == nil {
+		t.Error("Expected an error for an OTI that refers to a bill of lading")
+	}
+}
+
+func TestParse824RejectsSegmentsOutOfOrder(t *testing.T) {
+	isa := "ISA*00*          *00*          *12*8004171844     *ZZ*MYMOVE         *190429*1015*U*00401*000000401*0*T*|~"
+	tests := map[string]string{
+		"TED before OTI": isa + "GS*AG*8004171844*MYMOVE*20190429*1015*401*X*004010~ST*824*0001~BGN*00*401*20190429~TED*848~",
+		"NTE before TED": isa + "GS*AG*8004171844*MYMOVE*20190429*1015*401*X*004010~ST*824*0001~BGN*00*401*20190429~OTI*TR*CN*ABBV190002~NTE*ADD*note~",
+		"missing IEA":    isa,
+		"not an 824":     isa + "GS*AG*8004171844*MYMOVE*20190429*1015*401*X*004010~ST*997*0001~",
+	}
+	for name, edi := range tests {
+		if _, err := Parse824(strings.NewReader(edi)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
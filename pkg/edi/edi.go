@@ -1,43 +1,203 @@
 /*
-Package edi utilizes Go's csv.Reader/csv.Writer
-for generating Electronic Data Interchange Files
+Package edi reads and writes Electronic Data Interchange files in the X12 format
 
-This helps us:
-- Extend the current package if more complex EDI logic is necessary
-- Provide a more descriptive name for usage
-- Adhere to patterns of Go's stdlib csv.Writer/csv.Reader
+An X12 interchange is a sequence of segments, each a list of data elements. Three delimiters separate them:
+one between elements, one between the components of a composite element, and one terminating each segment.
+Unlike CSV, X12 has no quoting or escaping, so data can't contain the element separator or segment terminator.
+The ISA segment that opens an interchange declares its delimiters: the character after "ISA" is the element
+separator, ISA16 is the component element separator, and the character after ISA16 is the segment terminator.
 */
 package edi
 
 import (
-	"encoding/csv"
+	"bufio"
+	"bytes"
+	"fmt"
 	"io"
+	"strings"
 )
 
-// Writer is just a wrapper for csv.Writer
+// Delimiters are the characters separating the parts of an X12 interchange
+type Delimiters struct {
+	Element   byte
+	Component byte
+	Segment   byte
+}
+
+// DefaultDelimiters are the delimiters of the EDI we send: elements are separated by "*", components by "|",
+// and each segment is terminated by a newline
+var DefaultDelimiters = Delimiters{Element: '*', Component: '|', Segment: '\n'}
+
+// isaElements is the number of elements in an ISA segment, which are fixed width so the segment can be read
+// before its delimiters are known
+const isaElements = 16
+
+func (d Delimiters) validate() error {
+	if d.Element == d.Component || d.Element == d.Segment || d.Component == d.Segment {
+		return fmt.Errorf("EDI delimiters must be different, got %q, %q and %q", d.Element, d.Component, d.Segment)
+	}
+	return nil
+}
+
+// Writer writes segments as X12
 type Writer struct {
-	*csv.Writer
+	Delimiters Delimiters
+	w          *bufio.Writer
 }
 
-// NewWriter returns a wrapped csv.Writer with `Comma = '*'`
+// NewWriter returns a Writer that writes to w using DefaultDelimiters
 func NewWriter(w io.Writer) *Writer {
-	csvWriter := csv.NewWriter(w)
-	csvWriter.Comma = '*'
 	return &Writer{
-		csvWriter,
+		Delimiters: DefaultDelimiters,
+		w:          bufio.NewWriter(w),
+	}
+}
+
+// Write writes a segment, given as its ID followed by its elements. Since X12 can't escape delimiters, it
+// returns an error if an element contains the element separator or segment terminator, or if an ISA segment
+// declares a different component element separator than the Writer's.
+func (w *Writer) Write(segment []string) error {
+	if err := w.Delimiters.validate(); err != nil {
+		return err
+	}
+	if len(segment) == 0 || segment[0] == "" {
+		return fmt.Errorf("EDI segment has no ID")
+	}
+	id := segment[0]
+	for i, element := range segment {
+		if strings.IndexByte(element, w.Delimiters.Element) >= 0 {
+			return fmt.Errorf("%s%02d %q contains the element separator %q", id, i, element, w.Delimiters.Element)
+		}
+		if strings.IndexByte(element, w.Delimiters.Segment) >= 0 {
+			return fmt.Errorf("%s%02d %q contains the segment terminator %q", id, i, element, w.Delimiters.Segment)
+		}
+	}
+	if id == "ISA" && len(segment) == isaElements+1 && segment[isaElements] != string(w.Delimiters.Component) {
+		return fmt.Errorf("ISA16 %q doesn't match the component element separator %q", segment[isaElements], w.Delimiters.Component)
+	}
+
+	for i, element := range segment {
+		if i > 0 {
+			if err := w.w.WriteByte(w.Delimiters.Element); err != nil {
+				return err
+			}
+		}
+		if _, err := w.w.WriteString(element); err != nil {
+			return err
+		}
+	}
+	return w.w.WriteByte(w.Delimiters.Segment)
+}
+
+// Flush writes any buffered segments to the underlying io.Writer
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// WriteAll writes segments using Write and then calls Flush
+func (w *Writer) WriteAll(segments [][]string) error {
+	for _, segment := range segments {
+		if err := w.Write(segment); err != nil {
+			return err
+		}
 	}
+	return w.Flush()
 }
 
-// Reader is just a wrapper for csv.Reader
+// Reader reads segments from X12. If the input starts with an ISA segment its delimiters are read from it,
+// otherwise DefaultDelimiters are used. Line breaks between segments terminated by something other than a newline
+// are ignored, as are carriage returns before newline terminators.
 type Reader struct {
-	*csv.Reader
+	Delimiters Delimiters
+	r          *bufio.Reader
+	started    bool
 }
 
-// NewReader returns a wrapped csv.Reader with `Comma = '*'`
+// NewReader returns a Reader that reads from r
 func NewReader(r io.Reader) *Reader {
-	csvReader := csv.NewReader(r)
-	csvReader.Comma = '*'
 	return &Reader{
-		csvReader,
+		Delimiters: DefaultDelimiters,
+		r:          bufio.NewReader(r),
+	}
+}
+
+// Read reads a segment, returning its ID followed by its elements. At the end of the input it returns io.EOF.
+func (r *Reader) Read() ([]string, error) {
+	if !r.started {
+		r.started = true
+		if err := r.readDelimiters(); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		segment, err := r.r.ReadString(r.Delimiters.Segment)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		atEOF := err == io.EOF
+
+		segment = strings.TrimSuffix(segment, string(r.Delimiters.Segment))
+		if r.Delimiters.Segment == '\n' {
+			segment = strings.TrimSuffix(segment, "\r")
+		} else {
+			segment = strings.TrimLeft(segment, "\r\n")
+		}
+		if segment != "" {
+			return strings.Split(segment, string(r.Delimiters.Element)), nil
+		}
+		if atEOF {
+			return nil, io.EOF
+		}
+	}
+}
+
+// ReadAll reads all the remaining segments
+func (r *Reader) ReadAll() ([][]string, error) {
+	var segments [][]string
+	for {
+		segment, err := r.Read()
+		if err == io.EOF {
+			return segments, nil
+		}
+		if err != nil {
+			return segments, err
+		}
+		segments = append(segments, segment)
+	}
+}
+
+// readDelimiters reads the delimiters from the ISA segment at the start of the input, if there is one. The
+// elements are counted rather than relying on their fixed widths, so a malformed ISA is still read.
+func (r *Reader) readDelimiters() error {
+	start, _ := r.r.Peek(512)
+	start = bytes.TrimLeft(start, "\r\n\t ")
+	if !bytes.HasPrefix(start, []byte("ISA")) || len(start) < 4 {
+		return nil
+	}
+
+	element := start[3]
+	separators := 0
+	for i := 3; i < len(start); i++ {
+		if start[i] != element {
+			continue
+		}
+		separators++
+		if separators < isaElements {
+			continue
+		}
+		if i+2 >= len(start) {
+			break
+		}
+		delimiters := Delimiters{Element: element, Component: start[i+1], Segment: start[i+2]}
+		if delimiters.Segment == '\r' {
+			delimiters.Segment = '\n'
+		}
+		if err := delimiters.validate(); err != nil {
+			return err
+		}
+		r.Delimiters = delimiters
+		return nil
 	}
+	return fmt.Errorf("ISA segment is too short to declare the EDI delimiters")
 }
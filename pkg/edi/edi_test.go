@@ -1,21 +1,144 @@
 package edi
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
 
+const testISA = "ISA*00*0000000000*00*0000000000*ZZ*MYMOVE         *12*8004171844     *700101*0000*U*00401*000000002*1*T*|"
+
 func TestNewReader(t *testing.T) {
 	reader := NewReader(strings.NewReader(""))
-	if reader.Comma != '*' {
-		t.Errorf("Reader.Comma is %c, but should be ','", reader.Comma)
+	if reader.Delimiters != DefaultDelimiters {
+		t.Errorf("Reader.Delimiters are %+v, but should be %+v", reader.Delimiters, DefaultDelimiters)
 	}
 }
 
 func TestNewWriter(t *testing.T) {
 	writer := NewWriter(os.Stdout)
-	if writer.Comma != '*' {
-		t.Errorf("Writer.Comma is %x, but should be '*'", writer.Comma)
+	if writer.Delimiters != DefaultDelimiters {
+		t.Errorf("Writer.Delimiters are %+v, but should be %+v", writer.Delimiters, DefaultDelimiters)
+	}
+}
+
+func TestWriterRoundTripsGolden858C(t *testing.T) {
+	golden, err := ioutil.ReadFile("invoice/testdata/expected_invoice.edi.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	segments, err := NewReader(bytes.NewReader(golden)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := NewWriter(&b).WriteAll(segments); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(golden) {
+		t.Errorf("Expected the golden 858C to be written back unchanged, got:\n%s", b.String())
+	}
+}
+
+func TestWriterWithSegmentTerminator(t *testing.T) {
+	var b bytes.Buffer
+	writer := NewWriter(&b)
+	writer.Delimiters.Segment = '~'
+	if err := writer.WriteAll([][]string{{"ST", "858", "0001"}, {"N1", "SF", `"Spaceman", Leo`}}); err != nil {
+		t.Fatal(err)
+	}
+	// X12 has no quoting, so quotes and commas are written as they are
+	expected := `ST*858*0001~N1*SF*"Spaceman", Leo~`
+	if b.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestWriterRejectsDelimitersInData(t *testing.T) {
+	tests := map[string][]string{
+		"element separator":  {"N1", "SF", "Space*man"},
+		"segment terminator": {"N3", "123 Any Street\nApt 1"},
+		"no segment ID":      {},
+		"mismatched ISA16":   strings.Split(strings.Replace(testISA, "|", ":", 1), "*"),
+	}
+	for name, segment := range tests {
+		if err := NewWriter(ioutil.Discard).Write(segment); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// Components of composite elements are separated by the component separator, so it's allowed in data
+	if err := NewWriter(ioutil.Discard).Write([]string{"AK4", "3|1", "26", "1"}); err != nil {
+		t.Errorf("Expected a composite element to be written, got %s", err)
+	}
+}
+
+func TestReaderReadsDelimitersFromISA(t *testing.T) {
+	tests := map[string]struct {
+		edi        string
+		delimiters Delimiters
+	}{
+		"newlines": {
+			testISA + "\nGS*SI*MYMOVE\nIEA*1*000000002\n",
+			DefaultDelimiters,
+		},
+		"carriage returns and newlines": {
+			testISA + "\r\nGS*SI*MYMOVE\r\nIEA*1*000000002\r\n",
+			DefaultDelimiters,
+		},
+		"tildes without newlines": {
+			testISA + "~GS*SI*MYMOVE~IEA*1*000000002~",
+			Delimiters{Element: '*', Component: '|', Segment: '~'},
+		},
+		"tildes with newlines": {
+			testISA + "~\r\nGS*SI*MYMOVE~\r\nIEA*1*000000002~\r\n",
+			Delimiters{Element: '*', Component: '|', Segment: '~'},
+		},
+		"other delimiters": {
+			strings.Replace(strings.Replace(testISA, "*", "^", -1), "|", ":", 1) + "'GS^SI^MYMOVE'IEA^1^000000002'",
+			Delimiters{Element: '^', Component: ':', Segment: '\''},
+		},
+	}
+
+	for name, test := range tests {
+		reader := NewReader(strings.NewReader(test.edi))
+		segments, err := reader.ReadAll()
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if reader.Delimiters != test.delimiters {
+			t.Errorf("%s: expected delimiters %+v, got %+v", name, test.delimiters, reader.Delimiters)
+		}
+		if len(segments) != 3 || len(segments[0]) != 17 || segments[0][16] != string(test.delimiters.Component) {
+			t.Errorf("%s: wrong ISA segment %q", name, segments)
+			continue
+		}
+		expected := [][]string{{"GS", "SI", "MYMOVE"}, {"IEA", "1", "000000002"}}
+		if !reflect.DeepEqual(segments[1:], expected) {
+			t.Errorf("%s: expected %q, got %q", name, expected, segments[1:])
+		}
+	}
+}
+
+func TestReaderWithoutISA(t *testing.T) {
+	segments, err := NewReader(strings.NewReader(`ST*858*0001` + "\n" + `N1*SF*"Spaceman*` + "\n")).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Quotes have no special meaning
+	expected := [][]string{{"ST", "858", "0001"}, {"N1", "SF", `"Spaceman`, ""}}
+	if !reflect.DeepEqual(segments, expected) {
+		t.Errorf("Expected %q, got %q", expected, segments)
+	}
+}
+
+func TestReaderRejectsTruncatedISA(t *testing.T) {
+	if _, err := NewReader(strings.NewReader("ISA*00*0000000000*00\n")).ReadAll(); err == nil {
+		t.Error("Expected an error reading a truncated ISA segment")
 	}
 }
@@ -3,6 +3,7 @@ package ediinvoice
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/gobuffalo/pop"
@@ -37,6 +38,9 @@ const weightUnitCode = "L"         // Pounds
 const ladingLineItemNumber = 1
 const billedRatedAsQuantity = 1
 
+// maxTransactionSets is the most transaction sets an interchange can hold, since their control numbers are 4 digits
+const maxTransactionSets = 9999
+
 // Place holders that currently exist TODO: Replace this constants with real value
 const freightRate = 4.07
 
@@ -70,23 +74,141 @@ func (invoice Invoice858C) Segments() [][]string {
 // EDIString returns the EDI representation of an 858C
 func (invoice Invoice858C) EDIString() (string, error) {
 	var b bytes.Buffer
-	ediWriter := edi.NewWriter(&b)
-	err := ediWriter.WriteAll(invoice.Segments())
+	err := invoice.Write(edi.NewWriter(&b))
 	if err != nil {
 		return "", err
 	}
 	return b.String(), err
 }
 
+// Write writes the 858C with the given Writer, declaring the Writer's component element separator in ISA16
+func (invoice Invoice858C) Write(w *edi.Writer) error {
+	invoice.ISA.ComponentElementSeparator = string(w.Delimiters.Component)
+	return w.WriteAll(invoice.Segments())
+}
+
+// TransactionSetControlNumber returns the control number of the invoice's transaction set
+func (invoice Invoice858C) TransactionSetControlNumber() string {
+	return stControlNumber(invoice.Shipment)
+}
+
+// Invoice858CBatch holds an interchange invoicing a batch of shipments, with an 858C transaction set for each
+// shipment, all in one functional group
+type Invoice858CBatch struct {
+	ISA             edisegment.ISA
+	GS              edisegment.GS
+	TransactionSets [][]edisegment.Segment
+	GE              edisegment.GE
+	IEA             edisegment.IEA
+}
+
+// Segments returns the interchange as an array of rows (string arrays),
+// each containing a segment, to prepare it for writing
+func (batch Invoice858CBatch) Segments() [][]string {
+	records := [][]string{
+		batch.ISA.StringArray(),
+		batch.GS.StringArray(),
+	}
+
+	for _, transactionSet := range batch.TransactionSets {
+		for _, line := range transactionSet {
+			records = append(records, line.StringArray())
+		}
+	}
+	records = append(records, batch.GE.StringArray())
+	records = append(records, batch.IEA.StringArray())
+	return records
+}
+
+// EDIString returns the EDI representation of the interchange
+func (batch Invoice858CBatch) EDIString() (string, error) {
+	var b bytes.Buffer
+	err := batch.Write(edi.NewWriter(&b))
+	if err != nil {
+		return "", err
+	}
+	return b.String(), err
+}
+
+// Write writes the interchange with the given Writer, declaring the Writer's component element separator in ISA16
+func (batch Invoice858CBatch) Write(w *edi.Writer) error {
+	batch.ISA.ComponentElementSeparator = string(w.Delimiters.Component)
+	return w.WriteAll(batch.Segments())
+}
+
+// TransactionSetControlNumber returns the control number of the nth transaction set, counting from 0
+func (batch Invoice858CBatch) TransactionSetControlNumber(n int) string {
+	return stControlNumber(batch.TransactionSets[n])
+}
+
+// stControlNumber returns the control number of the transaction set's ST segment
+func stControlNumber(transactionSet []edisegment.Segment) string {
+	if len(transactionSet) > 0 {
+		if st, ok := transactionSet[0].(*edisegment.ST); ok {
+			return st.TransactionSetControlNumber
+		}
+	}
+	return ""
+}
+
 // Generate858C generates an EDI X12 858C transaction set
 func Generate858C(shipment models.Shipment, invoiceModel models.Invoice, db *pop.Connection, sendProductionInvoice bool, icnSequencer sequence.Sequencer, clock clock.Clock, logger Logger) (Invoice858C, error) {
-	currentTime := clock.Now().UTC()
-
 	interchangeControlNumber, err := icnSequencer.NextVal()
 	if err != nil {
 		return Invoice858C{}, errors.Wrap(err, fmt.Sprintf("Failed to get next Interchange Control Number"))
 	}
 
+	invoice := Invoice858C{}
+	invoice.ISA, invoice.GS = generateHeaders(interchangeControlNumber, sendProductionInvoice, clock.Now().UTC())
+
+	shipmentSegments, err := generate858CShipment(db, shipment, invoiceModel, 1, logger)
+	if err != nil {
+		return invoice, err
+	}
+	invoice.Shipment = shipmentSegments
+
+	invoice.GE, invoice.IEA = generateTrailers(interchangeControlNumber, 1)
+
+	return invoice, nil
+}
+
+// Generate858CBatch generates an EDI X12 interchange with an 858C transaction set for each shipment, invoiced
+// with the invoice at the same position in invoiceModels. The whole batch uses a single interchange control number.
+func Generate858CBatch(shipments models.Shipments, invoiceModels models.Invoices, db *pop.Connection, sendProductionInvoice bool, icnSequencer sequence.Sequencer, clock clock.Clock, logger Logger) (Invoice858CBatch, error) {
+	if len(shipments) == 0 {
+		return Invoice858CBatch{}, errors.New("Cannot generate an 858C batch without any shipments")
+	}
+	if len(shipments) != len(invoiceModels) {
+		return Invoice858CBatch{}, errors.Errorf("Got %d shipments but %d invoices", len(shipments), len(invoiceModels))
+	}
+	if len(shipments) > maxTransactionSets {
+		return Invoice858CBatch{}, errors.Errorf("An 858C batch can have at most %d shipments, got %d", maxTransactionSets, len(shipments))
+	}
+
+	interchangeControlNumber, err := icnSequencer.NextVal()
+	if err != nil {
+		return Invoice858CBatch{}, errors.Wrap(err, fmt.Sprintf("Failed to get next Interchange Control Number"))
+	}
+
+	batch := Invoice858CBatch{}
+	batch.ISA, batch.GS = generateHeaders(interchangeControlNumber, sendProductionInvoice, clock.Now().UTC())
+
+	for i, shipment := range shipments {
+		shipmentSegments, err := generate858CShipment(db, shipment, invoiceModels[i], i+1, logger)
+		if err != nil {
+			return batch, errors.Wrapf(err, "Could not generate 858C for shipment %s", shipment.ID)
+		}
+		batch.TransactionSets = append(batch.TransactionSets, shipmentSegments)
+	}
+
+	batch.GE, batch.IEA = generateTrailers(interchangeControlNumber, len(batch.TransactionSets))
+
+	return batch, nil
+}
+
+// generateHeaders generates the ISA and GS segments that open an interchange with a single functional group,
+// whose control number is the interchange control number
+func generateHeaders(interchangeControlNumber int64, sendProductionInvoice bool, currentTime time.Time) (edisegment.ISA, edisegment.GS) {
 	var usageIndicator string
 	if sendProductionInvoice {
 		usageIndicator = "P"
@@ -94,8 +216,7 @@ func Generate858C(shipment models.Shipment, invoiceModel models.Invoice, db *pop
 		usageIndicator = "T"
 	}
 
-	invoice := Invoice858C{}
-	invoice.ISA = edisegment.ISA{
+	isa := edisegment.ISA{
 		AuthorizationInformationQualifier: "00", // No authorization information
 		AuthorizationInformation:          fmt.Sprintf("%010d", 0),
 		SecurityInformationQualifier:      "00", // No security information
@@ -111,9 +232,9 @@ func Generate858C(shipment models.Shipment, invoiceModel models.Invoice, db *pop
 		InterchangeControlNumber:          interchangeControlNumber,
 		AcknowledgementRequested:          1,
 		UsageIndicator:                    usageIndicator, // T for test, P for production
-		ComponentElementSeparator:         "|",
+		ComponentElementSeparator:         string(edi.DefaultDelimiters.Component),
 	}
-	invoice.GS = edisegment.GS{
+	gs := edisegment.GS{
 		FunctionalIdentifierCode: "SI", // Shipment Information (858)
 		ApplicationSendersCode:   senderCode,
 		ApplicationReceiversCode: receiverCode,
@@ -123,27 +244,29 @@ func Generate858C(shipment models.Shipment, invoiceModel models.Invoice, db *pop
 		ResponsibleAgencyCode:    "X", // Accredited Standards Committee X12
 		Version:                  "004010",
 	}
+	return isa, gs
+}
 
-	shipmentSegments, err := generate858CShipment(db, shipment, invoiceModel, 1, logger)
-	if err != nil {
-		return invoice, err
-	}
-	invoice.Shipment = shipmentSegments
-
-	invoice.GE = edisegment.GE{
-		NumberOfTransactionSetsIncluded: 1,
+// generateTrailers generates the GE and IEA segments that close an interchange opened by generateHeaders
+func generateTrailers(interchangeControlNumber int64, transactionSets int) (edisegment.GE, edisegment.IEA) {
+	ge := edisegment.GE{
+		NumberOfTransactionSetsIncluded: transactionSets,
 		GroupControlNumber:              interchangeControlNumber,
 	}
-	invoice.IEA = edisegment.IEA{
+	iea := edisegment.IEA{
 		NumberOfIncludedFunctionalGroups: 1,
 		InterchangeControlNumber:         interchangeControlNumber,
 	}
+	return ge, iea
+}
 
-	return invoice, nil
+// transactionSetControlNumber formats the ST control number of the transaction set at sequenceNum, counting from 1
+func transactionSetControlNumber(sequenceNum int) string {
+	return fmt.Sprintf("%04d", sequenceNum)
 }
 
 func generate858CShipment(db *pop.Connection, shipment models.Shipment, invoiceModel models.Invoice, sequenceNum int, logger Logger) ([]edisegment.Segment, error) {
-	transactionNumber := fmt.Sprintf("%04d", sequenceNum)
+	transactionNumber := transactionSetControlNumber(sequenceNum)
 	segments := []edisegment.Segment{
 		&edisegment.ST{
 			TransactionSetIdentifierCode: "858",
@@ -120,6 +120,61 @@ func (suite *InvoiceSuite) TestGenerate858C() {
 	})
 }
 
+func (suite *InvoiceSuite) TestGenerate858CBatch() {
+	shipments := models.Shipments{helperShipment(suite), helperShipment(suite)}
+	invoiceModels := models.Invoices{
+		helperShipmentInvoice(suite, shipments[0]),
+		helperShipmentInvoice(suite, shipments[1]),
+	}
+
+	suite.T().Run("one transaction set per shipment under a single interchange", func(t *testing.T) {
+		err := suite.icnSequencer.SetVal(1)
+		suite.NoError(err, "error setting sequence value")
+
+		batch, err := ediinvoice.Generate858CBatch(shipments, invoiceModels, suite.DB(), false, suite.icnSequencer, clock.NewMock(), suite.logger)
+		suite.NoError(err)
+
+		suite.Equal(int64(2), batch.ISA.InterchangeControlNumber)
+		suite.Equal(int64(2), batch.GS.GroupControlNumber)
+		suite.Equal(2, batch.GE.NumberOfTransactionSetsIncluded)
+		suite.Equal(1, batch.IEA.NumberOfIncludedFunctionalGroups)
+		if suite.Len(batch.TransactionSets, 2) {
+			suite.Equal("0001", batch.TransactionSetControlNumber(0))
+			suite.Equal("0002", batch.TransactionSetControlNumber(1))
+			for i, transactionSet := range batch.TransactionSets {
+				bx, ok := transactionSet[1].(*edisegment.BX)
+				if suite.True(ok) {
+					suite.Equal(*shipments[i].GBLNumber, bx.ShipmentIdentificationNumber)
+				}
+			}
+		}
+
+		// The interchange is well formed X12
+		suite.NoError(edi.Validate(batch.Segments()))
+
+		// ISA16 declares the component element separator it's written with
+		var b strings.Builder
+		writer := edi.NewWriter(&b)
+		writer.Delimiters.Component = '^'
+		suite.NoError(batch.Write(writer))
+		segments, err := edi.NewReader(strings.NewReader(b.String())).ReadAll()
+		suite.NoError(err)
+		if suite.NotEmpty(segments) && suite.Len(segments[0], 17) {
+			suite.Equal("^", segments[0][16])
+		}
+	})
+
+	suite.T().Run("each shipment needs an invoice", func(t *testing.T) {
+		_, err := ediinvoice.Generate858CBatch(shipments, invoiceModels[:1], suite.DB(), false, suite.icnSequencer, clock.NewMock(), suite.logger)
+		suite.Error(err)
+	})
+
+	suite.T().Run("a batch needs shipments", func(t *testing.T) {
+		_, err := ediinvoice.Generate858CBatch(models.Shipments{}, models.Invoices{}, suite.DB(), false, suite.icnSequencer, clock.NewMock(), suite.logger)
+		suite.Error(err)
+	})
+}
+
 func (suite *InvoiceSuite) TestEDIString() {
 	suite.T().Run("full EDI string is expected", func(t *testing.T) {
 		err := suite.icnSequencer.SetVal(1)
@@ -0,0 +1,191 @@
+package ediinvoice
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/edi"
+	edisegment "github.com/transcom/mymove/pkg/edi/segment"
+)
+
+// shipmentSegmentTypes maps the ID of each segment that can appear in an 858C transaction set to its type
+var shipmentSegmentTypes = map[string]func() edisegment.Segment{
+	"ST":  func() edisegment.Segment { return &edisegment.ST{} },
+	"BX":  func() edisegment.Segment { return &edisegment.BX{} },
+	"N9":  func() edisegment.Segment { return &edisegment.N9{} },
+	"N1":  func() edisegment.Segment { return &edisegment.N1{} },
+	"N3":  func() edisegment.Segment { return &edisegment.N3{} },
+	"N4":  func() edisegment.Segment { return &edisegment.N4{} },
+	"NTE": func() edisegment.Segment { return &edisegment.NTE{} },
+	"FA1": func() edisegment.Segment { return &edisegment.FA1{} },
+	"FA2": func() edisegment.Segment { return &edisegment.FA2{} },
+	"L10": func() edisegment.Segment { return &edisegment.L10{} },
+	"LX":  func() edisegment.Segment { return &edisegment.LX{} },
+	"MEA": func() edisegment.Segment { return &edisegment.MEA{} },
+	"HL":  func() edisegment.Segment { return &edisegment.HL{} },
+	"L0":  func() edisegment.Segment { return &edisegment.L0{} },
+	"L1":  func() edisegment.Segment { return &edisegment.L1{} },
+	"SE":  func() edisegment.Segment { return &edisegment.SE{} },
+}
+
+// Parse858C reads an 858C, as written by Invoice858C.EDIString, back into an Invoice858C. It checks the envelope
+// and transaction set as Parse858CBatch does, and that the interchange has exactly one transaction set.
+func Parse858C(r io.Reader) (Invoice858C, error) {
+	batch, err := Parse858CBatch(r)
+	if err != nil {
+		return Invoice858C{}, err
+	}
+	if len(batch.TransactionSets) != 1 {
+		return Invoice858C{}, errors.Errorf("858C has %d transaction sets, but an invoice has 1", len(batch.TransactionSets))
+	}
+
+	return Invoice858C{
+		ISA:      batch.ISA,
+		GS:       batch.GS,
+		Shipment: batch.TransactionSets[0],
+		GE:       batch.GE,
+		IEA:      batch.IEA,
+	}, nil
+}
+
+// Parse858CBatch reads an interchange of 858C transaction sets in one functional group, as written by
+// Invoice858CBatch.EDIString, back into an Invoice858CBatch. Besides parsing each segment it checks the envelope:
+// each SE segment count and control number, the GE and IEA counts and control numbers, and that each line item's
+// HL segment is followed by an L0 and L1 pair.
+func Parse858CBatch(r io.Reader) (Invoice858CBatch, error) {
+	records, err := edi.NewReader(r).ReadAll()
+	if err != nil {
+		return Invoice858CBatch{}, errors.Wrap(err, "Could not read 858C")
+	}
+	if len(records) < 4 {
+		return Invoice858CBatch{}, errors.Errorf("858C has %d segments, but its envelope alone needs 4", len(records))
+	}
+
+	batch := Invoice858CBatch{}
+	last := len(records) - 1
+	envelope := []struct {
+		record  []string
+		id      string
+		segment edisegment.Segment
+	}{
+		{records[0], "ISA", &batch.ISA},
+		{records[1], "GS", &batch.GS},
+		{records[last-1], "GE", &batch.GE},
+		{records[last], "IEA", &batch.IEA},
+	}
+	for _, e := range envelope {
+		if e.record[0] != e.id {
+			return Invoice858CBatch{}, errors.Errorf("Expected an %s segment, got %s", e.id, e.record[0])
+		}
+		if err := e.segment.Parse(e.record[1:]); err != nil {
+			return Invoice858CBatch{}, err
+		}
+	}
+
+	// Segments are numbered from the ISA segment, which is segment 1
+	var transactionSet []edisegment.Segment
+	transactionSetStart := 0
+	for i, record := range records[2 : last-1] {
+		segmentNumber := i + 3
+		newSegment, ok := shipmentSegmentTypes[record[0]]
+		if !ok {
+			return Invoice858CBatch{}, errors.Errorf("Unexpected %s segment at segment %d", record[0], segmentNumber)
+		}
+		segment := newSegment()
+		if err := segment.Parse(record[1:]); err != nil {
+			return Invoice858CBatch{}, errors.Wrapf(err, "segment %d", segmentNumber)
+		}
+
+		_, isST := segment.(*edisegment.ST)
+		_, isSE := segment.(*edisegment.SE)
+		switch {
+		case isST && transactionSet != nil:
+			return Invoice858CBatch{}, errors.Errorf("858C segment %d: ST segment before the previous transaction set's SE segment", segmentNumber)
+		case isST:
+			transactionSetStart = segmentNumber
+		case transactionSet == nil:
+			return Invoice858CBatch{}, errors.Errorf("858C segment %d: %s segment outside of a transaction set", segmentNumber, record[0])
+		}
+		transactionSet = append(transactionSet, segment)
+
+		if isSE {
+			if err := validate858CTransactionSet(transactionSet, transactionSetStart); err != nil {
+				return Invoice858CBatch{}, err
+			}
+			batch.TransactionSets = append(batch.TransactionSets, transactionSet)
+			transactionSet = nil
+		}
+	}
+	if transactionSet != nil {
+		return Invoice858CBatch{}, errors.New("858C transaction set doesn't end with an SE segment")
+	}
+	if len(batch.TransactionSets) == 0 {
+		return Invoice858CBatch{}, errors.New("858C is missing its transaction set")
+	}
+
+	if err := validate858CEnvelope(batch); err != nil {
+		return Invoice858CBatch{}, err
+	}
+	return batch, nil
+}
+
+// validate858CTransactionSet checks that segments, the first of which is segment start of the interchange, are an
+// 858 transaction set whose SE segment agrees with its ST segment, and whose line items are HL, L0 and L1 segments
+// in that order
+func validate858CTransactionSet(segments []edisegment.Segment, start int) error {
+	st := segments[0].(*edisegment.ST)
+	se := segments[len(segments)-1].(*edisegment.SE)
+	if st.TransactionSetIdentifierCode != "858" {
+		return errors.Errorf("Expected an 858 transaction set, got %s", st.TransactionSetIdentifierCode)
+	}
+	if se.NumberOfIncludedSegments != len(segments) {
+		return errors.Errorf("SE counts %d segments, but the transaction set has %d", se.NumberOfIncludedSegments, len(segments))
+	}
+	if se.TransactionSetControlNumber != st.TransactionSetControlNumber {
+		return errors.Errorf("SE control number %s doesn't match ST control number %s",
+			se.TransactionSetControlNumber, st.TransactionSetControlNumber)
+	}
+
+	for i := 1; i < len(segments); i++ {
+		previous, segment := segments[i-1], segments[i]
+		_, isL0 := segment.(*edisegment.L0)
+		_, isL1 := segment.(*edisegment.L1)
+		_, followsHL := previous.(*edisegment.HL)
+		_, followsL0 := previous.(*edisegment.L0)
+		switch {
+		case isL0 != followsHL:
+			return errors.Errorf("858C segment %d: each HL segment must be followed by an L0 segment", start+i)
+		case isL1 != followsL0:
+			return errors.Errorf("858C segment %d: each L0 segment must be followed by an L1 segment", start+i)
+		}
+	}
+	return nil
+}
+
+// validate858CEnvelope checks that the functional group and interchange trailers agree with their headers and
+// with the number of transaction sets in the group
+func validate858CEnvelope(batch Invoice858CBatch) error {
+	var mismatches []string
+	if batch.GE.NumberOfTransactionSetsIncluded != len(batch.TransactionSets) {
+		mismatches = append(mismatches, fmt.Sprintf("GE counts %d transaction sets, but the group has %d",
+			batch.GE.NumberOfTransactionSetsIncluded, len(batch.TransactionSets)))
+	}
+	if batch.GE.GroupControlNumber != batch.GS.GroupControlNumber {
+		mismatches = append(mismatches, fmt.Sprintf("GE control number %d doesn't match GS control number %d",
+			batch.GE.GroupControlNumber, batch.GS.GroupControlNumber))
+	}
+	if batch.IEA.NumberOfIncludedFunctionalGroups != 1 {
+		mismatches = append(mismatches, fmt.Sprintf("IEA counts %d functional groups, but the interchange has 1",
+			batch.IEA.NumberOfIncludedFunctionalGroups))
+	}
+	if batch.IEA.InterchangeControlNumber != batch.ISA.InterchangeControlNumber {
+		mismatches = append(mismatches, fmt.Sprintf("IEA control number %d doesn't match ISA control number %d",
+			batch.IEA.InterchangeControlNumber, batch.ISA.InterchangeControlNumber))
+	}
+	if len(mismatches) > 0 {
+		return errors.Errorf("858C envelope is invalid: %v", mismatches)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package ediinvoice_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/facebookgo/clock"
+
+	ediinvoice "github.com/transcom/mymove/pkg/edi/invoice"
+	edisegment "github.com/transcom/mymove/pkg/edi/segment"
+	"github.com/transcom/mymove/pkg/models"
+)
+
+func (suite *InvoiceSuite) TestParse858C() {
+	goldenEDI := helperLoadExpectedEDI(suite, "expected_invoice.edi.golden")
+
+	suite.T().Run("golden EDI round-trips", func(t *testing.T) {
+		invoice, err := ediinvoice.Parse858C(strings.NewReader(goldenEDI))
+		suite.NoError(err)
+		suite.Equal(int64(2), invoice.ISA.InterchangeControlNumber)
+		suite.Len(invoice.Shipment, 45)
+
+		bx, ok := invoice.Shipment[1].(*edisegment.BX)
+		if suite.True(ok, "Expected a BX segment after ST") {
+			suite.Equal("J", bx.TransactionMethodTypeCode)
+			suite.Equal("KKFA7000001", bx.ShipmentIdentificationNumber)
+		}
+
+		ediString, err := invoice.EDIString()
+		suite.NoError(err)
+		suite.Equal(goldenEDI, ediString)
+	})
+
+	suite.T().Run("generated invoice round-trips", func(t *testing.T) {
+		shipment := helperShipment(suite)
+		invoiceModel := helperShipmentInvoice(suite, shipment)
+		generated, err := ediinvoice.Generate858C(shipment, invoiceModel, suite.DB(), false, suite.icnSequencer, clock.NewMock(), suite.logger)
+		suite.NoError(err)
+		generatedEDI, err := generated.EDIString()
+		suite.NoError(err)
+
+		parsed, err := ediinvoice.Parse858C(strings.NewReader(generatedEDI))
+		suite.NoError(err)
+		suite.Equal(generated.ISA, parsed.ISA)
+		suite.Equal(generated.GS, parsed.GS)
+		suite.Equal(generated.GE, parsed.GE)
+		suite.Equal(generated.IEA, parsed.IEA)
+		parsedEDI, err := parsed.EDIString()
+		suite.NoError(err)
+		suite.Equal(generatedEDI, parsedEDI)
+	})
+
+	invalidEDI := map[string]string{
+		"wrong SE segment count":        strings.Replace(goldenEDI, "SE*45*0001", "SE*44*0001", 1),
+		"mismatched SE control number":  strings.Replace(goldenEDI, "SE*45*0001", "SE*45*0002", 1),
+		"mismatched GE control number":  strings.Replace(goldenEDI, "GE*1*2", "GE*1*3", 1),
+		"mismatched IEA control number": strings.Replace(goldenEDI, "IEA*1*000000002", "IEA*1*000000003", 1),
+		"L1 without L0":                 strings.Replace(goldenEDI, "L0*1*1.000*FR********\n", "", 1),
+		"unknown segment":               strings.Replace(goldenEDI, "FA1*DZ", "XX1*DZ", 1),
+		"missing envelope":              "ST*858*0001\nSE*1*0001\n",
+	}
+	for name, edi := range invalidEDI {
+		suite.T().Run(name, func(t *testing.T) {
+			_, err := ediinvoice.Parse858C(strings.NewReader(edi))
+			suite.Error(err)
+		})
+	}
+}
+
+func (suite *InvoiceSuite) TestParse858CBatch() {
+	shipments := models.Shipments{helperShipment(suite), helperShipment(suite)}
+	invoiceModels := models.Invoices{
+		helperShipmentInvoice(suite, shipments[0]),
+		helperShipmentInvoice(suite, shipments[1]),
+	}
+	generated, err := ediinvoice.Generate858CBatch(shipments, invoiceModels, suite.DB(), false, suite.icnSequencer, clock.NewMock(), suite.logger)
+	suite.FatalNoError(err)
+	generatedEDI, err := generated.EDIString()
+	suite.FatalNoError(err)
+
+	suite.T().Run("generated batch round-trips", func(t *testing.T) {
+		parsed, err := ediinvoice.Parse858CBatch(strings.NewReader(generatedEDI))
+		suite.NoError(err)
+		suite.Equal(generated.GE, parsed.GE)
+		if suite.Len(parsed.TransactionSets, 2) {
+			suite.Equal("0001", parsed.TransactionSetControlNumber(0))
+			suite.Equal("0002", parsed.TransactionSetControlNumber(1))
+		}
+		parsedEDI, err := parsed.EDIString()
+		suite.NoError(err)
+		suite.Equal(generatedEDI, parsedEDI)
+	})
+
+	suite.T().Run("an invoice has one transaction set", func(t *testing.T) {
+		_, err := ediinvoice.Parse858C(strings.NewReader(generatedEDI))
+		suite.Error(err)
+	})
+
+	geSegment := fmt.Sprintf("GE*2*%d", generated.GE.GroupControlNumber)
+	firstSESegment := fmt.Sprintf("SE*%d*0001\n", len(generated.TransactionSets[0]))
+	invalidEDI := map[string]string{
+		"GE counts too few transaction sets": strings.Replace(generatedEDI, geSegment, fmt.Sprintf("GE*1*%d", generated.GE.GroupControlNumber), 1),
+		"second transaction set without ST":  strings.Replace(generatedEDI, "ST*858*0002\n", "", 1),
+		"first transaction set without SE":   strings.Replace(generatedEDI, firstSESegment, "", 1),
+	}
+	for name, edi := range invalidEDI {
+		suite.T().Run(name, func(t *testing.T) {
+			suite.NotEqual(generatedEDI, edi)
+			_, err := ediinvoice.Parse858CBatch(strings.NewReader(edi))
+			suite.Error(err)
+		})
+	}
+}
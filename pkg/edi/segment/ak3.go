@@ -0,0 +1,47 @@
+package edisegment
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AK3 represents the AK3 EDI segment, which reports an error in a segment of the acknowledged transaction set
+type AK3 struct {
+	SegmentIDCode                   string
+	SegmentPositionInTransactionSet int
+	LoopIdentifierCode              string
+	SegmentSyntaxErrorCode          string
+}
+
+// StringArray converts AK3 to an array of strings
+func (s *AK3) StringArray() []string {
+	return []string{
+		"AK3",
+		s.SegmentIDCode,
+		strconv.Itoa(s.SegmentPositionInTransactionSet),
+		s.LoopIdentifierCode,
+		s.SegmentSyntaxErrorCode,
+	}
+}
+
+// Parse parses an X12 string that's split into an array into the AK3 struct
+func (s *AK3) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements < 2 || numElements > 4 {
+		return fmt.Errorf("AK3: Wrong number of fields, expected 2 to 4, got %d", numElements)
+	}
+
+	var err error
+	s.SegmentIDCode = elements[0]
+	s.SegmentPositionInTransactionSet, err = strconv.Atoi(elements[1])
+	if err != nil {
+		return err
+	}
+	if numElements > 2 {
+		s.LoopIdentifierCode = elements[2]
+	}
+	if numElements > 3 {
+		s.SegmentSyntaxErrorCode = elements[3]
+	}
+	return nil
+}
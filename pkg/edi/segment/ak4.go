@@ -0,0 +1,42 @@
+package edisegment
+
+import (
+	"fmt"
+)
+
+// AK4 represents the AK4 EDI segment, which reports an error in a data element of the segment named by the
+// preceding AK3
+type AK4 struct {
+	// PositionInSegment is the element position, followed by the component position for composite elements
+	PositionInSegment          string
+	DataElementReferenceNumber string
+	DataElementSyntaxErrorCode string
+	CopyOfBadDataElement       string
+}
+
+// StringArray converts AK4 to an array of strings
+func (s *AK4) StringArray() []string {
+	return []string{
+		"AK4",
+		s.PositionInSegment,
+		s.DataElementReferenceNumber,
+		s.DataElementSyntaxErrorCode,
+		s.CopyOfBadDataElement,
+	}
+}
+
+// Parse parses an X12 string that's split into an array into the AK4 struct
+func (s *AK4) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements != 3 && numElements != 4 {
+		return fmt.Errorf("AK4: Wrong number of fields, expected 3 or 4, got %d", numElements)
+	}
+
+	s.PositionInSegment = elements[0]
+	s.DataElementReferenceNumber = elements[1]
+	s.DataElementSyntaxErrorCode = elements[2]
+	if numElements > 3 {
+		s.CopyOfBadDataElement = elements[3]
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package edisegment
+
+import (
+	"fmt"
+)
+
+// AK5 represents the AK5 EDI segment, which reports whether the transaction set named by the preceding AK2
+// was accepted or rejected
+type AK5 struct {
+	TransactionSetAcknowledgmentCode string
+	// TransactionSetSyntaxErrorCodes holds up to five codes explaining why the transaction set wasn't accepted
+	TransactionSetSyntaxErrorCodes []string
+}
+
+// StringArray converts AK5 to an array of strings
+func (s *AK5) StringArray() []string {
+	return append([]string{"AK5", s.TransactionSetAcknowledgmentCode}, s.TransactionSetSyntaxErrorCodes...)
+}
+
+// Parse parses an X12 string that's split into an array into the AK5 struct
+func (s *AK5) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements < 1 || numElements > 6 {
+		return fmt.Errorf("AK5: Wrong number of fields, expected 1 to 6, got %d", numElements)
+	}
+
+	s.TransactionSetAcknowledgmentCode = elements[0]
+	s.TransactionSetSyntaxErrorCodes = nil
+	for _, code := range elements[1:] {
+		if code != "" {
+			s.TransactionSetSyntaxErrorCodes = append(s.TransactionSetSyntaxErrorCodes, code)
+		}
+	}
+	return nil
+}
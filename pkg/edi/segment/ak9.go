@@ -0,0 +1,58 @@
+package edisegment
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AK9 represents the AK9 EDI segment, which reports whether the functional group named by the AK1 was
+// accepted or rejected
+type AK9 struct {
+	FunctionalGroupAcknowledgeCode  string
+	NumberOfTransactionSetsIncluded int
+	NumberOfReceivedTransactionSets int
+	NumberOfAcceptedTransactionSets int
+	// FunctionalGroupSyntaxErrorCodes holds up to five codes explaining why the functional group wasn't accepted
+	FunctionalGroupSyntaxErrorCodes []string
+}
+
+// StringArray converts AK9 to an array of strings
+func (s *AK9) StringArray() []string {
+	return append([]string{
+		"AK9",
+		s.FunctionalGroupAcknowledgeCode,
+		strconv.Itoa(s.NumberOfTransactionSetsIncluded),
+		strconv.Itoa(s.NumberOfReceivedTransactionSets),
+		strconv.Itoa(s.NumberOfAcceptedTransactionSets),
+	}, s.FunctionalGroupSyntaxErrorCodes...)
+}
+
+// Parse parses an X12 string that's split into an array into the AK9 struct
+func (s *AK9) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements < 4 || numElements > 9 {
+		return fmt.Errorf("AK9: Wrong number of elements, expected 4 to 9, got %d", numElements)
+	}
+
+	var err error
+	s.FunctionalGroupAcknowledgeCode = elements[0]
+	s.NumberOfTransactionSetsIncluded, err = strconv.Atoi(elements[1])
+	if err != nil {
+		return err
+	}
+	s.NumberOfReceivedTransactionSets, err = strconv.Atoi(elements[2])
+	if err != nil {
+		return err
+	}
+	s.NumberOfAcceptedTransactionSets, err = strconv.Atoi(elements[3])
+	if err != nil {
+		return err
+	}
+	s.FunctionalGroupSyntaxErrorCodes = nil
+	for _, code := range elements[4:] {
+		if code != "" {
+			s.FunctionalGroupSyntaxErrorCodes = append(s.FunctionalGroupSyntaxErrorCodes, code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package edisegment
+
+import (
+	"fmt"
+)
+
+// BGN represents the BGN EDI segment, which begins an 824 application advice
+type BGN struct {
+	TransactionSetPurposeCode string
+	ReferenceIdentification   string
+	Date                      string
+	Time                      string
+}
+
+// StringArray converts BGN to an array of strings
+func (s *BGN) StringArray() []string {
+	return []string{"BGN", s.TransactionSetPurposeCode, s.ReferenceIdentification, s.Date, s.Time}
+}
+
+// Parse parses an X12 string that's split into an array into the BGN struct
+func (s *BGN) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements < 3 || numElements > 4 {
+		return fmt.Errorf("BGN: Wrong number of fields, expected 3 to 4, got %d", numElements)
+	}
+
+	s.TransactionSetPurposeCode = elements[0]
+	s.ReferenceIdentification = elements[1]
+	s.Date = elements[2]
+	if numElements > 3 {
+		s.Time = elements[3]
+	}
+	return nil
+}
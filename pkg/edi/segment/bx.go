@@ -37,7 +37,7 @@ func (s *BX) Parse(elements []string) error {
 	}
 
 	s.TransactionSetPurposeCode = elements[0]
-	s.TransactionSetPurposeCode = elements[1]
+	s.TransactionMethodTypeCode = elements[1]
 	s.ShipmentMethodOfPayment = elements[2]
 	s.ShipmentIdentificationNumber = elements[3]
 	s.StandardCarrierAlphaCode = elements[4]
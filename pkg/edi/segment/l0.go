@@ -60,16 +60,23 @@ func (s *L0) Parse(parts []string) error {
 	if err != nil {
 		return err
 	}
-	s.BilledRatedAsQuantity, err = strconv.ParseFloat(parts[1], 64)
-	if err != nil {
-		return err
+	// An empty quantity or weight is written for zero, so read it back as zero
+	s.BilledRatedAsQuantity = 0
+	if parts[1] != "" {
+		s.BilledRatedAsQuantity, err = strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return err
+		}
 	}
 	s.BilledRatedAsQualifier = parts[2]
 
 	if numElements == 11 {
-		s.Weight, err = strconv.ParseFloat(parts[3], 64)
-		if err != nil {
-			return err
+		s.Weight = 0
+		if parts[3] != "" {
+			s.Weight, err = strconv.ParseFloat(parts[3], 64)
+			if err != nil {
+				return err
+			}
 		}
 		s.WeightQualifier = parts[4]
 		s.WeightUnitCode = parts[10]
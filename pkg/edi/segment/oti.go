@@ -0,0 +1,73 @@
+package edisegment
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OTI represents the OTI EDI segment, which identifies a transaction an 824 application advice reports on
+// and whether it was accepted
+type OTI struct {
+	ApplicationAcknowledgementCode   string
+	ReferenceIdentificationQualifier string
+	ReferenceIdentification          string
+	ApplicationSendersCode           string
+	ApplicationReceiversCode         string
+	Date                             string
+	Time                             string
+	// GroupControlNumber is 0 if the segment doesn't have one
+	GroupControlNumber           int64
+	TransactionSetControlNumber  string
+	TransactionSetIdentifierCode string
+}
+
+// StringArray converts OTI to an array of strings
+func (s *OTI) StringArray() []string {
+	groupControlNumber := ""
+	if s.GroupControlNumber != 0 {
+		groupControlNumber = strconv.FormatInt(s.GroupControlNumber, 10)
+	}
+	return []string{
+		"OTI",
+		s.ApplicationAcknowledgementCode,
+		s.ReferenceIdentificationQualifier,
+		s.ReferenceIdentification,
+		s.ApplicationSendersCode,
+		s.ApplicationReceiversCode,
+		s.Date,
+		s.Time,
+		groupControlNumber,
+		s.TransactionSetControlNumber,
+		s.TransactionSetIdentifierCode,
+	}
+}
+
+// Parse parses an X12 string that's split into an array into the OTI struct
+func (s *OTI) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements < 3 || numElements > 10 {
+		return fmt.Errorf("OTI: Wrong number of fields, expected 3 to 10, got %d", numElements)
+	}
+
+	optional := make([]string, 10)
+	copy(optional, elements)
+
+	s.ApplicationAcknowledgementCode = optional[0]
+	s.ReferenceIdentificationQualifier = optional[1]
+	s.ReferenceIdentification = optional[2]
+	s.ApplicationSendersCode = optional[3]
+	s.ApplicationReceiversCode = optional[4]
+	s.Date = optional[5]
+	s.Time = optional[6]
+	s.GroupControlNumber = 0
+	if optional[7] != "" {
+		var err error
+		s.GroupControlNumber, err = strconv.ParseInt(optional[7], 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+	s.TransactionSetControlNumber = optional[8]
+	s.TransactionSetIdentifierCode = optional[9]
+	return nil
+}
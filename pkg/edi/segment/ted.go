@@ -0,0 +1,66 @@
+package edisegment
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TED represents the TED EDI segment, which reports an error an 824 application advice found in a transaction
+type TED struct {
+	ApplicationErrorConditionCode string
+	FreeFormMessage               string
+	SegmentIDCode                 string
+	// SegmentPositionInTransactionSet is 0 if the segment doesn't have one
+	SegmentPositionInTransactionSet int
+	ElementPositionInSegment        string
+	DataElementReferenceNumber      string
+	CopyOfBadDataElement            string
+	DataElementNewContent           string
+}
+
+// StringArray converts TED to an array of strings
+func (s *TED) StringArray() []string {
+	segmentPosition := ""
+	if s.SegmentPositionInTransactionSet != 0 {
+		segmentPosition = strconv.Itoa(s.SegmentPositionInTransactionSet)
+	}
+	return []string{
+		"TED",
+		s.ApplicationErrorConditionCode,
+		s.FreeFormMessage,
+		s.SegmentIDCode,
+		segmentPosition,
+		s.ElementPositionInSegment,
+		s.DataElementReferenceNumber,
+		s.CopyOfBadDataElement,
+		s.DataElementNewContent,
+	}
+}
+
+// Parse parses an X12 string that's split into an array into the TED struct
+func (s *TED) Parse(elements []string) error {
+	numElements := len(elements)
+	if numElements < 1 || numElements > 8 {
+		return fmt.Errorf("TED: Wrong number of fields, expected 1 to 8, got %d", numElements)
+	}
+
+	optional := make([]string, 8)
+	copy(optional, elements)
+
+	s.ApplicationErrorConditionCode = optional[0]
+	s.FreeFormMessage = optional[1]
+	s.SegmentIDCode = optional[2]
+	s.SegmentPositionInTransactionSet = 0
+	if optional[3] != "" {
+		var err error
+		s.SegmentPositionInTransactionSet, err = strconv.Atoi(optional[3])
+		if err != nil {
+			return err
+		}
+	}
+	s.ElementPositionInSegment = optional[4]
+	s.DataElementReferenceNumber = optional[5]
+	s.CopyOfBadDataElement = optional[6]
+	s.DataElementNewContent = optional[7]
+	return nil
+}
@@ -0,0 +1,375 @@
+package edi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ElementType is the X12 data type of a data element
+type ElementType string
+
+const (
+	// ElementTypeID is an identifier, a code from a list defined by X12 or the trading partners
+	ElementTypeID ElementType = "ID"
+	// ElementTypeAN is an alphanumeric string
+	ElementTypeAN ElementType = "AN"
+	// ElementTypeN0 is an integer
+	ElementTypeN0 ElementType = "N0"
+	// ElementTypeN2 is a number with two implied decimal places
+	ElementTypeN2 ElementType = "N2"
+	// ElementTypeR is a decimal number
+	ElementTypeR ElementType = "R"
+	// ElementTypeDT is a date, as YYMMDD or CCYYMMDD
+	ElementTypeDT ElementType = "DT"
+	// ElementTypeTM is a time, as HHMM followed by optional seconds and decimal seconds
+	ElementTypeTM ElementType = "TM"
+)
+
+// ElementDefinition describes a data element of a segment. Lengths of numeric elements count digits only.
+type ElementDefinition struct {
+	Type      ElementType
+	Required  bool
+	MinLength int
+	MaxLength int
+}
+
+// SegmentDefinition describes the data elements of a segment, in order
+type SegmentDefinition []ElementDefinition
+
+func required(t ElementType, min int, max int) ElementDefinition {
+	return ElementDefinition{Type: t, Required: true, MinLength: min, MaxLength: max}
+}
+
+func optional(t ElementType, min int, max int) ElementDefinition {
+	return ElementDefinition{Type: t, MinLength: min, MaxLength: max}
+}
+
+// SegmentDefinitions holds the X12 4010 definitions of the segments we send
+var SegmentDefinitions = map[string]SegmentDefinition{
+	// ISA elements are fixed width
+	"ISA": {
+		required(ElementTypeID, 2, 2), required(ElementTypeAN, 10, 10), required(ElementTypeID, 2, 2),
+		required(ElementTypeAN, 10, 10), required(ElementTypeID, 2, 2), required(ElementTypeAN, 15, 15),
+		required(ElementTypeID, 2, 2), required(ElementTypeAN, 15, 15), required(ElementTypeDT, 6, 6),
+		required(ElementTypeTM, 4, 4), required(ElementTypeID, 1, 1), required(ElementTypeID, 5, 5),
+		required(ElementTypeN0, 9, 9), required(ElementTypeID, 1, 1), required(ElementTypeID, 1, 1),
+		required(ElementTypeAN, 1, 1),
+	},
+	"GS": {
+		required(ElementTypeID, 2, 2), required(ElementTypeAN, 2, 15), required(ElementTypeAN, 2, 15),
+		required(ElementTypeDT, 8, 8), required(ElementTypeTM, 4, 8), required(ElementTypeN0, 1, 9),
+		required(ElementTypeID, 1, 2), required(ElementTypeAN, 1, 12),
+	},
+	"ST": {
+		required(ElementTypeID, 3, 3), required(ElementTypeAN, 4, 9),
+	},
+	"BX": {
+		required(ElementTypeID, 2, 2), required(ElementTypeID, 1, 2), required(ElementTypeID, 2, 2),
+		required(ElementTypeAN, 1, 30), optional(ElementTypeID, 2, 4), optional(ElementTypeID, 1, 1),
+		optional(ElementTypeID, 1, 1),
+	},
+	"N9": {
+		required(ElementTypeID, 2, 3), optional(ElementTypeAN, 1, 30), optional(ElementTypeAN, 1, 45),
+		optional(ElementTypeDT, 8, 8),
+	},
+	"N1": {
+		required(ElementTypeID, 2, 3), optional(ElementTypeAN, 1, 60), optional(ElementTypeID, 1, 2),
+		optional(ElementTypeAN, 2, 80),
+	},
+	"N3": {
+		required(ElementTypeAN, 1, 55), optional(ElementTypeAN, 1, 55),
+	},
+	"N4": {
+		optional(ElementTypeAN, 2, 30), optional(ElementTypeID, 2, 2), optional(ElementTypeID, 3, 15),
+		optional(ElementTypeID, 2, 3), optional(ElementTypeID, 1, 2), optional(ElementTypeAN, 1, 30),
+	},
+	"NTE": {
+		optional(ElementTypeID, 3, 3), required(ElementTypeAN, 1, 80),
+	},
+	"FA1": {
+		required(ElementTypeID, 2, 2),
+	},
+	"FA2": {
+		required(ElementTypeID, 2, 2), required(ElementTypeAN, 1, 80),
+	},
+	"L10": {
+		required(ElementTypeR, 1, 10), required(ElementTypeID, 1, 3), optional(ElementTypeID, 1, 1),
+	},
+	"LX": {
+		required(ElementTypeN0, 1, 6),
+	},
+	"MEA": {
+		optional(ElementTypeID, 2, 2), optional(ElementTypeID, 1, 3), optional(ElementTypeR, 1, 20),
+	},
+	"HL": {
+		required(ElementTypeAN, 1, 12), optional(ElementTypeAN, 1, 12), required(ElementTypeID, 1, 2),
+	},
+	"L0": {
+		optional(ElementTypeN0, 1, 3), optional(ElementTypeR, 1, 15), optional(ElementTypeID, 2, 2),
+		optional(ElementTypeR, 1, 10), optional(ElementTypeID, 1, 2), optional(ElementTypeR, 1, 8),
+		optional(ElementTypeID, 1, 1), optional(ElementTypeN0, 1, 7), optional(ElementTypeID, 3, 5),
+		optional(ElementTypeID, 1, 1), optional(ElementTypeID, 1, 1),
+	},
+	"L1": {
+		optional(ElementTypeN0, 1, 3), optional(ElementTypeR, 1, 15), optional(ElementTypeID, 1, 2),
+		optional(ElementTypeN2, 1, 12), optional(ElementTypeN2, 1, 12), optional(ElementTypeN2, 1, 12),
+		optional(ElementTypeN2, 1, 12), optional(ElementTypeID, 3, 3), optional(ElementTypeAN, 1, 25),
+		optional(ElementTypeID, 1, 1), optional(ElementTypeAN, 1, 4), optional(ElementTypeAN, 2, 80),
+	},
+	"SE": {
+		required(ElementTypeN0, 1, 10), required(ElementTypeAN, 4, 9),
+	},
+	"GE": {
+		required(ElementTypeN0, 1, 6), required(ElementTypeN0, 1, 9),
+	},
+	"IEA": {
+		required(ElementTypeN0, 1, 5), required(ElementTypeN0, 9, 9),
+	},
+}
+
+// TransactionSetFollowRules lists, for each transaction set, the segments that must come directly after one
+// of a set of segments, and the segments that must come directly before one of a set of segments
+var TransactionSetFollowRules = map[string]struct {
+	Follows  map[string][]string
+	Precedes map[string][]string
+}{
+	"858": {
+		Follows: map[string][]string{
+			"BX": {"ST"},
+			"L0": {"HL"},
+			"L1": {"L0"},
+		},
+		Precedes: map[string][]string{
+			"ST": {"BX"},
+			"HL": {"L0"},
+			"L0": {"L1"},
+		},
+	},
+}
+
+// ValidationErrors are the problems found in an X12 interchange
+type ValidationErrors []string
+
+func (v ValidationErrors) Error() string {
+	return "invalid X12: " + strings.Join(v, "; ")
+}
+
+var (
+	integerRegex = regexp.MustCompile(`^-?[0-9]+$`)
+	decimalRegex = regexp.MustCompile(`^-?([0-9]+\.?[0-9]*|\.[0-9]+)$`)
+	digitsRegex  = regexp.MustCompile(`[0-9]`)
+)
+
+// Validate checks an X12 interchange, as an array of segments, before it is sent. It checks each segment's
+// elements against SegmentDefinitions, that the ISA/IEA, GS/GE and ST/SE envelopes are properly nested with
+// matching control numbers and counts, and TransactionSetFollowRules. It returns ValidationErrors listing every
+// problem found, or nil.
+func Validate(segments [][]string) error {
+	var errs ValidationErrors
+	addError := func(position int, format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf("segment %d: ", position+1)+fmt.Sprintf(format, args...))
+	}
+
+	for i, segment := range segments {
+		for _, problem := range validateSegment(segment) {
+			addError(i, "%s", problem)
+		}
+	}
+
+	// Envelopes: ISA (GS (ST ... SE)* GE)* IEA
+	var isa, gs, st []string
+	groups, transactionSets, transactionSetSegments := 0, 0, 0
+	var rules map[string][]string
+	var precedes map[string][]string
+	for i, segment := range segments {
+		if st != nil {
+			transactionSetSegments++
+		}
+		// Empty segments were reported above and have no ID to place them in an envelope
+		if len(segment) == 0 {
+			continue
+		}
+		id := segment[0]
+		switch id {
+		case "ISA":
+			if isa != nil || i != 0 {
+				addError(i, "ISA must be the first segment of the interchange")
+			}
+			isa = segment
+		case "GS":
+			if isa == nil || gs != nil {
+				addError(i, "GS must be inside an interchange, and not inside another functional group")
+			}
+			gs = segment
+			groups++
+			transactionSets = 0
+		case "ST":
+			if gs == nil || st != nil {
+				addError(i, "ST must be inside a functional group, and not inside another transaction set")
+			}
+			st = segment
+			transactionSets++
+			transactionSetSegments = 1
+			rule := TransactionSetFollowRules[element(segment, 1)]
+			rules, precedes = rule.Follows, rule.Precedes
+		case "SE":
+			if st == nil {
+				addError(i, "SE must end a transaction set")
+				break
+			}
+			if count, err := strconv.Atoi(element(segment, 1)); err != nil || count != transactionSetSegments {
+				addError(i, "SE counts %s segments, but the transaction set has %d", element(segment, 1), transactionSetSegments)
+			}
+			if element(segment, 2) != element(st, 2) {
+				addError(i, "SE control number %s doesn't match ST control number %s", element(segment, 2), element(st, 2))
+			}
+			st = nil
+			rules, precedes = nil, nil
+		case "GE":
+			if gs == nil || st != nil {
+				addError(i, "GE must end a functional group, after its last transaction set")
+				break
+			}
+			if count, err := strconv.Atoi(element(segment, 1)); err != nil || count != transactionSets {
+				addError(i, "GE counts %s transaction sets, but the functional group has %d", element(segment, 1), transactionSets)
+			}
+			if !sameNumber(element(segment, 2), element(gs, 6)) {
+				addError(i, "GE control number %s doesn't match GS control number %s", element(segment, 2), element(gs, 6))
+			}
+			gs = nil
+		case "IEA":
+			if isa == nil || gs != nil || i != len(segments)-1 {
+				addError(i, "IEA must be the last segment, after the last functional group")
+				break
+			}
+			if count, err := strconv.Atoi(element(segment, 1)); err != nil || count != groups {
+				addError(i, "IEA counts %s functional groups, but the interchange has %d", element(segment, 1), groups)
+			}
+			if !sameNumber(element(segment, 2), element(isa, 13)) {
+				addError(i, "IEA control number %s doesn't match ISA control number %s", element(segment, 2), element(isa, 13))
+			}
+		default:
+			if st == nil {
+				addError(i, "%s must be inside a transaction set", id)
+			}
+		}
+
+		if allowed, ok := rules[id]; ok && (i == 0 || !contains(allowed, element(segments[i-1], 0))) {
+			addError(i, "%s must directly follow %s", id, strings.Join(allowed, " or "))
+		}
+		if allowed, ok := precedes[id]; ok && (i == len(segments)-1 || !contains(allowed, element(segments[i+1], 0))) {
+			addError(i, "%s must be directly followed by %s", id, strings.Join(allowed, " or "))
+		}
+	}
+	if len(segments) == 0 || element(segments[len(segments)-1], 0) != "IEA" {
+		errs = append(errs, "interchange must end with an IEA segment")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateSegment checks a segment's elements against its definition
+func validateSegment(segment []string) []string {
+	if len(segment) == 0 {
+		return []string{"segment is empty"}
+	}
+	id := segment[0]
+	definition, ok := SegmentDefinitions[id]
+	if !ok {
+		return []string{fmt.Sprintf("%s is not a known segment", id)}
+	}
+
+	elements := segment[1:]
+	if len(elements) > len(definition) {
+		return []string{fmt.Sprintf("%s has %d elements, but is defined with %d", id, len(elements), len(definition))}
+	}
+
+	var problems []string
+	for i, elementDefinition := range definition {
+		value := ""
+		if i < len(elements) {
+			value = elements[i]
+		}
+		if problem := validateElement(elementDefinition, value); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s%02d %s", id, i+1, problem))
+		}
+	}
+	return problems
+}
+
+// validateElement checks an element's value against its definition, returning a description of the problem
+func validateElement(definition ElementDefinition, value string) string {
+	if value == "" {
+		if definition.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	length := len(value)
+	switch definition.Type {
+	case ElementTypeN0, ElementTypeN2:
+		if !integerRegex.MatchString(value) {
+			return fmt.Sprintf("%q is not a number", value)
+		}
+		length = len(digitsRegex.FindAllString(value, -1))
+	case ElementTypeR:
+		if !decimalRegex.MatchString(value) {
+			return fmt.Sprintf("%q is not a decimal number", value)
+		}
+		length = len(digitsRegex.FindAllString(value, -1))
+	case ElementTypeDT:
+		layout := "20060102"
+		if length == 6 {
+			layout = "060102"
+		}
+		if _, err := time.Parse(layout, value); err != nil {
+			return fmt.Sprintf("%q is not a date", value)
+		}
+	case ElementTypeTM:
+		if !integerRegex.MatchString(value) || length < 4 {
+			return fmt.Sprintf("%q is not a time", value)
+		}
+		if _, err := time.Parse("1504", value[:4]); err != nil {
+			return fmt.Sprintf("%q is not a time", value)
+		}
+	}
+
+	if length < definition.MinLength || length > definition.MaxLength {
+		if definition.MinLength == definition.MaxLength {
+			return fmt.Sprintf("%q must be %d characters long", value, definition.MinLength)
+		}
+		return fmt.Sprintf("%q must be %d to %d characters long", value, definition.MinLength, definition.MaxLength)
+	}
+	return ""
+}
+
+// element returns the segment's element at position, counting from 1, or an empty string if it has none
+func element(segment []string, position int) string {
+	if position < len(segment) {
+		return segment[position]
+	}
+	return ""
+}
+
+// sameNumber reports whether two control numbers are equal, ignoring leading zeros
+func sameNumber(a string, b string) bool {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	return errA == nil && errB == nil && x == y
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package edi
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func readGolden858C(t *testing.T) [][]string {
+	file, err := os.Open("invoice/testdata/expected_invoice.edi.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	records, err := NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestValidateAcceptsGolden858C(t *testing.T) {
+	if err := Validate(readGolden858C(t)); err != nil {
+		t.Errorf("Expected the golden 858C to be valid, got %s", err)
+	}
+}
+
+func TestValidateFindsInvalid858C(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(records [][]string) [][]string
+		expected string
+	}{
+		{"ISA element isn't fixed width", func(records [][]string) [][]string {
+			records[0][6] = "MYMOVE"
+			return records
+		}, `ISA06 "MYMOVE" must be 15 characters long`},
+		{"ISA control number isn't a number", func(records [][]string) [][]string {
+			records[0][13] = "00000000X"
+			return records
+		}, `ISA13 "00000000X" is not a number`},
+		{"GS date isn't a date", func(records [][]string) [][]string {
+			records[1][4] = "19701301"
+			return records
+		}, `GS04 "19701301" is not a date`},
+		{"required element is missing", func(records [][]string) [][]string {
+			records[14] = []string{"FA2", "TA", ""}
+			return records
+		}, "FA202 is required"},
+		{"element is too long", func(records [][]string) [][]string {
+			records[3][4] = strings.Repeat("K", 31)
+			return records
+		}, "must be 1 to 30 characters long"},
+		{"decimal isn't a number", func(records [][]string) [][]string {
+			records[15][1] = "20.0.0"
+			return records
+		}, `L1001 "20.0.0" is not a decimal number`},
+		{"segment has too many elements", func(records [][]string) [][]string {
+			records[13] = []string{"FA1", "DZ", "X"}
+			return records
+		}, "FA1 has 2 elements, but is defined with 1"},
+		{"unknown segment", func(records [][]string) [][]string {
+			records[13] = []string{"ZZZ", "DZ"}
+			return records
+		}, "ZZZ is not a known segment"},
+		{"SE count is wrong", func(records [][]string) [][]string {
+			records[len(records)-3][1] = "44"
+			return records
+		}, "SE counts 44 segments, but the transaction set has 45"},
+		{"SE control number doesn't match ST", func(records [][]string) [][]string {
+			records[len(records)-3][2] = "0002"
+			return records
+		}, "SE control number 0002 doesn't match ST control number 0001"},
+		{"GE control number doesn't match GS", func(records [][]string) [][]string {
+			records[len(records)-2][2] = "3"
+			return records
+		}, "GE control number 3 doesn't match GS control number 2"},
+		{"IEA control number doesn't match ISA", func(records [][]string) [][]string {
+			records[len(records)-1][2] = "000000003"
+			return records
+		}, "IEA control number 000000003 doesn't match ISA control number 000000002"},
+		{"L0 doesn't follow HL", func(records [][]string) [][]string {
+			// Swap the first line item's HL and L0
+			records[16], records[17] = records[17], records[16]
+			return records
+		}, "L0 must directly follow HL"},
+		{"segment outside a transaction set", func(records [][]string) [][]string {
+			last := len(records) - 1
+			return append(records[:last-1], []string{"FA1", "DZ"}, records[last-1], records[last])
+		}, "FA1 must be inside a transaction set"},
+		{"empty segment", func(records [][]string) [][]string {
+			records[14] = []string{}
+			return records
+		}, "segment 15: segment is empty"},
+		{"empty final segment", func(records [][]string) [][]string {
+			records[len(records)-1] = []string{}
+			return records
+		}, "interchange must end with an IEA segment"},
+		{"missing IEA", func(records [][]string) [][]string {
+			return records[:len(records)-1]
+		}, "interchange must end with an IEA segment"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.modify(readGolden858C(t)))
+			if err == nil {
+				t.Fatal("Expected a validation error")
+			}
+			if !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("Expected %q to contain %q", err.Error(), test.expected)
+			}
+		})
+	}
+}
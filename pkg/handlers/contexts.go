@@ -13,6 +13,7 @@ import (
 	"github.com/transcom/mymove/pkg/iws"
 	"github.com/transcom/mymove/pkg/logging/hnyzap"
 	"github.com/transcom/mymove/pkg/notifications"
+	"github.com/transcom/mymove/pkg/rateengine"
 	"github.com/transcom/mymove/pkg/route"
 	"github.com/transcom/mymove/pkg/services"
 	"github.com/transcom/mymove/pkg/storage"
@@ -29,6 +30,8 @@ type HandlerContext interface {
 	SetNotificationSender(sender notifications.NotificationSender)
 	Planner() route.Planner
 	SetPlanner(planner route.Planner)
+	RateFetcher() rateengine.RateFetcher
+	SetRateFetcher(rates rateengine.RateFetcher)
 	CookieSecret() string
 	SetCookieSecret(secret string)
 	NoSessionTimeout() bool
@@ -57,6 +60,7 @@ type handlerContext struct {
 	cookieSecret          string
 	noSessionTimeout      bool
 	planner               route.Planner
+	rateFetcher           rateengine.RateFetcher
 	storage               storage.FileStorer
 	notificationSender    notifications.NotificationSender
 	iwsPersonLookup       iws.PersonLookup
@@ -135,6 +139,16 @@ func (hctx *handlerContext) SetPlanner(planner route.Planner) {
 	hctx.planner = planner
 }
 
+// RateFetcher returns the rateengine.RateFetcher shared by rate engines created by handlers, if any
+func (hctx *handlerContext) RateFetcher() rateengine.RateFetcher {
+	return hctx.rateFetcher
+}
+
+// SetRateFetcher is a simple setter for the rateengine.RateFetcher private field
+func (hctx *handlerContext) SetRateFetcher(rates rateengine.RateFetcher) {
+	hctx.rateFetcher = rates
+}
+
 // CookieSecret returns the secret key to use when signing cookies
 func (hctx *handlerContext) CookieSecret() string {
 	return hctx.cookieSecret
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/transcom/mymove/pkg/rateengine"
 	"github.com/transcom/mymove/pkg/route"
 
 	"github.com/go-openapi/runtime"
@@ -71,6 +72,17 @@ func ResponseForError(logger Logger, err error) middleware.Responder {
 		default:
 			return newErrResponse(http.StatusInternalServerError, err)
 		}
+	case rateengine.Error:
+		skipLogger.Info("Encountered error using rate engine", zap.Error(e))
+		// Handle rate engine error codes
+		switch e.Code() {
+		case rateengine.MissingQuantity:
+			return newErrResponse(http.StatusUnprocessableEntity, err)
+		case rateengine.InternationalNotPriced:
+			return newErrResponse(http.StatusUnprocessableEntity, err)
+		default:
+			return newErrResponse(http.StatusInternalServerError, err)
+		}
 	default:
 		return responseForBaseError(skipLogger, err)
 	}
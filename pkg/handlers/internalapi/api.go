@@ -34,8 +34,14 @@ func NewInternalAPIHandler(context handlers.HandlerContext) http.Handler {
 	internalAPI.PpmShowPPMEstimateHandler = ShowPPMEstimateHandler{context}
 	internalAPI.PpmShowPPMSitEstimateHandler = ShowPPMSitEstimateHandler{context}
 	internalAPI.PpmShowPPMIncentiveHandler = ShowPPMIncentiveHandler{context}
+	internalAPI.PpmShowPPMIncentiveMatrixHandler = ShowPPMIncentiveMatrixHandler{context}
 	internalAPI.PpmRequestPPMPaymentHandler = RequestPPMPaymentHandler{context}
 	internalAPI.PpmCreatePPMAttachmentsHandler = CreatePersonallyProcuredMoveAttachmentsHandler{context}
+
+	internalAPI.PovIndexVehicleProcessingCentersHandler = IndexVehicleProcessingCentersHandler{context}
+	internalAPI.PovCreatePrivatelyOwnedVehicleHandler = CreatePrivatelyOwnedVehicleHandler{context}
+	internalAPI.PovIndexPrivatelyOwnedVehiclesHandler = IndexPrivatelyOwnedVehiclesHandler{context}
+	internalAPI.PovPatchPrivatelyOwnedVehicleHandler = PatchPrivatelyOwnedVehicleHandler{context}
 	internalAPI.PpmRequestPPMExpenseSummaryHandler = RequestPPMExpenseSummaryHandler{context}
 
 	internalAPI.DutyStationsSearchDutyStationsHandler = SearchDutyStationsHandler{context}
@@ -84,8 +90,12 @@ func NewInternalAPIHandler(context handlers.HandlerContext) http.Handler {
 
 	internalAPI.OfficeApproveMoveHandler = ApproveMoveHandler{context}
 	internalAPI.OfficeApprovePPMHandler = ApprovePPMHandler{context}
+	internalAPI.OfficeRecordPOVDropOffHandler = RecordPOVDropOffHandler{context}
+	internalAPI.OfficeRecordPOVPickupHandler = RecordPOVPickupHandler{context}
 	internalAPI.OfficeApproveReimbursementHandler = ApproveReimbursementHandler{context}
 	internalAPI.OfficeCancelMoveHandler = CancelMoveHandler{context}
+	internalAPI.OfficeShowAwardQueueFairnessReportHandler = ShowAwardQueueFairnessReportHandler{context}
+	internalAPI.OfficeShowShipmentLineItemPricingTraceHandler = ShowShipmentLineItemPricingTraceHandler{context}
 
 	internalAPI.EntitlementsValidateEntitlementHandler = ValidateEntitlementHandler{context}
 
@@ -52,8 +52,13 @@ func (h ValidateEntitlementHandler) Handle(params entitlementop.ValidateEntitlem
 	if len(move.PersonallyProcuredMoves) >= 1 {
 		// PPMs are in descending order - this is the last one created
 		weightEstimate = int64(*move.PersonallyProcuredMoves[0].WeightEstimate)
-	} else if len(move.Shipments) >= 1 {
-		weightEstimate = int64(*move.Shipments[0].WeightEstimate)
+	} else {
+		// A move can have both an HHG and a UB shipment, which count against the same entitlement
+		for _, shipment := range move.Shipments {
+			if shipment.WeightEstimate != nil {
+				weightEstimate += int64(*shipment.WeightEstimate)
+			}
+		}
 	}
 
 	smEntitlement, err := models.GetEntitlement(*serviceMember.Rank, orders.HasDependents, orders.SpouseHasProGear)
@@ -90,13 +90,32 @@ func (h CreateGenericMoveDocumentHandler) Handle(params movedocop.CreateGenericM
 		ppmID = &id
 	}
 
+	// Vehicle paperwork is linked to the POV shipment instead of a PPM
+	modelID := ppmID
+	moveType := *move.SelectedMoveType
+	if payload.PrivatelyOwnedVehicleID != nil {
+		id := uuid.Must(uuid.FromString(payload.PrivatelyOwnedVehicleID.String()))
+
+		// Enforce that the pov's move_id matches our move
+		pov, err := models.FetchPrivatelyOwnedVehicle(h.DB(), session, id)
+		if err != nil {
+			return handlers.ResponseForError(h.Logger(), err)
+		}
+		if pov.MoveID != moveID {
+			return movedocop.NewCreateGenericMoveDocumentBadRequest()
+		}
+
+		modelID = &id
+		moveType = models.SelectedMoveTypePOV
+	}
+
 	newMoveDocument, verrs, err := move.CreateMoveDocument(h.DB(),
 		uploads,
-		ppmID,
+		modelID,
 		models.MoveDocumentType(payload.MoveDocumentType),
 		*payload.Title,
 		payload.Notes,
-		*move.SelectedMoveType)
+		moveType)
 
 	if err != nil || verrs.HasAny() {
 		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
@@ -49,7 +49,7 @@ func calculateMoveDatesFromMove(db *pop.Connection, planner route.Planner, moveI
 	}
 	entitlementWeight := unit.Pound(entitlement)
 	estimatedPackDays := models.PackDays(entitlementWeight)
-	estimatedTransitDays, err := models.TransitDays(entitlementWeight, transitDistance)
+	estimatedTransitDays, err := models.TransitDays(models.ShipmentTypeHHG, entitlementWeight, transitDistance)
 	if err != nil {
 		return summary, err
 	}
@@ -51,6 +51,11 @@ func payloadForMoveModel(storer storage.FileStorer, order models.Order, move mod
 		shipmentPayloads = append(shipmentPayloads, payload)
 	}
 
+	povPayloads := make(internalmessages.IndexPrivatelyOwnedVehiclesPayload, len(move.PrivatelyOwnedVehicles))
+	for i, pov := range move.PrivatelyOwnedVehicles {
+		povPayloads[i] = payloadForPOVModel(pov)
+	}
+
 	movePayload := &internalmessages.MovePayload{
 		CreatedAt:               handlers.FmtDateTime(move.CreatedAt),
 		SelectedMoveType:        &SelectedMoveType,
@@ -62,6 +67,7 @@ func payloadForMoveModel(storer storage.FileStorer, order models.Order, move mod
 		ServiceMemberID:         *handlers.FmtUUID(order.ServiceMemberID),
 		Status:                  internalmessages.MoveStatus(move.Status),
 		Shipments:               shipmentPayloads,
+		PrivatelyOwnedVehicles:  povPayloads,
 	}
 	return movePayload, nil
 }
@@ -185,7 +191,12 @@ func (h SubmitMoveHandler) Handle(params moveop.SubmitMoveForApprovalParams) mid
 	}
 
 	if len(move.Shipments) > 0 {
-		go awardqueue.NewAwardQueue(h.DB(), h.HoneyZapLogger()).Run(ctx)
+		awardQueue, err := awardqueue.NewAwardQueue(h.DB(), h.HoneyZapLogger())
+		if err != nil {
+			h.Logger().Error("problem loading award queue policies", zap.Error(err))
+		} else {
+			go awardQueue.Run(ctx)
+		}
 	}
 
 	movePayload, err := payloadForMoveModel(h.FileStorer(), move.Orders, *move)
@@ -241,7 +252,7 @@ type ShowShipmentSummaryWorksheetHandler struct {
 func (h ShowShipmentSummaryWorksheetHandler) Handle(params moveop.ShowShipmentSummaryWorksheetParams) middleware.Responder {
 	session := auth.SessionFromRequestContext(params.HTTPRequest)
 	moveID, _ := uuid.FromString(params.MoveID.String())
-	ppmComputer := paperwork.NewSSWPPMComputer(rateengine.NewRateEngine(h.DB(), h.Logger()))
+	ppmComputer := paperwork.NewSSWPPMComputer(rateengine.NewRateEngine(h.DB(), h.Logger()).WithRateFetcher(h.RateFetcher()))
 
 	ssfd, err := models.FetchDataShipmentSummaryWorksheetFormData(h.DB(), session, moveID)
 	if err != nil {
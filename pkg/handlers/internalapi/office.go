@@ -1,15 +1,21 @@
 package internalapi
 
 import (
+	"database/sql"
 	"reflect"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/gofrs/uuid"
 	"github.com/honeycombio/beeline-go"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/transcom/mymove/pkg/auth"
+	"github.com/transcom/mymove/pkg/awardqueue"
 	officeop "github.com/transcom/mymove/pkg/gen/internalapi/internaloperations/office"
+	"github.com/transcom/mymove/pkg/gen/internalmessages"
 	"github.com/transcom/mymove/pkg/handlers"
 	"github.com/transcom/mymove/pkg/models"
 	"github.com/transcom/mymove/pkg/notifications"
@@ -22,6 +28,10 @@ type ApproveMoveHandler struct {
 
 // Handle ... approves a Move from a request payload
 func (h ApproveMoveHandler) Handle(params officeop.ApproveMoveParams) middleware.Responder {
+
+	ctx, span := beeline.StartSpan(params.HTTPRequest.Context(), reflect.TypeOf(h).Name())
+	defer span.Send()
+
 	session := auth.SessionFromRequestContext(params.HTTPRequest)
 
 	if !session.IsOfficeUser() {
@@ -48,13 +58,26 @@ func (h ApproveMoveHandler) Handle(params officeop.ApproveMoveParams) middleware
 		return handlers.ResponseForError(h.Logger(), err)
 	}
 
-	verrs, err := h.DB().ValidateAndUpdate(move)
+	// Approving the move also approves its POV shipments, so save those along with it
+	verrs, err := models.SaveMoveDependencies(h.DB(), move)
 	if err != nil || verrs.HasAny() {
 		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
 	}
 
 	// TODO: Save and/or update the move association status' (PPM, Reimbursement, Orders) a la Cancel handler
 
+	// PPMs send their approval email when they are approved, but POVs are approved with the move
+	if len(move.PrivatelyOwnedVehicles) > 0 {
+		err = h.NotificationSender().SendNotification(
+			ctx,
+			notifications.NewMoveApproved(h.DB(), h.Logger(), session, moveID),
+		)
+		if err != nil {
+			h.Logger().Error("problem sending email to user", zap.Error(err))
+			return handlers.ResponseForError(h.Logger(), err)
+		}
+	}
+
 	movePayload, err := payloadForMoveModel(h.FileStorer(), move.Orders, *move)
 	if err != nil {
 		return handlers.ResponseForError(h.Logger(), err)
@@ -202,3 +225,221 @@ func (h ApproveReimbursementHandler) Handle(params officeop.ApproveReimbursement
 	reimbursementPayload := payloadForReimbursementModel(reimbursement)
 	return officeop.NewApproveReimbursementOK().WithPayload(reimbursementPayload)
 }
+
+// RecordPOVDropOffHandler records a vehicle being dropped off via POST /privately_owned_vehicles/{privatelyOwnedVehicleId}/drop_off
+type RecordPOVDropOffHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle ... records the drop-off of a Privately Owned Vehicle from a request payload
+func (h RecordPOVDropOffHandler) Handle(params officeop.RecordPOVDropOffParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	if !session.IsOfficeUser() {
+		return officeop.NewRecordPOVDropOffForbidden()
+	}
+
+	// #nosec UUID is pattern matched by swagger and will be ok
+	povID, _ := uuid.FromString(params.PrivatelyOwnedVehicleID.String())
+
+	pov, err := models.FetchPrivatelyOwnedVehicle(h.DB(), session, povID)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	payload := params.RecordPOVDropOffPayload
+	// #nosec UUID is pattern matched by swagger and will be ok
+	vpcID, _ := uuid.FromString(payload.DropOffVpcID.String())
+	err = pov.DropOff(time.Time(*payload.ActualDropOffDate), vpcID)
+	if err != nil {
+		h.Logger().Error("Attempted to drop off POV, got invalid transition", zap.Error(err), zap.String("pov_status", string(pov.Status)))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	verrs, err := h.DB().ValidateAndUpdate(pov)
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return officeop.NewRecordPOVDropOffOK().WithPayload(payloadForPOVModel(*pov))
+}
+
+// RecordPOVPickupHandler records a vehicle being picked up via POST /privately_owned_vehicles/{privatelyOwnedVehicleId}/pick_up
+type RecordPOVPickupHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle ... records the pickup of a Privately Owned Vehicle from a request payload
+func (h RecordPOVPickupHandler) Handle(params officeop.RecordPOVPickupParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	if !session.IsOfficeUser() {
+		return officeop.NewRecordPOVPickupForbidden()
+	}
+
+	// #nosec UUID is pattern matched by swagger and will be ok
+	povID, _ := uuid.FromString(params.PrivatelyOwnedVehicleID.String())
+
+	pov, err := models.FetchPrivatelyOwnedVehicle(h.DB(), session, povID)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	payload := params.RecordPOVPickupPayload
+	// #nosec UUID is pattern matched by swagger and will be ok
+	vpcID, _ := uuid.FromString(payload.PickupVpcID.String())
+	err = pov.PickUp(time.Time(*payload.ActualPickupDate), vpcID)
+	if err != nil {
+		h.Logger().Error("Attempted to pick up POV, got invalid transition", zap.Error(err), zap.String("pov_status", string(pov.Status)))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	verrs, err := h.DB().ValidateAndUpdate(pov)
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return officeop.NewRecordPOVPickupOK().WithPayload(payloadForPOVModel(*pov))
+}
+
+func payloadForFairnessReport(report *awardqueue.FairnessReport) *internalmessages.AwardQueueFairnessReport {
+	payload := &internalmessages.AwardQueueFairnessReport{
+		TrafficDistributionListID: strfmt.UUID(report.TrafficDistributionListID.String()),
+		StartDate:                 strfmt.Date(report.Start),
+		EndDate:                   strfmt.Date(report.End),
+		PerformancePeriods:        []*internalmessages.AwardQueueFairnessPerformancePeriod{},
+		Flags:                     report.Flags,
+	}
+	for _, period := range report.PerformancePeriods {
+		periodPayload := &internalmessages.AwardQueueFairnessPerformancePeriod{
+			PerformancePeriodStart: strfmt.Date(period.PerformancePeriodStart),
+			PerformancePeriodEnd:   strfmt.Date(period.PerformancePeriodEnd),
+			Offers:                 int64(period.Offers),
+			AdministrativeOffers:   int64(period.AdministrativeOffers),
+		}
+		for _, band := range period.Bands {
+			periodPayload.Bands = append(periodPayload.Bands, &internalmessages.AwardQueueFairnessBand{
+				QualityBand:      int64(band.QualityBand),
+				TspCount:         int64(band.TSPs),
+				ExpectedTspCount: int64(band.ExpectedTSPs),
+				Offers:           int64(band.Offers),
+				ExpectedOffers:   int64(band.ExpectedOffers),
+				Drift:            band.Drift,
+			})
+		}
+		for _, tsp := range period.TSPs {
+			var qualityBand *int64
+			if tsp.QualityBand != nil {
+				qualityBand = handlers.FmtInt64(int64(*tsp.QualityBand))
+			}
+			periodPayload.Tsps = append(periodPayload.Tsps, &internalmessages.AwardQueueFairnessTSP{
+				TransportationServiceProviderID: strfmt.UUID(tsp.TSPID.String()),
+				Scac:                            tsp.SCAC,
+				QualityBand:                     qualityBand,
+				BestValueScore:                  tsp.BestValueScore,
+				OfferCount:                      int64(tsp.OfferCount),
+				Offers:                          int64(tsp.Offers),
+				AdministrativeOffers:            int64(tsp.AdministrativeOffers),
+				ExpectedOffers:                  int64(tsp.ExpectedOffers),
+				Drift:                           tsp.Drift,
+			})
+		}
+		payload.PerformancePeriods = append(payload.PerformancePeriods, periodPayload)
+	}
+	return payload
+}
+
+// ShowAwardQueueFairnessReportHandler reports how the award queue distributed offers in a TDL
+type ShowAwardQueueFairnessReportHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle returns the fairness report for the TDL and date range
+func (h ShowAwardQueueFairnessReportHandler) Handle(params officeop.ShowAwardQueueFairnessReportParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	if !session.IsOfficeUser() {
+		return officeop.NewShowAwardQueueFairnessReportForbidden()
+	}
+
+	// #nosec UUID is pattern matched by swagger and will be ok
+	tdlID, _ := uuid.FromString(params.TrafficDistributionListID.String())
+	startDate := time.Time(params.StartDate)
+	endDate := time.Time(params.EndDate)
+	if endDate.Before(startDate) {
+		return officeop.NewShowAwardQueueFairnessReportBadRequest()
+	}
+
+	tdl, err := models.FetchTDLByID(h.DB(), tdlID)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	report, err := awardqueue.NewFairnessReport(h.DB(), tdl.ID, startDate, endDate)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	return officeop.NewShowAwardQueueFairnessReportOK().WithPayload(payloadForFairnessReport(report))
+}
+
+func payloadForPricingTraceStepModels(s models.PricingTraceSteps) internalmessages.PricingTraceSteps {
+	payloads := make(internalmessages.PricingTraceSteps, len(s))
+
+	for i, step := range s {
+		payloads[i] = payloadForPricingTraceStepModel(&step)
+	}
+
+	return payloads
+}
+
+func payloadForPricingTraceStepModel(s *models.PricingTraceStep) *internalmessages.PricingTraceStep {
+	if s == nil {
+		return nil
+	}
+
+	return &internalmessages.PricingTraceStep{
+		ID:                 *handlers.FmtUUID(s.ID),
+		ShipmentLineItemID: *handlers.FmtUUID(s.ShipmentLineItemID),
+		Position:           int64(s.Position),
+		Kind:               internalmessages.PricingTraceStepKind(s.Kind),
+		Description:        s.Description,
+		RateTable:          s.RateTable,
+		RowID:              handlers.FmtUUIDPtr(s.RowID),
+		Value:              s.Value,
+	}
+}
+
+// ShowShipmentLineItemPricingTraceHandler returns the pricing trace for a shipment line item
+type ShowShipmentLineItemPricingTraceHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle returns the steps used to price a specified shipment line item
+func (h ShowShipmentLineItemPricingTraceHandler) Handle(params officeop.ShowShipmentLineItemPricingTraceParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+
+	// Only office users can see how a line item was priced
+	if !session.IsOfficeUser() {
+		return officeop.NewShowShipmentLineItemPricingTraceForbidden()
+	}
+
+	// #nosec UUID is pattern matched by swagger and will be ok
+	shipmentLineItemID, _ := uuid.FromString(params.ShipmentLineItemID.String())
+	shipmentLineItem, err := models.FetchShipmentLineItemByID(h.DB(), &shipmentLineItemID)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			h.Logger().Error("Error shipment line item not found", zap.Error(err))
+			return officeop.NewShowShipmentLineItemPricingTraceNotFound()
+		}
+
+		h.Logger().Error("Error fetching shipment line item", zap.Error(err))
+		return officeop.NewShowShipmentLineItemPricingTraceInternalServerError()
+	}
+
+	steps, err := models.FetchPricingTraceStepsForShipmentLineItem(h.DB(), shipmentLineItem.ID)
+	if err != nil {
+		h.Logger().Error("Error fetching pricing trace for shipment line item", zap.Error(err))
+		return officeop.NewShowShipmentLineItemPricingTraceInternalServerError()
+	}
+
+	payload := payloadForPricingTraceStepModels(steps)
+	return officeop.NewShowShipmentLineItemPricingTraceOK().WithPayload(payload)
+}
@@ -286,3 +286,56 @@ func (suite *HandlerSuite) TestApproveReimbursementHandlerForbidden() {
 	// Then: expect Forbidden response
 	suite.Assertions.IsType(&officeop.ApproveReimbursementForbidden{}, response)
 }
+
+func (suite *HandlerSuite) TestShowShipmentLineItemPricingTraceHandler() {
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+	item := testdatagen.MakeDefaultShipmentLineItem(suite.DB())
+
+	// Given: the line item has been priced
+	steps := models.PricingTraceSteps{
+		{Kind: models.PricingTraceStepKindRATELOOKUP, Description: "Rate for 105B", Value: "1000"},
+		{Kind: models.PricingTraceStepKindCHARGE, Description: "Charge for 105B", Value: "1000"},
+	}
+	verrs, err := models.SavePricingTraceSteps(suite.DB(), item.ID, steps)
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+
+	// When: the office user asks for the pricing trace
+	req := httptest.NewRequest("GET", "/shipments/accessorials/some_id/pricing_trace", nil)
+	req = suite.AuthenticateOfficeRequest(req, officeUser)
+	params := officeop.ShowShipmentLineItemPricingTraceParams{
+		HTTPRequest:        req,
+		ShipmentLineItemID: strfmt.UUID(item.ID.String()),
+	}
+
+	handler := ShowShipmentLineItemPricingTraceHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	// Then: the steps are returned in order, ending with the charge
+	if suite.Assertions.IsType(&officeop.ShowShipmentLineItemPricingTraceOK{}, response) {
+		okResponse := response.(*officeop.ShowShipmentLineItemPricingTraceOK)
+		if suite.Len(okResponse.Payload, 2) {
+			suite.Equal(internalmessages.PricingTraceStepKindRATELOOKUP, okResponse.Payload[0].Kind)
+			suite.Equal(internalmessages.PricingTraceStepKindCHARGE, okResponse.Payload[1].Kind)
+			suite.Equal(int64(1), okResponse.Payload[1].Position)
+		}
+	}
+}
+
+func (suite *HandlerSuite) TestShowShipmentLineItemPricingTraceHandlerForbidden() {
+	user := testdatagen.MakeDefaultServiceMember(suite.DB())
+	item := testdatagen.MakeDefaultShipmentLineItem(suite.DB())
+
+	req := httptest.NewRequest("GET", "/shipments/accessorials/some_id/pricing_trace", nil)
+	req = suite.AuthenticateRequest(req, user)
+	params := officeop.ShowShipmentLineItemPricingTraceParams{
+		HTTPRequest:        req,
+		ShipmentLineItemID: strfmt.UUID(item.ID.String()),
+	}
+
+	handler := ShowShipmentLineItemPricingTraceHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	// Then: expect anyone but an office user to be forbidden from seeing the trace
+	suite.Assertions.IsType(&officeop.ShowShipmentLineItemPricingTraceForbidden{}, response)
+}
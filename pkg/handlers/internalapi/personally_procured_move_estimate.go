@@ -21,7 +21,7 @@ type ShowPPMEstimateHandler struct {
 
 // Handle calculates a PPM reimbursement range.
 func (h ShowPPMEstimateHandler) Handle(params ppmop.ShowPPMEstimateParams) middleware.Responder {
-	engine := rateengine.NewRateEngine(h.DB(), h.Logger())
+	engine := rateengine.NewRateEngine(h.DB(), h.Logger()).WithRateFetcher(h.RateFetcher())
 
 	lhDiscount, _, err := models.PPMDiscountFetch(h.DB(),
 		h.Logger(),
@@ -6,6 +6,7 @@ import (
 	"github.com/transcom/mymove/pkg/models"
 
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
 	"github.com/transcom/mymove/pkg/auth"
@@ -28,7 +29,7 @@ func (h ShowPPMIncentiveHandler) Handle(params ppmop.ShowPPMIncentiveParams) mid
 	if !session.IsOfficeUser() {
 		return ppmop.NewShowPPMIncentiveForbidden()
 	}
-	engine := rateengine.NewRateEngine(h.DB(), h.Logger())
+	engine := rateengine.NewRateEngine(h.DB(), h.Logger()).WithRateFetcher(h.RateFetcher())
 
 	lhDiscount, _, err := models.PPMDiscountFetch(h.DB(),
 		h.Logger(),
@@ -60,7 +61,7 @@ func (h ShowPPMIncentiveHandler) Handle(params ppmop.ShowPPMIncentiveParams) mid
 	}
 
 	gcc := cost.GCC
-	incentivePercentage := cost.GCC.MultiplyFloat64(0.95)
+	incentivePercentage := cost.GCC.MultiplyFloat64(rateengine.PPMIncentiveFactor)
 
 	ppmObligation := internalmessages.PPMIncentive{
 		Gcc:                 swag.Int64(gcc.Int64()),
@@ -68,3 +69,122 @@ func (h ShowPPMIncentiveHandler) Handle(params ppmop.ShowPPMIncentiveParams) mid
 	}
 	return ppmop.NewShowPPMIncentiveOK().WithPayload(&ppmObligation)
 }
+
+// ShowPPMIncentiveMatrixHandler returns PPM incentives across a range of move dates and weights
+type ShowPPMIncentiveMatrixHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle calculates a PPM incentive for every move date and weight in the requested ranges.
+func (h ShowPPMIncentiveMatrixHandler) Handle(params ppmop.ShowPPMIncentiveMatrixParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+
+	if !session.IsOfficeUser() {
+		return ppmop.NewShowPPMIncentiveMatrixForbidden()
+	}
+
+	moveDateInterval := int64(7)
+	if params.MoveDateIntervalDays != nil {
+		moveDateInterval = *params.MoveDateIntervalDays
+	}
+	weightInterval := int64(500)
+	if params.WeightInterval != nil {
+		weightInterval = *params.WeightInterval
+	}
+
+	startMoveDate := time.Time(params.StartMoveDate)
+	endMoveDate := time.Time(params.EndMoveDate)
+
+	// Check the size of the matrix before building it, so a huge range can't be allocated
+	moveDateCount := ppmIncentiveMoveDateCount(startMoveDate, endMoveDate, moveDateInterval)
+	weightCount := ppmIncentiveWeightCount(params.MinWeight, params.MaxWeight, weightInterval)
+	if moveDateCount == 0 || weightCount == 0 || moveDateCount > rateengine.MaxPPMIncentiveMatrixCells/weightCount {
+		return ppmop.NewShowPPMIncentiveMatrixBadRequest()
+	}
+
+	moveDates := ppmIncentiveMoveDates(startMoveDate, int(moveDateInterval), moveDateCount)
+	weights := ppmIncentiveWeights(unit.Pound(params.MinWeight), unit.Pound(weightInterval), weightCount)
+
+	distanceMiles, err := h.Planner().Zip5TransitDistance(params.OriginZip, params.DestinationZip)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	engine := rateengine.NewRateEngine(h.DB(), h.Logger()).WithRateFetcher(h.RateFetcher())
+	matrix, err := engine.ComputePPMIncentiveMatrix(params.OriginZip,
+		params.DestinationZip,
+		distanceMiles,
+		moveDates,
+		weights,
+	)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	return ppmop.NewShowPPMIncentiveMatrixOK().WithPayload(payloadForPPMIncentiveMatrix(matrix))
+}
+
+// ppmIncentiveMoveDateCount returns the number of move dates from start through end, interval days apart
+func ppmIncentiveMoveDateCount(start time.Time, end time.Time, interval int64) int64 {
+	if interval < 1 || end.Before(start) {
+		return 0
+	}
+	days := int64(end.Sub(start) / (24 * time.Hour))
+	return days/interval + 1
+}
+
+// ppmIncentiveWeightCount returns the number of weights from min through max, interval pounds apart
+func ppmIncentiveWeightCount(min int64, max int64, interval int64) int64 {
+	if interval < 1 || min < 0 || max < min {
+		return 0
+	}
+	return (max-min)/interval + 1
+}
+
+// ppmIncentiveMoveDates returns count move dates from start, interval days apart. The count comes from
+// ppmIncentiveMoveDateCount, so the loop is bounded by it rather than by comparing dates.
+func ppmIncentiveMoveDates(start time.Time, interval int, count int64) []time.Time {
+	moveDates := make([]time.Time, 0, count)
+	for i := int64(0); i < count; i++ {
+		moveDates = append(moveDates, start.AddDate(0, 0, int(i)*interval))
+	}
+	return moveDates
+}
+
+// ppmIncentiveWeights returns count weights from min, interval pounds apart. The count comes from
+// ppmIncentiveWeightCount, so the loop is bounded by it and can't run forever if a weight overflows.
+func ppmIncentiveWeights(min unit.Pound, interval unit.Pound, count int64) []unit.Pound {
+	weights := make([]unit.Pound, 0, count)
+	for i := int64(0); i < count; i++ {
+		weights = append(weights, min+unit.Pound(i)*interval)
+	}
+	return weights
+}
+
+func payloadForPPMIncentiveMatrix(matrix rateengine.PPMIncentiveMatrix) *internalmessages.PPMIncentiveMatrix {
+	payload := internalmessages.PPMIncentiveMatrix{
+		MoveDates: make([]strfmt.Date, len(matrix.MoveDates)),
+		Weights:   make([]int64, len(matrix.Weights)),
+		Cells:     []*internalmessages.PPMIncentiveMatrixCell{},
+	}
+
+	for i, moveDate := range matrix.MoveDates {
+		payload.MoveDates[i] = strfmt.Date(moveDate)
+	}
+	for i, weight := range matrix.Weights {
+		payload.Weights[i] = int64(weight)
+	}
+	for _, row := range matrix.Cells {
+		for _, cell := range row {
+			payload.Cells = append(payload.Cells, &internalmessages.PPMIncentiveMatrixCell{
+				MoveDate:            handlers.FmtDate(cell.MoveDate),
+				Weight:              swag.Int64(int64(cell.Weight)),
+				PeakRateCycle:       swag.Bool(cell.PeakRateCycle),
+				Gcc:                 swag.Int64(cell.GCC.Int64()),
+				IncentivePercentage: swag.Int64(cell.Incentive.Int64()),
+			})
+		}
+	}
+
+	return &payload
+}
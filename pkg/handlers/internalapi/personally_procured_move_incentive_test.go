@@ -1,13 +1,16 @@
 package internalapi
 
 import (
+	"math"
 	"net/http/httptest"
+	"time"
 
 	ppmop "github.com/transcom/mymove/pkg/gen/internalapi/internaloperations/ppm"
 	"github.com/transcom/mymove/pkg/handlers"
 	"github.com/transcom/mymove/pkg/route"
 	"github.com/transcom/mymove/pkg/testdatagen"
 	"github.com/transcom/mymove/pkg/testdatagen/scenario"
+	"github.com/transcom/mymove/pkg/unit"
 )
 
 func (suite *HandlerSuite) TestShowPPMIncentiveHandlerForbidden() {
@@ -93,3 +96,92 @@ func (suite *HandlerSuite) TestShowPPMIncentiveHandlerLowWeight() {
 	suite.Equal(int64(270252), *cost.Gcc, "Gcc was not equal")
 	suite.Equal(int64(256739), *cost.IncentivePercentage, "IncentivePercentage was not equal")
 }
+
+func (suite *HandlerSuite) TestShowPPMIncentiveMatrixHandler() {
+	if err := scenario.RunRateEngineScenario2(suite.DB()); err != nil {
+		suite.FailNow("failed to run scenario 2: %+v", err)
+	}
+
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+
+	req := httptest.NewRequest("GET", "/personally_procured_moves/incentive_matrix", nil)
+	req = suite.AuthenticateOfficeRequest(req, officeUser)
+
+	params := ppmop.ShowPPMIncentiveMatrixParams{
+		HTTPRequest:    req,
+		OriginZip:      "94540",
+		DestinationZip: "78626",
+		StartMoveDate:  *handlers.FmtDate(scenario.Oct1_2018),
+		EndMoveDate:    *handlers.FmtDate(scenario.Oct1_2018),
+		MinWeight:      7500,
+		MaxWeight:      7500,
+	}
+
+	context := handlers.NewHandlerContext(suite.DB(), suite.TestLogger())
+	context.SetPlanner(route.NewTestingPlanner(1693))
+	showHandler := ShowPPMIncentiveMatrixHandler{context}
+	showResponse := showHandler.Handle(params)
+
+	// The single cell matches the incentive for the same move date and weight
+	if suite.IsType(&ppmop.ShowPPMIncentiveMatrixOK{}, showResponse) {
+		matrix := showResponse.(*ppmop.ShowPPMIncentiveMatrixOK).Payload
+		suite.Len(matrix.MoveDates, 1)
+		suite.Equal([]int64{7500}, matrix.Weights)
+		if suite.Len(matrix.Cells, 1) {
+			suite.Equal(int64(637056), *matrix.Cells[0].Gcc, "Gcc was not equal")
+			suite.Equal(int64(605203), *matrix.Cells[0].IncentivePercentage, "IncentivePercentage was not equal")
+			suite.False(*matrix.Cells[0].PeakRateCycle)
+		}
+	}
+}
+
+func (suite *HandlerSuite) TestShowPPMIncentiveMatrixHandlerTooLarge() {
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+
+	req := httptest.NewRequest("GET", "/personally_procured_moves/incentive_matrix", nil)
+	req = suite.AuthenticateOfficeRequest(req, officeUser)
+
+	weightInterval := int64(1)
+	params := ppmop.ShowPPMIncentiveMatrixParams{
+		HTTPRequest:    req,
+		OriginZip:      "94540",
+		DestinationZip: "78626",
+		StartMoveDate:  *handlers.FmtDate(scenario.Oct1_2018),
+		EndMoveDate:    *handlers.FmtDate(scenario.Oct1_2018),
+		MinWeight:      1000,
+		MaxWeight:      9000,
+		WeightInterval: &weightInterval,
+	}
+
+	context := handlers.NewHandlerContext(suite.DB(), suite.TestLogger())
+	context.SetPlanner(route.NewTestingPlanner(1693))
+	showHandler := ShowPPMIncentiveMatrixHandler{context}
+	showResponse := showHandler.Handle(params)
+	suite.Assertions.IsType(&ppmop.ShowPPMIncentiveMatrixBadRequest{}, showResponse)
+
+	// Ranges too large to build, or to count in an int, are refused before anything is allocated
+	params.MaxWeight = math.MaxInt64
+	params.EndMoveDate = *handlers.FmtDate(time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC))
+	showResponse = showHandler.Handle(params)
+	suite.Assertions.IsType(&ppmop.ShowPPMIncentiveMatrixBadRequest{}, showResponse)
+}
+
+func (suite *HandlerSuite) TestPPMIncentiveWeightsNearMaxInt64() {
+	// Adding the interval to the last weight would overflow, which must not keep the loop going
+	min := int64(math.MaxInt64 - 1)
+	weightCount := ppmIncentiveWeightCount(min, math.MaxInt64, 500)
+	suite.Equal(int64(1), weightCount)
+
+	weights := ppmIncentiveWeights(unit.Pound(min), unit.Pound(500), weightCount)
+	suite.Equal([]unit.Pound{unit.Pound(min)}, weights)
+}
+
+func (suite *HandlerSuite) TestPPMIncentiveMoveDates() {
+	start := scenario.Oct1_2018
+	end := start.AddDate(0, 0, 20)
+	moveDateCount := ppmIncentiveMoveDateCount(start, end, 7)
+	suite.Equal(int64(3), moveDateCount)
+
+	moveDates := ppmIncentiveMoveDates(start, 7, moveDateCount)
+	suite.Equal([]time.Time{start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)}, moveDates)
+}
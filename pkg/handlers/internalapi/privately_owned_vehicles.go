@@ -0,0 +1,192 @@
+package internalapi
+
+import (
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/auth"
+	povop "github.com/transcom/mymove/pkg/gen/internalapi/internaloperations/pov"
+	"github.com/transcom/mymove/pkg/gen/internalmessages"
+	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
+)
+
+func payloadForVehicleProcessingCenterModel(center models.VehicleProcessingCenter) *internalmessages.VehicleProcessingCenter {
+	return &internalmessages.VehicleProcessingCenter{
+		ID:      handlers.FmtUUID(center.ID),
+		Name:    swag.String(center.Name),
+		Code:    swag.String(center.Code),
+		Address: payloadForAddressModel(&center.Address),
+	}
+}
+
+func payloadForPOVModel(pov models.PrivatelyOwnedVehicle) *internalmessages.PrivatelyOwnedVehicle {
+	return &internalmessages.PrivatelyOwnedVehicle{
+		ID:                   handlers.FmtUUID(pov.ID),
+		MoveID:               handlers.FmtUUID(pov.MoveID),
+		CreatedAt:            handlers.FmtDateTime(pov.CreatedAt),
+		UpdatedAt:            handlers.FmtDateTime(pov.UpdatedAt),
+		Status:               internalmessages.POVStatus(pov.Status),
+		Vin:                  swag.String(pov.VIN),
+		Make:                 swag.String(pov.Make),
+		Model:                swag.String(pov.Model),
+		Year:                 swag.Int64(int64(pov.Year)),
+		RequestedDropOffDate: handlers.FmtDatePtr(pov.RequestedDropOffDate),
+		DropOffVpcID:         handlers.FmtUUIDPtr(pov.DropOffVPCID),
+		PickupVpcID:          handlers.FmtUUIDPtr(pov.PickupVPCID),
+		ApproveDate:          handlers.FmtDateTimePtr(pov.ApproveDate),
+		ActualDropOffDate:    handlers.FmtDatePtr(pov.ActualDropOffDate),
+		ActualPickupDate:     handlers.FmtDatePtr(pov.ActualPickupDate),
+	}
+}
+
+// uuidFromPayload converts an optional UUID from a payload, which swagger has already pattern matched
+func uuidFromPayload(id *strfmt.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	converted := uuid.Must(uuid.FromString(id.String()))
+	return &converted
+}
+
+// IndexVehicleProcessingCentersHandler returns the vehicle processing centers a vehicle can be shipped through
+type IndexVehicleProcessingCentersHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle returns all of the vehicle processing centers
+func (h IndexVehicleProcessingCentersHandler) Handle(params povop.IndexVehicleProcessingCentersParams) middleware.Responder {
+	centers, err := models.FetchVehicleProcessingCenters(h.DB())
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	centersPayload := make(internalmessages.VehicleProcessingCenters, len(centers))
+	for i, center := range centers {
+		centersPayload[i] = payloadForVehicleProcessingCenterModel(center)
+	}
+	return povop.NewIndexVehicleProcessingCentersOK().WithPayload(centersPayload)
+}
+
+// CreatePrivatelyOwnedVehicleHandler creates a POV shipment
+type CreatePrivatelyOwnedVehicleHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle is the handler
+func (h CreatePrivatelyOwnedVehicleHandler) Handle(params povop.CreatePrivatelyOwnedVehicleParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	// #nosec UUID is pattern matched by swagger and will be ok
+	moveID, _ := uuid.FromString(params.MoveID.String())
+
+	// Validate that this move belongs to the current user
+	move, err := models.FetchMove(h.DB(), session, moveID)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	payload := params.CreatePrivatelyOwnedVehiclePayload
+
+	newPOV, verrs, err := move.CreatePOV(h.DB(),
+		*payload.Vin,
+		*payload.Make,
+		*payload.Model,
+		int(*payload.Year),
+		(*time.Time)(payload.RequestedDropOffDate),
+		uuidFromPayload(payload.DropOffVpcID),
+		uuidFromPayload(payload.PickupVpcID))
+
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return povop.NewCreatePrivatelyOwnedVehicleCreated().WithPayload(payloadForPOVModel(*newPOV))
+}
+
+// IndexPrivatelyOwnedVehiclesHandler returns a list of all the POV shipments associated with this move.
+type IndexPrivatelyOwnedVehiclesHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle handles the request
+func (h IndexPrivatelyOwnedVehiclesHandler) Handle(params povop.IndexPrivatelyOwnedVehiclesParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	// #nosec UUID is pattern matched by swagger and will be ok
+	moveID, _ := uuid.FromString(params.MoveID.String())
+
+	// Validate that this move belongs to the current user
+	move, err := models.FetchMove(h.DB(), session, moveID)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	povsPayload := make(internalmessages.IndexPrivatelyOwnedVehiclesPayload, len(move.PrivatelyOwnedVehicles))
+	for i, pov := range move.PrivatelyOwnedVehicles {
+		povsPayload[i] = payloadForPOVModel(pov)
+	}
+	return povop.NewIndexPrivatelyOwnedVehiclesOK().WithPayload(povsPayload)
+}
+
+func patchPOVWithPayload(pov *models.PrivatelyOwnedVehicle, payload *internalmessages.PatchPrivatelyOwnedVehiclePayload) {
+	if payload.Vin != nil {
+		pov.VIN = *payload.Vin
+	}
+	if payload.Make != nil {
+		pov.Make = *payload.Make
+	}
+	if payload.Model != nil {
+		pov.Model = *payload.Model
+	}
+	if payload.Year != nil {
+		pov.Year = int(*payload.Year)
+	}
+	if payload.RequestedDropOffDate != nil {
+		pov.RequestedDropOffDate = (*time.Time)(payload.RequestedDropOffDate)
+	}
+	if payload.DropOffVpcID != nil {
+		pov.DropOffVPCID = uuidFromPayload(payload.DropOffVpcID)
+	}
+	if payload.PickupVpcID != nil {
+		pov.PickupVPCID = uuidFromPayload(payload.PickupVpcID)
+	}
+}
+
+// PatchPrivatelyOwnedVehicleHandler updates a POV shipment
+type PatchPrivatelyOwnedVehicleHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle is the handler
+func (h PatchPrivatelyOwnedVehicleHandler) Handle(params povop.PatchPrivatelyOwnedVehicleParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	// #nosec UUID is pattern matched by swagger and will be ok
+	moveID, _ := uuid.FromString(params.MoveID.String())
+	// #nosec UUID is pattern matched by swagger and will be ok
+	povID, _ := uuid.FromString(params.PrivatelyOwnedVehicleID.String())
+
+	pov, err := models.FetchPrivatelyOwnedVehicle(h.DB(), session, povID)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	if pov.MoveID != moveID {
+		h.Logger().Info("Move ID for POV does not match requested POV Move ID",
+			zap.String("requested move_id", moveID.String()),
+			zap.String("actual move_id", pov.MoveID.String()))
+		return povop.NewPatchPrivatelyOwnedVehicleBadRequest()
+	}
+
+	patchPOVWithPayload(pov, params.PatchPrivatelyOwnedVehiclePayload)
+
+	verrs, err := h.DB().ValidateAndUpdate(pov)
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return povop.NewPatchPrivatelyOwnedVehicleOK().WithPayload(payloadForPOVModel(*pov))
+}
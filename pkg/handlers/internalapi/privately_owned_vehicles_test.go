@@ -0,0 +1,93 @@
+package internalapi
+
+import (
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	officeop "github.com/transcom/mymove/pkg/gen/internalapi/internaloperations/office"
+	povop "github.com/transcom/mymove/pkg/gen/internalapi/internaloperations/pov"
+	"github.com/transcom/mymove/pkg/gen/internalmessages"
+	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *HandlerSuite) TestCreatePrivatelyOwnedVehicleHandlerRequiresAuthorizingOrders() {
+	move := testdatagen.MakeDefaultMove(suite.DB())
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/moves/%s/privately_owned_vehicles", move.ID), nil)
+	req = suite.AuthenticateRequest(req, move.Orders.ServiceMember)
+
+	params := povop.CreatePrivatelyOwnedVehicleParams{
+		HTTPRequest: req,
+		MoveID:      strfmt.UUID(move.ID.String()),
+		CreatePrivatelyOwnedVehiclePayload: &internalmessages.CreatePrivatelyOwnedVehiclePayload{
+			Vin:   swag.String("1HGCM82633A004352"),
+			Make:  swag.String("Honda"),
+			Model: swag.String("Accord"),
+			Year:  swag.Int64(2003),
+		},
+	}
+
+	handler := CreatePrivatelyOwnedVehicleHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	// The default orders are for a move within the continental US, which doesn't authorize shipping a vehicle
+	suite.Assertions.IsType(&handlers.ValidationErrorsResponse{}, response)
+}
+
+func (suite *HandlerSuite) TestApproveMoveHandlerApprovesPOV() {
+	hhgPermitted := internalmessages.OrdersTypeDetailHHGPERMITTED
+	pov := testdatagen.MakePrivatelyOwnedVehicle(suite.DB(), testdatagen.Assertions{
+		Order: models.Order{
+			OrdersNumber:        handlers.FmtString("1234"),
+			OrdersTypeDetail:    &hhgPermitted,
+			TAC:                 handlers.FmtString("1234"),
+			DepartmentIndicator: handlers.FmtString("17 - United States Marines"),
+		},
+	})
+	move := pov.Move
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+
+	suite.NoError(move.Submit())
+	verrs, err := models.SaveMoveDependencies(suite.DB(), &move)
+	suite.NoError(err)
+	suite.NoVerrs(verrs)
+
+	req := httptest.NewRequest("POST", "/moves/some_id/approve", nil)
+	req = suite.AuthenticateOfficeRequest(req, officeUser)
+	params := officeop.ApproveMoveParams{
+		HTTPRequest: req,
+		MoveID:      strfmt.UUID(move.ID.String()),
+	}
+
+	context := handlers.NewHandlerContext(suite.DB(), suite.TestLogger())
+	context.SetNotificationSender(suite.TestNotificationSender())
+	handler := ApproveMoveHandler{context}
+	response := handler.Handle(params)
+
+	suite.Assertions.IsType(&officeop.ApproveMoveOK{}, response)
+	okResponse := response.(*officeop.ApproveMoveOK)
+	suite.Equal(1, len(okResponse.Payload.PrivatelyOwnedVehicles))
+	suite.Equal(internalmessages.POVStatusAPPROVED, okResponse.Payload.PrivatelyOwnedVehicles[0].Status)
+
+	// Then the office can track the vehicle through the processing centers
+	center := testdatagen.MakeVehicleProcessingCenter(suite.DB(), testdatagen.Assertions{})
+	dropOffParams := officeop.RecordPOVDropOffParams{
+		HTTPRequest:             req,
+		PrivatelyOwnedVehicleID: strfmt.UUID(pov.ID.String()),
+		RecordPOVDropOffPayload: &internalmessages.RecordPOVDropOffPayload{
+			ActualDropOffDate: handlers.FmtDate(testdatagen.DateInsidePeakRateCycle),
+			DropOffVpcID:      handlers.FmtUUID(center.ID),
+		},
+	}
+	dropOffResponse := RecordPOVDropOffHandler{context}.Handle(dropOffParams)
+
+	suite.Assertions.IsType(&officeop.RecordPOVDropOffOK{}, dropOffResponse)
+	dropOffPayload := dropOffResponse.(*officeop.RecordPOVDropOffOK).Payload
+	suite.Equal(internalmessages.POVStatusDROPPEDOFF, dropOffPayload.Status)
+	suite.Equal(center.ID.String(), dropOffPayload.DropOffVpcID.String())
+}
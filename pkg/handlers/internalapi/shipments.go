@@ -30,6 +30,7 @@ func payloadForInvoiceModel(a *models.Invoice) *internalmessages.Invoice {
 		ApproverLastName:  a.Approver.LastName,
 		Status:            internalmessages.InvoiceStatus(a.Status),
 		InvoicedDate:      *handlers.FmtDateTime(a.InvoicedDate),
+		RejectionNotes:    a.RejectionNotes,
 	}
 }
 
@@ -67,6 +68,7 @@ func payloadForShipmentModel(s models.Shipment) (*internalmessages.Shipment, err
 	shipmentPayload := &internalmessages.Shipment{
 		ID:               strfmt.UUID(s.ID.String()),
 		Status:           internalmessages.ShipmentStatus(s.Status),
+		ShipmentType:     internalmessages.ShipmentType(s.ShipmentType),
 		SourceGbloc:      payloadForGBLOC(s.SourceGBLOC),
 		DestinationGbloc: payloadForGBLOC(s.DestinationGBLOC),
 		Market:           payloadForMarkets(s.Market),
@@ -155,7 +157,22 @@ func (h CreateShipmentHandler) Handle(params shipmentop.CreateShipmentParams) mi
 	secondaryPickupAddress := addressModelFromPayload(payload.SecondaryPickupAddress)
 	deliveryAddress := addressModelFromPayload(payload.DeliveryAddress)
 	partialSITDeliveryAddress := addressModelFromPayload(payload.PartialSitDeliveryAddress)
-	market := "dHHG"
+
+	shipmentType := models.ShipmentTypeHHG
+	if payload.ShipmentType != "" {
+		shipmentType = models.ShipmentType(payload.ShipmentType)
+	}
+
+	// Shipments to or from anywhere outside the continental US are priced in the international market
+	destinationAddress := deliveryAddress
+	if destinationAddress == nil {
+		newDutyStation, err := models.FetchDutyStation(params.HTTPRequest.Context(), h.DB(), move.Orders.NewDutyStationID)
+		if err != nil {
+			return handlers.ResponseForError(h.Logger(), err)
+		}
+		destinationAddress = &newDutyStation.Address
+	}
+	market := models.ShipmentMarketForAddresses(shipmentType, pickupAddress, destinationAddress)
 
 	var requestedPickupDate *time.Time
 	if payload.RequestedPickupDate != nil {
@@ -182,6 +199,7 @@ func (h CreateShipmentHandler) Handle(params shipmentop.CreateShipmentParams) mi
 		MoveID:                       move.ID,
 		ServiceMemberID:              session.ServiceMemberID,
 		Status:                       models.ShipmentStatusDRAFT,
+		ShipmentType:                 shipmentType,
 		RequestedPickupDate:          requestedPickupDate,
 		EstimatedPackDays:            payload.EstimatedPackDays,
 		EstimatedTransitDays:         payload.EstimatedTransitDays,
@@ -289,6 +307,11 @@ func patchShipmentWithPayload(shipment *models.Shipment, payload *internalmessag
 		shipment.HasDeliveryAddress = *payload.HasDeliveryAddress
 	}
 
+	// Moving either end of the shipment can move it in or out of the international market
+	if payload.PickupAddress != nil || payload.HasDeliveryAddress != nil {
+		shipment.UpdateMarket()
+	}
+
 	if payload.HasPartialSitDeliveryAddress != nil {
 		if *payload.HasPartialSitDeliveryAddress == false {
 			shipment.PartialSITDeliveryAddress = nil
@@ -505,13 +528,14 @@ func (h ShipmentInvoiceHandler) Handle(params shipmentop.CreateAndSendHHGInvoice
 
 	//for now we limit a shipment to 1 invoice
 	//if invoices exists and at least one is either in process or has succeeded then return 409
+	//invoices that failed to be submitted or were rejected by Syncada can be resubmitted
 	existingInvoices, err := models.FetchInvoicesForShipment(h.DB(), shipmentID)
 	if err != nil {
 		return handlers.ResponseForError(h.Logger(), err)
 	}
 	for _, invoice := range existingInvoices {
 		//if an invoice has started, is in process or has been submitted successfully then throw err
-		if invoice.Status != models.InvoiceStatusSUBMISSIONFAILURE {
+		if invoice.Status != models.InvoiceStatusSUBMISSIONFAILURE && invoice.Status != models.InvoiceStatusREJECTED {
 			payload := payloadForInvoiceModel(&invoice)
 			return shipmentop.NewCreateAndSendHHGInvoiceConflict().WithPayload(payload)
 		}
@@ -373,6 +373,51 @@ func (suite *HandlerSuite) TestPatchShipmentsHandlerHappyPath() {
 	suite.Equal(*patchShipmentPayload.SpouseProgearWeightEstimate, int64(100), "SpouseProgearWeightEstimate should have been set to 100")
 }
 
+func (suite *HandlerSuite) TestPatchShipmentHandlerUpdatesMarket() {
+	move := testdatagen.MakeMove(suite.DB(), testdatagen.Assertions{})
+	sm := move.Orders.ServiceMember
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			Move:            move,
+			MoveID:          move.ID,
+			ServiceMember:   sm,
+			ServiceMemberID: sm.ID,
+			Status:          models.ShipmentStatusDRAFT,
+		},
+	})
+	suite.Equal(models.ShipmentMarketDHHG, *shipment.Market)
+
+	req := httptest.NewRequest("PATCH", "/shipments/shipment_id", nil)
+	req = suite.AuthenticateRequest(req, sm)
+	handler := PatchShipmentHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+
+	// A delivery address in Alaska makes the shipment international
+	alaskaAddress := otherFakeAddressPayload()
+	alaskaAddress.State = swag.String("AK")
+	alaskaAddress.PostalCode = swag.String("99501")
+	response := handler.Handle(shipmentop.PatchShipmentParams{
+		HTTPRequest: req,
+		ShipmentID:  strfmt.UUID(shipment.ID.String()),
+		Shipment: &internalmessages.Shipment{
+			HasDeliveryAddress: handlers.FmtBool(true),
+			DeliveryAddress:    alaskaAddress,
+		},
+	})
+	suite.IsType(&shipmentop.PatchShipmentOK{}, response)
+	suite.Equal(internalmessages.ShipmentMarketIHHG, *response.(*shipmentop.PatchShipmentOK).Payload.Market)
+
+	// Without a delivery address the shipment goes to the new duty station, which is in the continental US
+	response = handler.Handle(shipmentop.PatchShipmentParams{
+		HTTPRequest: req,
+		ShipmentID:  strfmt.UUID(shipment.ID.String()),
+		Shipment: &internalmessages.Shipment{
+			HasDeliveryAddress: handlers.FmtBool(false),
+		},
+	})
+	suite.IsType(&shipmentop.PatchShipmentOK{}, response)
+	suite.Equal(internalmessages.ShipmentMarketDHHG, *response.(*shipmentop.PatchShipmentOK).Payload.Market)
+}
+
 func (suite *HandlerSuite) TestSetShipmentDates() {
 	move := testdatagen.MakeMove(suite.DB(), testdatagen.Assertions{
 		Order: models.Order{
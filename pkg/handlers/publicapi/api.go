@@ -39,6 +39,7 @@ func NewPublicAPIHandler(context handlers.HandlerContext) http.Handler {
 	publicAPI.ShipmentsGetShipmentHandler = GetShipmentHandler{context}
 	publicAPI.ShipmentsPatchShipmentHandler = PatchShipmentHandler{context}
 	publicAPI.ShipmentsAcceptShipmentHandler = AcceptShipmentHandler{context}
+	publicAPI.ShipmentsRejectShipmentHandler = RejectShipmentHandler{context}
 	publicAPI.ShipmentsTransportShipmentHandler = TransportShipmentHandler{context}
 	publicAPI.ShipmentsDeliverShipmentHandler = DeliverShipmentHandler{context}
 	publicAPI.ShipmentsGetShipmentInvoicesHandler = GetShipmentInvoicesHandler{context}
@@ -65,6 +66,9 @@ func NewPublicAPIHandler(context handlers.HandlerContext) http.Handler {
 	publicAPI.TransportationServiceProviderGetTransportationServiceProviderHandler = GetTransportationServiceProviderHandler{context}
 	publicAPI.TspsIndexTSPsHandler = TspsIndexTSPsHandler{context}
 	publicAPI.TspsGetTspShipmentsHandler = TspsGetTspShipmentsHandler{context}
+	publicAPI.TspsCreateTspBlackoutHandler = TspsCreateTspBlackoutHandler{context}
+	publicAPI.TspsImportTspBlackoutsHandler = TspsImportTspBlackoutsHandler{context}
+	publicAPI.TspsPreviewTspBlackoutImpactHandler = TspsPreviewTspBlackoutImpactHandler{context}
 
 	// Storage In Transits
 	publicAPI.StorageInTransitsCreateStorageInTransitHandler = CreateStorageInTransitHandler{context}
@@ -73,5 +77,11 @@ func NewPublicAPIHandler(context handlers.HandlerContext) http.Handler {
 	publicAPI.StorageInTransitsDeleteStorageInTransitHandler = DeleteStorageInTransitHandler{context}
 	publicAPI.StorageInTransitsPatchStorageInTransitHandler = PatchStorageInTransitHandler{context}
 
+	// Non-Temporary Storage
+	publicAPI.NonTemporaryStorageGetNonTemporaryStorageHandler = GetNonTemporaryStorageHandler{context}
+	publicAPI.NonTemporaryStorageRecordNonTemporaryStorageHandler = RecordNonTemporaryStorageHandler{context}
+	publicAPI.NonTemporaryStorageRequestNonTemporaryStorageReleaseHandler = RequestNonTemporaryStorageReleaseHandler{context}
+	publicAPI.NonTemporaryStorageReleaseNonTemporaryStorageHandler = ReleaseNonTemporaryStorageHandler{context}
+
 	return publicAPI.Serve(nil)
 }
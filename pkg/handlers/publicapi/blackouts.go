@@ -1,10 +1,23 @@
 package publicapi
 
 import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/gobuffalo/validate"
+	"github.com/gofrs/uuid"
 
+	"github.com/transcom/mymove/pkg/gen/apimessages"
 	blackoutsop "github.com/transcom/mymove/pkg/gen/restapi/apioperations/blackouts"
 	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
 )
 
 // BlackoutIndexHandler returns a list of all the Blackouts
@@ -56,3 +69,154 @@ type PatchBlackoutHandler struct {
 func (h PatchBlackoutHandler) Handle(params blackoutsop.PatchBlackoutParams) middleware.Responder {
 	return middleware.NotImplemented("operation .patchBlackout has not yet been implemented")
 }
+
+func payloadForBlackoutDateModel(b models.BlackoutDate) *apimessages.BlackoutPayload {
+	// The payload's end date is the first day after the blackout ends
+	payload := &apimessages.BlackoutPayload{
+		ID:        *handlers.FmtUUID(b.ID),
+		TspID:     *handlers.FmtUUID(b.TransportationServiceProviderID),
+		StartDate: handlers.FmtDate(b.StartBlackoutDate),
+		EndDate:   handlers.FmtDate(b.EndBlackoutDate.AddDate(0, 0, 1)),
+		Gbloc:     payloadForGBLOC(b.SourceGBLOC),
+		Market:    payloadForMarkets(b.Market),
+	}
+	if b.Zip3 != nil {
+		payload.Zip3 = fmt.Sprintf("%03d", *b.Zip3)
+	}
+	return payload
+}
+
+// blackoutDateFromPayload makes a blackout date for the TSP from a create payload. Channel and code of service
+// restrictions aren't supported, as the award queue matches blackouts on GBLOC and market.
+func blackoutDateFromPayload(tspID uuid.UUID, payload apimessages.CreateBlackoutPayload) (models.BlackoutDate, *validate.Errors) {
+	verrs := validate.NewErrors()
+	blackout := models.BlackoutDate{TransportationServiceProviderID: tspID}
+
+	if payload.StartDate == nil {
+		verrs.Add("start_date", "start_date is required.")
+	} else {
+		blackout.StartBlackoutDate = time.Time(*payload.StartDate)
+	}
+	if payload.EndDate == nil {
+		verrs.Add("end_date", "end_date is required.")
+	} else {
+		blackout.EndBlackoutDate = time.Time(*payload.EndDate).AddDate(0, 0, -1)
+		if payload.StartDate != nil && !time.Time(*payload.EndDate).After(blackout.StartBlackoutDate) {
+			verrs.Add("end_date", "end_date must be after start_date.")
+		}
+	}
+	if payload.Gbloc != nil {
+		blackout.SourceGBLOC = swag.String(string(*payload.Gbloc))
+	}
+	if payload.Market != nil {
+		blackout.Market = swag.String(string(*payload.Market))
+	}
+	if payload.Zip3 != "" {
+		zip3, err := strconv.Atoi(payload.Zip3)
+		if err != nil || len(payload.Zip3) != 3 {
+			verrs.Add("zip3", "zip3 must be three digits.")
+		} else {
+			blackout.Zip3 = &zip3
+		}
+	}
+	if payload.Channel != nil || payload.CodeOfService != "" {
+		verrs.Add("channel", "Blackouts can't be restricted by channel or code of service.")
+	}
+
+	return blackout, verrs
+}
+
+const blackoutCSVDateFormat = "2006-01-02"
+
+// blackoutCSVColumns are the columns a blackout import file can have, named in its header row
+var blackoutCSVColumns = map[string]bool{
+	"start_date": true,
+	"end_date":   true,
+	"gbloc":      true,
+	"market":     true,
+	"zip3":       true,
+}
+
+// parseBlackoutCSV reads create payloads from a CSV file of blackouts. The first row is a header naming the
+// columns, and dates are formatted as YYYY-MM-DD. Errors are keyed by the row they were found on.
+func parseBlackoutCSV(r io.Reader) ([]apimessages.CreateBlackoutPayload, *validate.Errors) {
+	verrs := validate.NewErrors()
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		verrs.Add("file", "The file is empty.")
+		return nil, verrs
+	}
+	if err != nil {
+		verrs.Add("row 1", err.Error())
+		return nil, verrs
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !blackoutCSVColumns[name] {
+			verrs.Add("row 1", fmt.Sprintf("Unknown column %q.", name))
+			continue
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"start_date", "end_date"} {
+		if _, ok := columns[required]; !ok {
+			verrs.Add("row 1", fmt.Sprintf("Missing column %q.", required))
+		}
+	}
+	if verrs.HasAny() {
+		return nil, verrs
+	}
+
+	var payloads []apimessages.CreateBlackoutPayload
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		key := fmt.Sprintf("row %d", row)
+		if err != nil {
+			verrs.Add(key, err.Error())
+			continue
+		}
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		var payload apimessages.CreateBlackoutPayload
+		start, startErr := time.Parse(blackoutCSVDateFormat, value("start_date"))
+		end, endErr := time.Parse(blackoutCSVDateFormat, value("end_date"))
+		if startErr != nil || endErr != nil {
+			verrs.Add(key, "start_date and end_date must be dates formatted as YYYY-MM-DD.")
+			continue
+		}
+		payload.StartDate = handlers.FmtDate(start)
+		payload.EndDate = handlers.FmtDate(end)
+		if gbloc := value("gbloc"); gbloc != "" {
+			payload.Gbloc = payloadForGBLOC(&gbloc)
+		}
+		if market := value("market"); market != "" {
+			payload.Market = payloadForMarkets(&market)
+		}
+		payload.Zip3 = value("zip3")
+
+		// Check the GBLOC, market and zip3 formats as the API does for a single blackout
+		if err := payload.Validate(strfmt.Default); err != nil {
+			verrs.Add(key, err.Error())
+			continue
+		}
+		payloads = append(payloads, payload)
+	}
+	if len(payloads) == 0 && !verrs.HasAny() {
+		verrs.Add("file", "The file has no blackouts.")
+	}
+
+	return payloads, verrs
+}
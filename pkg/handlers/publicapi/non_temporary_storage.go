@@ -0,0 +1,219 @@
+package publicapi
+
+import (
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/auth"
+	"github.com/transcom/mymove/pkg/gen/apimessages"
+	ntsop "github.com/transcom/mymove/pkg/gen/restapi/apioperations/non_temporary_storage"
+	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/rateengine"
+	shipmentservice "github.com/transcom/mymove/pkg/services/shipment"
+)
+
+func payloadForNonTemporaryStorageModel(n *models.NonTemporaryStorage) *apimessages.NonTemporaryStorage {
+	if n == nil {
+		return nil
+	}
+
+	return &apimessages.NonTemporaryStorage{
+		ID:                   *handlers.FmtUUID(n.ID),
+		ShipmentID:           *handlers.FmtUUID(n.ShipmentID),
+		Status:               string(n.Status),
+		StorageStartDate:     handlers.FmtDate(n.StorageStartDate),
+		RequestedReleaseDate: handlers.FmtDatePtr(n.RequestedReleaseDate),
+		ActualReleaseDate:    handlers.FmtDatePtr(n.ActualReleaseDate),
+		ReleaseAddress:       payloadForAddressModel(n.ReleaseAddress),
+		StorageFeeCents:      handlers.FmtCost(n.StorageFeeCents),
+		Notes:                handlers.FmtStringPtr(n.Notes),
+		WarehouseAddress:     payloadForAddressModel(&n.WarehouseAddress),
+		WarehouseEmail:       handlers.FmtStringPtr(n.WarehouseEmail),
+		WarehouseID:          handlers.FmtString(n.WarehouseID),
+		WarehouseName:        handlers.FmtString(n.WarehouseName),
+		WarehousePhone:       handlers.FmtStringPtr(n.WarehousePhone),
+	}
+}
+
+func patchNonTemporaryStorageWithPayload(nts *models.NonTemporaryStorage, payload *apimessages.NonTemporaryStorage) {
+	if payload.StorageStartDate != nil {
+		nts.StorageStartDate = time.Time(*payload.StorageStartDate)
+	}
+	if payload.WarehouseID != nil {
+		nts.WarehouseID = *payload.WarehouseID
+	}
+	if payload.WarehouseName != nil {
+		nts.WarehouseName = *payload.WarehouseName
+	}
+	if payload.WarehouseAddress != nil {
+		updateAddressWithPayload(&nts.WarehouseAddress, payload.WarehouseAddress)
+	}
+	nts.Notes = payload.Notes
+	nts.WarehousePhone = payload.WarehousePhone
+	nts.WarehouseEmail = payload.WarehouseEmail
+}
+
+// GetNonTemporaryStorageHandler gets the storage details of an NTS shipment
+type GetNonTemporaryStorageHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle returns the NTS record for a shipment to its TSP or an office user
+func (h GetNonTemporaryStorageHandler) Handle(params ntsop.GetNonTemporaryStorageParams) middleware.Responder {
+	shipmentID, _ := uuid.FromString(params.ShipmentID.String())
+
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	isUserAuthorized, err := authorizeStorageInTransitRequest(h.DB(), session, shipmentID, true)
+	if isUserAuthorized == false {
+		h.Logger().Error("User is unauthorized", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	nts, err := models.FetchNonTemporaryStorageForShipment(h.DB(), shipmentID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	return ntsop.NewGetNonTemporaryStorageOK().WithPayload(payloadForNonTemporaryStorageModel(nts))
+}
+
+// RecordNonTemporaryStorageHandler records the storage facility holding an NTS shipment
+type RecordNonTemporaryStorageHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle creates the NTS record for a shipment the first time it is called, and updates the facility details after that
+func (h RecordNonTemporaryStorageHandler) Handle(params ntsop.RecordNonTemporaryStorageParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	if !session.IsTspUser() {
+		h.Logger().Error("Only TSP users may record non-temporary storage")
+		return ntsop.NewRecordNonTemporaryStorageForbidden()
+	}
+
+	shipmentID, _ := uuid.FromString(params.ShipmentID.String())
+	_, shipment, err := models.FetchShipmentForVerifiedTSPUser(h.DB(), session.TspUserID, shipmentID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	if shipment.ShipmentType != models.ShipmentTypeNTS {
+		h.Logger().Error("Shipment is not an NTS shipment", zap.String("shipment_type", string(shipment.ShipmentType)))
+		return ntsop.NewRecordNonTemporaryStorageBadRequest()
+	}
+
+	nts, err := models.FetchNonTemporaryStorageForShipment(h.DB(), shipmentID)
+	if err == models.ErrFetchNotFound {
+		nts = &models.NonTemporaryStorage{
+			ShipmentID: shipmentID,
+			Status:     models.NonTemporaryStorageStatusINSTORAGE,
+		}
+	} else if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	patchNonTemporaryStorageWithPayload(nts, params.NonTemporaryStorage)
+
+	verrs, err := models.SaveNonTemporaryStorageAndAddresses(h.DB(), nts)
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return ntsop.NewRecordNonTemporaryStorageOK().WithPayload(payloadForNonTemporaryStorageModel(nts))
+}
+
+// RequestNonTemporaryStorageReleaseHandler requests the release of an NTS shipment's goods from storage
+type RequestNonTemporaryStorageReleaseHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle records the requested release date and address for an NTS shipment
+func (h RequestNonTemporaryStorageReleaseHandler) Handle(params ntsop.RequestNonTemporaryStorageReleaseParams) middleware.Responder {
+	shipmentID, _ := uuid.FromString(params.ShipmentID.String())
+
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	isUserAuthorized, err := authorizeStorageInTransitRequest(h.DB(), session, shipmentID, true)
+	if isUserAuthorized == false {
+		h.Logger().Error("User is unauthorized", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	nts, err := models.FetchNonTemporaryStorageForShipment(h.DB(), shipmentID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	payload := params.ReleaseRequest
+	releaseAddress := nts.ReleaseAddress
+	if releaseAddress == nil {
+		releaseAddress = addressModelFromPayload(payload.ReleaseAddress)
+	} else {
+		updateAddressWithPayload(releaseAddress, payload.ReleaseAddress)
+	}
+
+	err = nts.RequestRelease(time.Time(*payload.RequestedReleaseDate), releaseAddress)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	verrs, err := models.SaveNonTemporaryStorageAndAddresses(h.DB(), nts)
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return ntsop.NewRequestNonTemporaryStorageReleaseOK().WithPayload(payloadForNonTemporaryStorageModel(nts))
+}
+
+// ReleaseNonTemporaryStorageHandler records an NTS shipment's goods leaving storage
+type ReleaseNonTemporaryStorageHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle releases the goods and prices the storage period - checks that the logged in user is the shipment's TSP
+func (h ReleaseNonTemporaryStorageHandler) Handle(params ntsop.ReleaseNonTemporaryStorageParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	if !session.IsTspUser() {
+		h.Logger().Error("Only TSP users may release non-temporary storage")
+		return ntsop.NewReleaseNonTemporaryStorageForbidden()
+	}
+
+	shipmentID, _ := uuid.FromString(params.ShipmentID.String())
+
+	tspUser, err := models.FetchTspUserByID(h.DB(), session.TspUserID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return ntsop.NewReleaseNonTemporaryStorageForbidden()
+	}
+
+	shipment, err := models.FetchShipmentByTSP(h.DB(), tspUser.TransportationServiceProviderID, shipmentID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return ntsop.NewReleaseNonTemporaryStorageBadRequest()
+	}
+
+	nts, err := models.FetchNonTemporaryStorageForShipment(h.DB(), shipmentID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	actualReleaseDate := time.Time(*params.Release.ActualReleaseDate)
+	engine := rateengine.NewRateEngine(h.DB(), h.Logger()).WithRateFetcher(h.RateFetcher())
+
+	verrs, err := shipmentservice.ReleaseNTSShipment{
+		DB:     h.DB(),
+		Engine: engine,
+	}.Call(actualReleaseDate, shipment, nts)
+	if err != nil || verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return ntsop.NewReleaseNonTemporaryStorageOK().WithPayload(payloadForNonTemporaryStorageModel(nts))
+}
@@ -0,0 +1,113 @@
+package publicapi
+
+import (
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/transcom/mymove/pkg/gen/apimessages"
+	ntsop "github.com/transcom/mymove/pkg/gen/restapi/apioperations/non_temporary_storage"
+	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *HandlerSuite) TestRecordNonTemporaryStorageHandler() {
+	tspUsers, shipments, _, err := testdatagen.CreateShipmentOfferData(suite.DB(), 1, 1, []int{1}, []models.ShipmentStatus{models.ShipmentStatusACCEPTED}, models.SelectedMoveTypeNTS)
+	suite.NoError(err)
+	tspUser := tspUsers[0]
+	shipment := shipments[0]
+
+	path := fmt.Sprintf("/shipments/%s/non_temporary_storage", shipment.ID.String())
+	req := httptest.NewRequest("PUT", path, nil)
+	req = suite.AuthenticateTspRequest(req, tspUser)
+
+	warehouseAddress := testdatagen.MakeDefaultAddress(suite.DB())
+	payload := apimessages.NonTemporaryStorage{
+		StorageStartDate: handlers.FmtDate(testdatagen.DateInsidePeakRateCycle),
+		WarehouseID:      swag.String("000383"),
+		WarehouseName:    swag.String("ABC Warehouse, Inc."),
+		WarehouseAddress: payloadForAddressModel(&warehouseAddress),
+		WarehousePhone:   swag.String("212-555-5555"),
+	}
+	params := ntsop.RecordNonTemporaryStorageParams{
+		HTTPRequest:         req,
+		ShipmentID:          strfmt.UUID(shipment.ID.String()),
+		NonTemporaryStorage: &payload,
+	}
+
+	handler := RecordNonTemporaryStorageHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	suite.Assertions.IsType(&ntsop.RecordNonTemporaryStorageOK{}, response)
+	okResponse := response.(*ntsop.RecordNonTemporaryStorageOK)
+	suite.Equal(string(models.NonTemporaryStorageStatusINSTORAGE), okResponse.Payload.Status)
+	suite.Equal("000383", *okResponse.Payload.WarehouseID)
+	suite.Equal(payload.StorageStartDate.String(), okResponse.Payload.StorageStartDate.String())
+
+	// Recording again updates the same record rather than creating another
+	payload.WarehouseName = swag.String("XYZ Warehouse, Inc.")
+	response = handler.Handle(params)
+	suite.Assertions.IsType(&ntsop.RecordNonTemporaryStorageOK{}, response)
+
+	nts, err := models.FetchNonTemporaryStorageForShipment(suite.DB(), shipment.ID)
+	suite.NoError(err)
+	suite.Equal("XYZ Warehouse, Inc.", nts.WarehouseName)
+}
+
+func (suite *HandlerSuite) TestRecordNonTemporaryStorageHandlerRejectsHHGShipment() {
+	tspUsers, shipments, _, err := testdatagen.CreateShipmentOfferData(suite.DB(), 1, 1, []int{1}, []models.ShipmentStatus{models.ShipmentStatusACCEPTED}, models.SelectedMoveTypeHHG)
+	suite.NoError(err)
+
+	path := fmt.Sprintf("/shipments/%s/non_temporary_storage", shipments[0].ID.String())
+	req := httptest.NewRequest("PUT", path, nil)
+	req = suite.AuthenticateTspRequest(req, tspUsers[0])
+
+	warehouseAddress := testdatagen.MakeDefaultAddress(suite.DB())
+	params := ntsop.RecordNonTemporaryStorageParams{
+		HTTPRequest: req,
+		ShipmentID:  strfmt.UUID(shipments[0].ID.String()),
+		NonTemporaryStorage: &apimessages.NonTemporaryStorage{
+			StorageStartDate: handlers.FmtDate(testdatagen.DateInsidePeakRateCycle),
+			WarehouseID:      swag.String("000383"),
+			WarehouseName:    swag.String("ABC Warehouse, Inc."),
+			WarehouseAddress: payloadForAddressModel(&warehouseAddress),
+		},
+	}
+
+	handler := RecordNonTemporaryStorageHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	suite.Assertions.IsType(&ntsop.RecordNonTemporaryStorageBadRequest{}, response)
+}
+
+func (suite *HandlerSuite) TestRequestNonTemporaryStorageReleaseHandler() {
+	nts := testdatagen.MakeDefaultNonTemporaryStorage(suite.DB())
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+
+	path := fmt.Sprintf("/shipments/%s/non_temporary_storage/request_release", nts.ShipmentID.String())
+	req := httptest.NewRequest("POST", path, nil)
+	req = suite.AuthenticateOfficeRequest(req, officeUser)
+
+	releaseAddress := testdatagen.MakeDefaultAddress(suite.DB())
+	requestedDate := nts.StorageStartDate.AddDate(0, 3, 0)
+	params := ntsop.RequestNonTemporaryStorageReleaseParams{
+		HTTPRequest: req,
+		ShipmentID:  strfmt.UUID(nts.ShipmentID.String()),
+		ReleaseRequest: &apimessages.NonTemporaryStorageReleaseRequest{
+			RequestedReleaseDate: handlers.FmtDate(requestedDate),
+			ReleaseAddress:       payloadForAddressModel(&releaseAddress),
+		},
+	}
+
+	handler := RequestNonTemporaryStorageReleaseHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	suite.Assertions.IsType(&ntsop.RequestNonTemporaryStorageReleaseOK{}, response)
+	okResponse := response.(*ntsop.RequestNonTemporaryStorageReleaseOK)
+	suite.Equal(string(models.NonTemporaryStorageStatusRELEASEREQUESTED), okResponse.Payload.Status)
+	suite.Equal(handlers.FmtDate(requestedDate).String(), okResponse.Payload.RequestedReleaseDate.String())
+	suite.Equal(releaseAddress.StreetAddress1, *okResponse.Payload.ReleaseAddress.StreetAddress1)
+}
@@ -478,6 +478,14 @@ func (h ApproveShipmentLineItemHandler) Handle(params accessorialop.ApproveShipm
 	}
 	h.DB().ValidateAndUpdate(&shipmentLineItem)
 
+	// Keep the pricing trace alongside the price it explains
+	if shipmentLineItem.PricingTrace != nil {
+		verrs, err := models.SavePricingTraceSteps(h.DB(), shipmentLineItem.ID, shipmentLineItem.PricingTrace)
+		if err != nil || verrs.HasAny() {
+			return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+		}
+	}
+
 	payload := payloadForShipmentLineItemModel(&shipmentLineItem)
 	return accessorialop.NewApproveShipmentLineItemOK().WithPayload(payload)
 }
@@ -43,6 +43,7 @@ func payloadForShipmentModel(s models.Shipment) *apimessages.Shipment {
 	shipmentpayload := &apimessages.Shipment{
 		ID:               *handlers.FmtUUID(s.ID),
 		Status:           apimessages.ShipmentStatus(s.Status),
+		ShipmentType:     apimessages.ShipmentType(s.ShipmentType),
 		SourceGbloc:      payloadForGBLOC(s.SourceGBLOC),
 		DestinationGbloc: payloadForGBLOC(s.DestinationGBLOC),
 		GblNumber:        s.GBLNumber,
@@ -269,6 +270,42 @@ func (h AcceptShipmentHandler) Handle(params shipmentop.AcceptShipmentParams) mi
 	return shipmentop.NewAcceptShipmentOK().WithPayload(sp)
 }
 
+// RejectShipmentHandler allows a TSP to reject a particular shipment
+type RejectShipmentHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle rejects the shipment, which returns it to the award queue - checks that currently logged in user is authorized to act for the TSP assigned the shipment
+func (h RejectShipmentHandler) Handle(params shipmentop.RejectShipmentParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+
+	shipmentID, _ := uuid.FromString(params.ShipmentID.String())
+
+	tspUser, err := models.FetchTspUserByID(h.DB(), session.TspUserID)
+	if err != nil {
+		h.Logger().Error("DB Query", zap.Error(err))
+		return shipmentop.NewRejectShipmentForbidden()
+	}
+
+	// Reject the shipment
+	shipment, _, verrs, err := models.RejectShipmentForTSP(h.DB(), tspUser.TransportationServiceProviderID, shipmentID, *params.Payload.Reason)
+	if err != nil || verrs.HasAny() {
+		if err == models.ErrFetchNotFound {
+			h.Logger().Error("DB Query", zap.Error(err))
+			return shipmentop.NewRejectShipmentBadRequest()
+		} else if errors.Cause(err) == models.ErrInvalidTransition {
+			h.Logger().Info("Attempted to reject shipment, got invalid transition", zap.Error(err), zap.String("shipment_status", string(shipment.Status)))
+			return shipmentop.NewRejectShipmentConflict().WithPayload(payloadForShipmentModel(*shipment))
+		} else {
+			h.Logger().Error("Unknown Error", zap.Error(err))
+			return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+		}
+	}
+
+	sp := payloadForShipmentModel(*shipment)
+	return shipmentop.NewRejectShipmentOK().WithPayload(sp)
+}
+
 // TransportShipmentHandler allows a TSP to start transporting a particular shipment
 type TransportShipmentHandler struct {
 	handlers.HandlerContext
@@ -540,6 +577,11 @@ func patchShipmentWithPayload(shipment *models.Shipment, payload *apimessages.Sh
 		shipment.HasDeliveryAddress = *payload.HasDeliveryAddress
 	}
 
+	// Moving either end of the shipment can move it in or out of the international market
+	if payload.PickupAddress != nil || payload.HasDeliveryAddress != nil {
+		shipment.UpdateMarket()
+	}
+
 	if payload.HasPartialSitDeliveryAddress != nil {
 		if *payload.HasPartialSitDeliveryAddress == false {
 			shipment.PartialSITDeliveryAddress = nil
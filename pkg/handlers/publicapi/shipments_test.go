@@ -728,6 +728,48 @@ func (suite *HandlerSuite) TestAcceptShipmentHandler() {
 	suite.Equal("ACCEPTED", string(okResponse.Payload.Status))
 }
 
+func (suite *HandlerSuite) TestRejectShipmentHandler() {
+	numTspUsers := 1
+	numShipments := 1
+	numShipmentOfferSplit := []int{1}
+	status := []models.ShipmentStatus{models.ShipmentStatusAWARDED}
+	tspUsers, shipments, shipmentOffers, err := testdatagen.CreateShipmentOfferData(suite.DB(), numTspUsers, numShipments, numShipmentOfferSplit, status, models.SelectedMoveTypeHHG)
+	suite.NoError(err)
+
+	tspUser := tspUsers[0]
+	shipment := shipments[0]
+
+	// Handler to Test
+	handler := RejectShipmentHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+
+	path := fmt.Sprintf("/shipments/%s/reject", shipment.ID.String())
+	req := httptest.NewRequest("POST", path, nil)
+	req = suite.AuthenticateTspRequest(req, tspUser)
+	params := shipmentop.RejectShipmentParams{
+		HTTPRequest: req,
+		ShipmentID:  *handlers.FmtUUID(shipment.ID),
+		Payload: &apimessages.RejectShipmentPayload{
+			Reason: swag.String("No capacity"),
+		},
+	}
+
+	response := handler.Handle(params)
+	suite.Assertions.IsType(&shipmentop.RejectShipmentOK{}, response)
+	okResponse := response.(*shipmentop.RejectShipmentOK)
+
+	// The shipment goes back in the queue
+	suite.Equal("SUBMITTED", string(okResponse.Payload.Status))
+
+	var offer models.ShipmentOffer
+	suite.NoError(suite.DB().Find(&offer, shipmentOffers[0].ID))
+	suite.False(*offer.Accepted)
+	suite.Equal("No capacity", *offer.RejectionReason)
+
+	// Rejecting again is a conflict
+	response = handler.Handle(params)
+	suite.Assertions.IsType(&shipmentop.RejectShipmentConflict{}, response)
+}
+
 // TestTransportShipmentHandler tests the api endpoint that transports a shipment
 func (suite *HandlerSuite) TestTransportShipmentHandler() {
 	numTspUsers := 1
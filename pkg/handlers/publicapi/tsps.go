@@ -1,10 +1,21 @@
 package publicapi
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
+	"github.com/transcom/mymove/pkg/auth"
+	"github.com/transcom/mymove/pkg/gen/apimessages"
 	tspsop "github.com/transcom/mymove/pkg/gen/restapi/apioperations/tsps"
 	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
 )
 
 // TspsIndexTSPsHandler returns a list of all the TSPs
@@ -36,3 +47,218 @@ type TspsGetTspBlackoutsHandler struct {
 func (h TspsGetTspBlackoutsHandler) Handle(params tspsop.GetTspShipmentsParams) middleware.Responder {
 	return middleware.NotImplemented("operation .tspShipments has not yet been implemented")
 }
+
+// authorizeTSPBlackouts checks that the session can manage the TSP's blackouts. Office users can manage the
+// blackouts of any TSP, and TSP users the blackouts of their own TSP.
+func authorizeTSPBlackouts(db *pop.Connection, session *auth.Session, tspID uuid.UUID) (bool, error) {
+	if session.IsOfficeUser() {
+		return true, nil
+	}
+	if !session.IsTspUser() {
+		return false, nil
+	}
+	tspUser, err := models.FetchTspUserByID(db, session.TspUserID)
+	if err != nil {
+		return false, err
+	}
+	return tspUser.TransportationServiceProviderID == tspID, nil
+}
+
+// saveBlackoutDates saves blackouts in one transaction, merging them with overlapping blackouts. It returns the
+// blackouts that remain once they have been merged. If any blackout is invalid none are saved.
+func saveBlackoutDates(db *pop.Connection, blackouts models.BlackoutDates, errorKeys []string) (models.BlackoutDates, *validate.Errors, error) {
+	responseVErrors := validate.NewErrors()
+	var responseError error
+	var saved models.BlackoutDates
+
+	db.Transaction(func(tx *pop.Connection) error {
+		transactionError := errors.New("Rollback the transaction")
+
+		for i := range blackouts {
+			merged, verrs, err := models.SaveBlackoutDate(tx, &blackouts[i])
+			if err != nil {
+				responseError = err
+				return transactionError
+			}
+			for _, key := range verrs.Keys() {
+				responseVErrors.Add(errorKeys[i], strings.Join(verrs.Get(key), " "))
+			}
+
+			// Blackouts saved earlier may have been merged into this one
+			mergedIDs := map[uuid.UUID]bool{}
+			for _, m := range merged {
+				mergedIDs[m.ID] = true
+			}
+			remaining := models.BlackoutDates{}
+			for _, s := range saved {
+				if !mergedIDs[s.ID] {
+					remaining = append(remaining, s)
+				}
+			}
+			saved = append(remaining, blackouts[i])
+		}
+		if responseVErrors.HasAny() {
+			return transactionError
+		}
+		return nil
+	})
+
+	return saved, responseVErrors, responseError
+}
+
+// TspsCreateTspBlackoutHandler creates a blackout for a TSP
+type TspsCreateTspBlackoutHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle creates a blackout, merging it with the TSP's overlapping blackouts
+func (h TspsCreateTspBlackoutHandler) Handle(params tspsop.CreateTspBlackoutParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	tspID, _ := uuid.FromString(params.TspID.String())
+
+	authorized, err := authorizeTSPBlackouts(h.DB(), session, tspID)
+	if err != nil {
+		h.Logger().Error("Error retrieving authenticated TSP user", zap.Error(err))
+		return tspsop.NewCreateTspBlackoutForbidden()
+	}
+	if !authorized {
+		return tspsop.NewCreateTspBlackoutForbidden()
+	}
+	if _, err := models.FetchTransportationServiceProvider(h.DB(), tspID); err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	blackout, verrs := blackoutDateFromPayload(tspID, *params.Payload)
+	if verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, nil)
+	}
+
+	saved, verrs, err := saveBlackoutDates(h.DB(), models.BlackoutDates{blackout}, []string{"blackout"})
+	if verrs.HasAny() || err != nil {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	return tspsop.NewCreateTspBlackoutCreated().WithPayload(payloadForBlackoutDateModel(saved[0]))
+}
+
+// TspsImportTspBlackoutsHandler creates blackouts for a TSP from a CSV file
+type TspsImportTspBlackoutsHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle creates a blackout for each row of the file, or none if any row is invalid
+func (h TspsImportTspBlackoutsHandler) Handle(params tspsop.ImportTspBlackoutsParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	tspID, _ := uuid.FromString(params.TspID.String())
+
+	authorized, err := authorizeTSPBlackouts(h.DB(), session, tspID)
+	if err != nil {
+		h.Logger().Error("Error retrieving authenticated TSP user", zap.Error(err))
+		return tspsop.NewImportTspBlackoutsForbidden()
+	}
+	if !authorized {
+		return tspsop.NewImportTspBlackoutsForbidden()
+	}
+	if _, err := models.FetchTransportationServiceProvider(h.DB(), tspID); err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	payloads, verrs := parseBlackoutCSV(params.File)
+	if verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, nil)
+	}
+
+	// Rows are numbered from the header, which is row 1
+	blackouts := make(models.BlackoutDates, len(payloads))
+	errorKeys := make([]string, len(payloads))
+	for i, payload := range payloads {
+		errorKeys[i] = fmt.Sprintf("row %d", i+2)
+		var rowVErrors *validate.Errors
+		blackouts[i], rowVErrors = blackoutDateFromPayload(tspID, payload)
+		for _, key := range rowVErrors.Keys() {
+			verrs.Add(errorKeys[i], strings.Join(rowVErrors.Get(key), " "))
+		}
+	}
+	if verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, nil)
+	}
+
+	saved, verrs, err := saveBlackoutDates(h.DB(), blackouts, errorKeys)
+	if verrs.HasAny() || err != nil {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, err)
+	}
+
+	h.Logger().Info("Imported TSP blackouts",
+		zap.String("tsp_id", tspID.String()),
+		zap.Int("rows", len(payloads)),
+		zap.Int("blackouts", len(saved)))
+
+	payload := make(apimessages.IndexBlackoutsPayload, len(saved))
+	for i, blackout := range saved {
+		payload[i] = payloadForBlackoutDateModel(blackout)
+	}
+	return tspsop.NewImportTspBlackoutsOK().WithPayload(payload)
+}
+
+// TspsPreviewTspBlackoutImpactHandler lists the shipments a blackout for a TSP would affect
+type TspsPreviewTspBlackoutImpactHandler struct {
+	handlers.HandlerContext
+}
+
+// Handle lists the unoffered shipments that would be offered to the TSP administratively during the blackout
+func (h TspsPreviewTspBlackoutImpactHandler) Handle(params tspsop.PreviewTspBlackoutImpactParams) middleware.Responder {
+	session := auth.SessionFromRequestContext(params.HTTPRequest)
+	tspID, _ := uuid.FromString(params.TspID.String())
+
+	authorized, err := authorizeTSPBlackouts(h.DB(), session, tspID)
+	if err != nil {
+		h.Logger().Error("Error retrieving authenticated TSP user", zap.Error(err))
+		return tspsop.NewPreviewTspBlackoutImpactForbidden()
+	}
+	if !authorized {
+		return tspsop.NewPreviewTspBlackoutImpactForbidden()
+	}
+	if _, err := models.FetchTransportationServiceProvider(h.DB(), tspID); err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	blackoutPayload := apimessages.CreateBlackoutPayload{
+		StartDate: &params.StartDate,
+		EndDate:   &params.EndDate,
+	}
+	if params.Gbloc != nil {
+		blackoutPayload.Gbloc = payloadForGBLOC(params.Gbloc)
+	}
+	if params.Market != nil {
+		blackoutPayload.Market = payloadForMarkets(params.Market)
+	}
+	if params.Zip3 != nil {
+		blackoutPayload.Zip3 = *params.Zip3
+	}
+	blackout, verrs := blackoutDateFromPayload(tspID, blackoutPayload)
+	if verrs.HasAny() {
+		return handlers.ResponseForVErrors(h.Logger(), verrs, nil)
+	}
+
+	shipments, err := models.FetchShipmentsAffectedByBlackout(h.DB(), blackout)
+	if err != nil {
+		return handlers.ResponseForError(h.Logger(), err)
+	}
+
+	payload := make(apimessages.IndexBlackoutImpactShipments, len(shipments))
+	for i, shipment := range shipments {
+		payload[i] = payloadForBlackoutImpactShipment(shipment)
+	}
+	return tspsop.NewPreviewTspBlackoutImpactOK().WithPayload(payload)
+}
+
+// payloadForBlackoutImpactShipment describes a shipment affected by a blackout. The shipment hasn't been offered
+// to the TSP yet, so it leaves out the service member, their addresses and the shipment's weights.
+func payloadForBlackoutImpactShipment(s models.Shipment) *apimessages.BlackoutImpactShipment {
+	return &apimessages.BlackoutImpactShipment{
+		ID:                        handlers.FmtUUID(s.ID),
+		RequestedPickupDate:       handlers.FmtDatePtr(s.RequestedPickupDate),
+		TrafficDistributionListID: handlers.FmtUUIDPtr(s.TrafficDistributionListID),
+		Market:                    payloadForMarkets(s.Market),
+	}
+}
@@ -0,0 +1,165 @@
+package publicapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/transcom/mymove/pkg/gen/apimessages"
+	tspsop "github.com/transcom/mymove/pkg/gen/restapi/apioperations/tsps"
+	"github.com/transcom/mymove/pkg/handlers"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *HandlerSuite) TestCreateTspBlackoutHandlerMergesOverlappingBlackouts() {
+	tspUser := testdatagen.MakeDefaultTspUser(suite.DB())
+	tspID := tspUser.TransportationServiceProviderID
+	gbloc := apimessages.GBLOC("KKFA")
+	handler := TspsCreateTspBlackoutHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+
+	create := func(start time.Time, end time.Time) *tspsop.CreateTspBlackoutCreated {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/tsps/%s/blackouts", tspID), nil)
+		req = suite.AuthenticateTspRequest(req, tspUser)
+		startDate := strfmt.Date(start)
+		endDate := strfmt.Date(end)
+		params := tspsop.CreateTspBlackoutParams{
+			HTTPRequest: req,
+			TspID:       strfmt.UUID(tspID.String()),
+			Payload: &apimessages.CreateBlackoutPayload{
+				StartDate: &startDate,
+				EndDate:   &endDate,
+				Gbloc:     &gbloc,
+			},
+		}
+		response := handler.Handle(params)
+		suite.Assertions.IsType(&tspsop.CreateTspBlackoutCreated{}, response)
+		return response.(*tspsop.CreateTspBlackoutCreated)
+	}
+
+	create(time.Date(testdatagen.TestYear, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(testdatagen.TestYear, time.March, 8, 0, 0, 0, 0, time.UTC))
+	response := create(time.Date(testdatagen.TestYear, time.March, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(testdatagen.TestYear, time.March, 12, 0, 0, 0, 0, time.UTC))
+
+	suite.True(time.Time(*response.Payload.StartDate).Equal(time.Date(testdatagen.TestYear, time.March, 1, 0, 0, 0, 0, time.UTC)))
+	suite.True(time.Time(*response.Payload.EndDate).Equal(time.Date(testdatagen.TestYear, time.March, 12, 0, 0, 0, 0, time.UTC)))
+
+	count, err := suite.DB().Where("transportation_service_provider_id = ?", tspID).Count(&models.BlackoutDate{})
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *HandlerSuite) TestCreateTspBlackoutHandlerForbidsOtherTSPs() {
+	tspUser := testdatagen.MakeDefaultTspUser(suite.DB())
+	otherTSP := testdatagen.MakeDefaultTSP(suite.DB())
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("/tsps/%s/blackouts", otherTSP.ID), nil)
+	req = suite.AuthenticateTspRequest(req, tspUser)
+	startDate := strfmt.Date(time.Date(testdatagen.TestYear, time.March, 1, 0, 0, 0, 0, time.UTC))
+	endDate := strfmt.Date(time.Date(testdatagen.TestYear, time.March, 8, 0, 0, 0, 0, time.UTC))
+	params := tspsop.CreateTspBlackoutParams{
+		HTTPRequest: req,
+		TspID:       strfmt.UUID(otherTSP.ID.String()),
+		Payload: &apimessages.CreateBlackoutPayload{
+			StartDate: &startDate,
+			EndDate:   &endDate,
+		},
+	}
+
+	handler := TspsCreateTspBlackoutHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+	suite.Assertions.IsType(&tspsop.CreateTspBlackoutForbidden{}, response)
+}
+
+func (suite *HandlerSuite) TestImportTspBlackoutsHandler() {
+	tspUser := testdatagen.MakeDefaultTspUser(suite.DB())
+	tspID := tspUser.TransportationServiceProviderID
+	handler := TspsImportTspBlackoutsHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+
+	importCSV := func(csv string) middleware.Responder {
+		req := httptest.NewRequest("POST", fmt.Sprintf("/tsps/%s/blackouts/import", tspID), nil)
+		req = suite.AuthenticateTspRequest(req, tspUser)
+		params := tspsop.ImportTspBlackoutsParams{
+			HTTPRequest: req,
+			TspID:       strfmt.UUID(tspID.String()),
+			File:        ioutil.NopCloser(strings.NewReader(csv)),
+		}
+		return handler.Handle(params)
+	}
+
+	// A file with an invalid row imports nothing
+	response := importCSV(fmt.Sprintf("start_date,end_date,gbloc\n%d-04-01,%d-04-05,KKFA\n%d-04-10,not a date,KKFA\n",
+		testdatagen.TestYear, testdatagen.TestYear, testdatagen.TestYear))
+	suite.CheckResponseBadRequest(response)
+	count, err := suite.DB().Where("transportation_service_provider_id = ?", tspID).Count(&models.BlackoutDate{})
+	suite.NoError(err)
+	suite.Equal(0, count)
+
+	// Overlapping rows are merged
+	response = importCSV(fmt.Sprintf("start_date,end_date,gbloc,market\n%d-04-01,%d-04-05,KKFA,dHHG\n%d-04-03,%d-04-10,KKFA,dHHG\n%d-04-03,%d-04-10,KKNO,dHHG\n",
+		testdatagen.TestYear, testdatagen.TestYear, testdatagen.TestYear, testdatagen.TestYear, testdatagen.TestYear, testdatagen.TestYear))
+	suite.Assertions.IsType(&tspsop.ImportTspBlackoutsOK{}, response)
+	okResponse := response.(*tspsop.ImportTspBlackoutsOK)
+	suite.Len(okResponse.Payload, 2)
+	suite.True(time.Time(*okResponse.Payload[0].StartDate).Equal(time.Date(testdatagen.TestYear, time.April, 1, 0, 0, 0, 0, time.UTC)))
+	suite.True(time.Time(*okResponse.Payload[0].EndDate).Equal(time.Date(testdatagen.TestYear, time.April, 10, 0, 0, 0, 0, time.UTC)))
+
+	count, err = suite.DB().Where("transportation_service_provider_id = ?", tspID).Count(&models.BlackoutDate{})
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
+func (suite *HandlerSuite) TestPreviewTspBlackoutImpactHandler() {
+	tspUser := testdatagen.MakeDefaultTspUser(suite.DB())
+	tsp := models.TransportationServiceProvider{ID: tspUser.TransportationServiceProviderID}
+	market := "dHHG"
+	gbloc := "KKFA"
+	pickupDate := time.Date(testdatagen.TestYear, time.April, 3, 0, 0, 0, 0, time.UTC)
+
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &pickupDate,
+			SourceGBLOC:         &gbloc,
+			Market:              &market,
+			BookDate:            &testdatagen.DateInsidePerformancePeriod,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+	_, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, *shipment.TrafficDistributionList, nil, 0.5, 0, .3, .3)
+	suite.NoError(err)
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/tsps/%s/blackouts/impact", tsp.ID), nil)
+	req = suite.AuthenticateTspRequest(req, tspUser)
+	params := tspsop.PreviewTspBlackoutImpactParams{
+		HTTPRequest: req,
+		TspID:       strfmt.UUID(tsp.ID.String()),
+		StartDate:   strfmt.Date(time.Date(testdatagen.TestYear, time.April, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:     strfmt.Date(time.Date(testdatagen.TestYear, time.April, 6, 0, 0, 0, 0, time.UTC)),
+		Gbloc:       &gbloc,
+		Market:      &market,
+	}
+
+	handler := TspsPreviewTspBlackoutImpactHandler{handlers.NewHandlerContext(suite.DB(), suite.TestLogger())}
+	response := handler.Handle(params)
+
+	suite.Assertions.IsType(&tspsop.PreviewTspBlackoutImpactOK{}, response)
+	okResponse := response.(*tspsop.PreviewTspBlackoutImpactOK)
+	if suite.Len(okResponse.Payload, 1) {
+		suite.Equal(strfmt.UUID(shipment.ID.String()), *okResponse.Payload[0].ID)
+		suite.True(time.Time(*okResponse.Payload[0].RequestedPickupDate).Equal(pickupDate))
+	}
+
+	// The shipment hasn't been offered to the TSP, so its user mustn't see where it's picked up from
+	body, err := json.Marshal(okResponse.Payload)
+	suite.NoError(err)
+	suite.NotContains(string(body), "address")
+	suite.NotContains(string(body), shipment.PickupAddress.StreetAddress1)
+	suite.NotContains(string(body), "service_member")
+}
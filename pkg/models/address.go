@@ -99,6 +99,31 @@ func (a *Address) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	return nil
 }
 
+// oconusStates are the state codes of addresses outside the continental US, including the
+// military "states" used for APO and FPO addresses
+var oconusStates = map[string]bool{
+	"AK": true,
+	"HI": true,
+	"AA": true,
+	"AE": true,
+	"AP": true,
+}
+
+// IsOCONUS returns true if the address is outside the continental US. A nil address is not.
+func (a *Address) IsOCONUS() bool {
+	if a == nil {
+		return false
+	}
+	if a.Country != nil && *a.Country != "" {
+		switch strings.ToUpper(*a.Country) {
+		case "US", "USA", "UNITED STATES", "UNITED STATES OF AMERICA":
+		default:
+			return true
+		}
+	}
+	return oconusStates[strings.ToUpper(a.State)]
+}
+
 // Format returns the address in default US mailing address format
 func (a *Address) Format() string {
 	lines := []string{}
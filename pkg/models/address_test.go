@@ -33,3 +33,25 @@ func (suite *ModelSuite) TestEmptyAddressInstantiation() {
 	}
 	suite.verifyValidationErrors(&newAddress, expErrors)
 }
+
+func (suite *ModelSuite) TestAddressIsOCONUS() {
+	suite.False((*Address)(nil).IsOCONUS())
+	suite.False((&Address{State: "CA", Country: swag.String("US")}).IsOCONUS())
+	suite.False((&Address{State: "VA", Country: swag.String("United States")}).IsOCONUS())
+	suite.True((&Address{State: "AK", Country: swag.String("US")}).IsOCONUS())
+	suite.True((&Address{State: "HI"}).IsOCONUS())
+	suite.True((&Address{State: "AE", PostalCode: "09001"}).IsOCONUS())
+	suite.True((&Address{State: "Bavaria", Country: swag.String("Germany")}).IsOCONUS())
+}
+
+func (suite *ModelSuite) TestShipmentMarketForAddresses() {
+	conus := &Address{State: "CA", Country: swag.String("US")}
+	alaska := &Address{State: "AK", Country: swag.String("US")}
+
+	suite.Equal(ShipmentMarketDHHG, ShipmentMarketForAddresses(ShipmentTypeHHG, conus, conus))
+	suite.Equal(ShipmentMarketDHHG, ShipmentMarketForAddresses(ShipmentTypeHHG, nil, conus))
+	suite.Equal(ShipmentMarketIHHG, ShipmentMarketForAddresses(ShipmentTypeHHG, conus, alaska))
+	suite.Equal(ShipmentMarketIHHG, ShipmentMarketForAddresses(ShipmentTypeHHG, alaska, conus))
+	suite.Equal(ShipmentMarketDHHG, ShipmentMarketForAddresses(ShipmentTypeUB, conus, conus))
+	suite.Equal(ShipmentMarketIUB, ShipmentMarketForAddresses(ShipmentTypeUB, conus, alaska))
+}
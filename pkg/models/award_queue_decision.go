@@ -0,0 +1,120 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gobuffalo/validate/validators"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// AwardQueueDecisionOutcome is how an attempt to offer a shipment ended
+type AwardQueueDecisionOutcome string
+
+const (
+	// AwardQueueDecisionOutcomeOFFERED captures enum value "OFFERED"
+	AwardQueueDecisionOutcomeOFFERED AwardQueueDecisionOutcome = "OFFERED"
+	// AwardQueueDecisionOutcomeNOTOFFERED captures enum value "NOT_OFFERED"
+	AwardQueueDecisionOutcomeNOTOFFERED AwardQueueDecisionOutcome = "NOT_OFFERED"
+)
+
+var awardQueueDecisionOutcomes = []string{
+	string(AwardQueueDecisionOutcomeOFFERED),
+	string(AwardQueueDecisionOutcomeNOTOFFERED),
+}
+
+// AwardQueueDecision records one attempt by the award queue to offer a shipment: the TSP performances it
+// considered, how blackout dates were evaluated, and which TSP was chosen and why. Decisions are kept so that
+// questions about how a shipment was offered can be answered long after the logs are gone.
+type AwardQueueDecision struct {
+	ID                                         uuid.UUID                    `json:"id" db:"id"`
+	CreatedAt                                  time.Time                    `json:"created_at" db:"created_at"`
+	UpdatedAt                                  time.Time                    `json:"updated_at" db:"updated_at"`
+	ShipmentID                                 uuid.UUID                    `json:"shipment_id" db:"shipment_id"`
+	TrafficDistributionListID                  *uuid.UUID                   `json:"traffic_distribution_list_id" db:"traffic_distribution_list_id"`
+	AwardQueuePolicyID                         *uuid.UUID                   `json:"award_queue_policy_id" db:"award_queue_policy_id"`
+	Outcome                                    AwardQueueDecisionOutcome    `json:"outcome" db:"outcome"`
+	Reason                                     string                       `json:"reason" db:"reason"`
+	TransportationServiceProviderPerformanceID *uuid.UUID                   `json:"transportation_service_provider_performance_id" db:"transportation_service_provider_performance_id"`
+	TransportationServiceProviderID            *uuid.UUID                   `json:"transportation_service_provider_id" db:"transportation_service_provider_id"`
+	ShipmentOfferID                            *uuid.UUID                   `json:"shipment_offer_id" db:"shipment_offer_id"`
+	Candidates                                 AwardQueueDecisionCandidates `has_many:"award_queue_decision_candidates" order_by:"attempt asc, quality_band asc"`
+}
+
+// AwardQueueDecisions is a slice of AwardQueueDecision objects
+type AwardQueueDecisions []AwardQueueDecision
+
+// AwardQueueDecisionCandidate is a TSP performance the award queue considered during an attempt to offer a
+// shipment. Each time the award queue picks a TSP it considers the next TSP performance in each quality band,
+// and selects one of them. Blackout dates are only evaluated for the selected TSP.
+type AwardQueueDecisionCandidate struct {
+	ID                                         uuid.UUID  `json:"id" db:"id"`
+	CreatedAt                                  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt                                  time.Time  `json:"updated_at" db:"updated_at"`
+	AwardQueueDecisionID                       uuid.UUID  `json:"award_queue_decision_id" db:"award_queue_decision_id"`
+	Attempt                                    int        `json:"attempt" db:"attempt"`
+	QualityBand                                int        `json:"quality_band" db:"quality_band"`
+	TransportationServiceProviderPerformanceID uuid.UUID  `json:"transportation_service_provider_performance_id" db:"transportation_service_provider_performance_id"`
+	TransportationServiceProviderID            uuid.UUID  `json:"transportation_service_provider_id" db:"transportation_service_provider_id"`
+	OfferCount                                 int        `json:"offer_count" db:"offer_count"`
+	Selected                                   bool       `json:"selected" db:"selected"`
+	WithinBlackout                             *bool      `json:"within_blackout" db:"within_blackout"`
+	ShipmentOfferID                            *uuid.UUID `json:"shipment_offer_id" db:"shipment_offer_id"`
+}
+
+// AwardQueueDecisionCandidates is a slice of AwardQueueDecisionCandidate objects
+type AwardQueueDecisionCandidates []AwardQueueDecisionCandidate
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (d *AwardQueueDecision) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: d.ShipmentID, Name: "ShipmentID"},
+		&validators.StringInclusion{Field: string(d.Outcome), Name: "Outcome", List: awardQueueDecisionOutcomes},
+		&validators.StringIsPresent{Field: d.Reason, Name: "Reason"},
+	), nil
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (c *AwardQueueDecisionCandidate) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: c.AwardQueueDecisionID, Name: "AwardQueueDecisionID"},
+		&validators.UUIDIsPresent{Field: c.TransportationServiceProviderPerformanceID, Name: "TransportationServiceProviderPerformanceID"},
+		&validators.UUIDIsPresent{Field: c.TransportationServiceProviderID, Name: "TransportationServiceProviderID"},
+		&validators.IntIsGreaterThan{Field: c.Attempt, Name: "Attempt", Compared: 0},
+	), nil
+}
+
+// CreateAwardQueueDecision saves a decision along with the candidates it considered
+func CreateAwardQueueDecision(tx *pop.Connection, decision *AwardQueueDecision) (*validate.Errors, error) {
+	verrs, err := tx.ValidateAndCreate(decision)
+	if verrs.HasAny() || err != nil {
+		return verrs, errors.Wrap(err, "Error creating award queue decision")
+	}
+
+	for i := range decision.Candidates {
+		candidate := &decision.Candidates[i]
+		candidate.AwardQueueDecisionID = decision.ID
+		verrs, err := tx.ValidateAndCreate(candidate)
+		if verrs.HasAny() || err != nil {
+			return verrs, errors.Wrap(err, "Error creating award queue decision candidate")
+		}
+	}
+
+	return validate.NewErrors(), nil
+}
+
+// FetchAwardQueueDecisionsForShipment returns every decision the award queue made about a shipment, oldest
+// first, with the candidates it considered
+func FetchAwardQueueDecisionsForShipment(db *pop.Connection, shipmentID uuid.UUID) (AwardQueueDecisions, error) {
+	var decisions AwardQueueDecisions
+	err := db.Eager("Candidates").
+		Where("shipment_id = ?", shipmentID).
+		Order("created_at ASC").
+		All(&decisions)
+	if err != nil {
+		return nil, errors.Wrap(err, "Award queue decisions query failed")
+	}
+	return decisions, nil
+}
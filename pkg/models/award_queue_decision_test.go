@@ -0,0 +1,55 @@
+package models_test
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *ModelSuite) Test_AwardQueueDecisionValidations() {
+	decision := &models.AwardQueueDecision{}
+
+	expErrors := map[string][]string{
+		"shipment_id": {"ShipmentID can not be blank."},
+		"outcome":     {"Outcome is not in the list [OFFERED, NOT_OFFERED]."},
+		"reason":      {"Reason can not be blank."},
+	}
+
+	suite.verifyValidationErrors(decision, expErrors)
+}
+
+func (suite *ModelSuite) Test_CreateAndFetchAwardQueueDecisions() {
+	shipment := testdatagen.MakeDefaultShipment(suite.DB())
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	tspp, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, *shipment.TrafficDistributionList, nil, 50, 0, .3, .3)
+	suite.NoError(err)
+
+	decision := models.AwardQueueDecision{
+		ShipmentID:                shipment.ID,
+		TrafficDistributionListID: shipment.TrafficDistributionListID,
+		Outcome:                   models.AwardQueueDecisionOutcomeNOTOFFERED,
+		Reason:                    "could not find a TSP without blackout dates in 1 tries",
+		Candidates: models.AwardQueueDecisionCandidates{
+			{
+				Attempt:     1,
+				QualityBand: 1,
+				TransportationServiceProviderPerformanceID: tspp.ID,
+				TransportationServiceProviderID:            tsp.ID,
+				Selected:                                   true,
+			},
+		},
+	}
+	verrs, err := models.CreateAwardQueueDecision(suite.DB(), &decision)
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+	suite.NotEqual(uuid.Nil, decision.ID)
+
+	decisions, err := models.FetchAwardQueueDecisionsForShipment(suite.DB(), shipment.ID)
+	suite.NoError(err)
+	suite.Len(decisions, 1)
+	suite.Equal(decision.Reason, decisions[0].Reason)
+	suite.Len(decisions[0].Candidates, 1)
+	suite.Equal(tspp.ID, decisions[0].Candidates[0].TransportationServiceProviderPerformanceID)
+	suite.True(decisions[0].Candidates[0].Selected)
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gobuffalo/validate/validators"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// BandDistribution describes how TSPs that don't divide evenly into quality bands are distributed
+type BandDistribution string
+
+const (
+	// BandDistributionTOPDOWN gives the remaining TSPs to the bands from the top band down
+	BandDistributionTOPDOWN BandDistribution = "TOP_DOWN"
+	// BandDistributionBOTTOMUP gives the remaining TSPs to the bands from the bottom band up
+	BandDistributionBOTTOMUP BandDistribution = "BOTTOM_UP"
+)
+
+var bandDistributions = []string{
+	string(BandDistributionTOPDOWN),
+	string(BandDistributionBOTTOMUP),
+}
+
+// AwardQueuePolicy holds the settings the award queue uses when banding TSPs and offering
+// shipments during a rate cycle. Policies are versioned; the highest version for a rate cycle is in effect.
+type AwardQueuePolicy struct {
+	ID                      uuid.UUID        `json:"id" db:"id"`
+	CreatedAt               time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time        `json:"updated_at" db:"updated_at"`
+	RateCycleStart          time.Time        `json:"rate_cycle_start" db:"rate_cycle_start"`
+	RateCycleEnd            time.Time        `json:"rate_cycle_end" db:"rate_cycle_end"`
+	Version                 int              `json:"version" db:"version"`
+	MinimumPerformanceScore float64          `json:"minimum_performance_score" db:"minimum_performance_score"`
+	NumQualityBands         int              `json:"num_quality_bands" db:"num_quality_bands"`
+	BandDistribution        BandDistribution `json:"band_distribution" db:"band_distribution"`
+	MaxOffersPerTSP         *int             `json:"max_offers_per_tsp" db:"max_offers_per_tsp"`
+	OfferAcceptanceHours    int              `json:"offer_acceptance_hours" db:"offer_acceptance_hours"`
+}
+
+// AwardQueuePolicies is not required by pop and may be deleted
+type AwardQueuePolicies []AwardQueuePolicy
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (p *AwardQueuePolicy) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.TimeIsPresent{Field: p.RateCycleStart, Name: "RateCycleStart"},
+		&validators.TimeIsPresent{Field: p.RateCycleEnd, Name: "RateCycleEnd"},
+		&validators.TimeAfterTime{
+			FirstTime: p.RateCycleEnd, FirstName: "RateCycleEnd",
+			SecondTime: p.RateCycleStart, SecondName: "RateCycleStart"},
+		&validators.IntIsGreaterThan{Field: p.Version, Name: "Version", Compared: 0},
+		&validators.IntIsGreaterThan{Field: int(p.MinimumPerformanceScore), Name: "MinimumPerformanceScore", Compared: -1},
+		// TSP performances can only be in quality bands 1 - 4, as defined in DTR 402
+		&validators.IntIsGreaterThan{Field: p.NumQualityBands, Name: "NumQualityBands", Compared: 0},
+		&validators.IntIsLessThan{Field: p.NumQualityBands, Name: "NumQualityBands", Compared: 5},
+		&validators.StringInclusion{Field: string(p.BandDistribution), Name: "BandDistribution", List: bandDistributions},
+		&OptionalIntIsPositive{Field: p.MaxOffersPerTSP, Name: "MaxOffersPerTSP"},
+		&validators.IntIsGreaterThan{Field: p.OfferAcceptanceHours, Name: "OfferAcceptanceHours", Compared: 0},
+	), nil
+}
+
+// DefaultAwardQueuePolicy returns the policy used for rate cycles that don't have one configured:
+// no Minimum Performance Score, four quality bands filled from the top down, no offer limit,
+// and 48 hours for a TSP to accept an offer.
+func DefaultAwardQueuePolicy() AwardQueuePolicy {
+	return AwardQueuePolicy{
+		MinimumPerformanceScore: 0,
+		NumQualityBands:         4,
+		BandDistribution:        BandDistributionTOPDOWN,
+		OfferAcceptanceHours:    48,
+	}
+}
+
+// OfferAcceptanceWindow returns how long a TSP has to accept a shipment offer before it expires
+func (p AwardQueuePolicy) OfferAcceptanceWindow() time.Duration {
+	return time.Duration(p.OfferAcceptanceHours) * time.Hour
+}
+
+// IsDefault reports whether this is the built-in policy rather than one stored in the database
+func (p AwardQueuePolicy) IsDefault() bool {
+	return p.ID == uuid.Nil
+}
+
+// PolicyID returns the ID to record on shipment offers made under this policy, or nil for the default policy
+func (p AwardQueuePolicy) PolicyID() *uuid.UUID {
+	if p.IsDefault() {
+		return nil
+	}
+	id := p.ID
+	return &id
+}
+
+// FetchCurrentAwardQueuePolicies returns the highest version of the policy for each rate cycle
+func FetchCurrentAwardQueuePolicies(db *pop.Connection) (AwardQueuePolicies, error) {
+	sql := `SELECT DISTINCT ON (rate_cycle_start, rate_cycle_end)
+			*
+		FROM
+			award_queue_policies
+		ORDER BY
+			rate_cycle_start, rate_cycle_end, version DESC
+		`
+
+	var policies AwardQueuePolicies
+	err := db.RawQuery(sql).All(&policies)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not fetch award queue policies")
+	}
+	return policies, nil
+}
+
+// ForDate returns the policy in effect for the rate cycle containing date, falling back to
+// DefaultAwardQueuePolicy when no policy covers it.
+func (policies AwardQueuePolicies) ForDate(date time.Time) AwardQueuePolicy {
+	for _, policy := range policies {
+		if !date.Before(policy.RateCycleStart) && !date.After(policy.RateCycleEnd) {
+			return policy
+		}
+	}
+	return DefaultAwardQueuePolicy()
+}
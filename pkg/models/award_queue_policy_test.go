@@ -0,0 +1,82 @@
+package models_test
+
+import (
+	"github.com/go-openapi/swag"
+	"github.com/gofrs/uuid"
+
+	. "github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *ModelSuite) TestAwardQueuePolicyValidation() {
+	policy := &AwardQueuePolicy{
+		RateCycleStart:          testdatagen.PeakRateCycleEnd,
+		RateCycleEnd:            testdatagen.PeakRateCycleStart,
+		MinimumPerformanceScore: -5,
+		BandDistribution:        "SIDEWAYS",
+		MaxOffersPerTSP:         swag.Int(0),
+	}
+
+	expErrors := map[string][]string{
+		"rate_cycle_end":            {"RateCycleEnd must be after RateCycleStart."},
+		"version":                   {"0 is not greater than 0."},
+		"minimum_performance_score": {"-5 is not greater than -1."},
+		"num_quality_bands":         {"0 is not greater than 0."},
+		"band_distribution":         {"BandDistribution is not in the list [TOP_DOWN, BOTTOM_UP]."},
+		"max_offers_per_tsp":        {"0 is less than or equal to zero."},
+		"offer_acceptance_hours":    {"0 is not greater than 0."},
+	}
+
+	suite.verifyValidationErrors(policy, expErrors)
+}
+
+func (suite *ModelSuite) TestFetchCurrentAwardQueuePolicies() {
+	testdatagen.MakeDefaultAwardQueuePolicy(suite.DB())
+	current := testdatagen.MakeAwardQueuePolicy(suite.DB(), testdatagen.Assertions{
+		AwardQueuePolicy: AwardQueuePolicy{
+			Version:                 2,
+			MinimumPerformanceScore: 70,
+		},
+	})
+
+	policies, err := FetchCurrentAwardQueuePolicies(suite.DB())
+	suite.NoError(err)
+	suite.Len(policies, 1)
+
+	// The latest version is in effect for dates in its rate cycle
+	policy := policies.ForDate(testdatagen.DateInsidePeakRateCycle)
+	suite.Equal(current.ID, policy.ID)
+	suite.Equal(70.0, policy.MinimumPerformanceScore)
+	suite.Equal(current.ID, *policy.PolicyID())
+
+	// Other rate cycles fall back to the default policy
+	policy = policies.ForDate(testdatagen.DateInsideNonPeakRateCycle)
+	suite.True(policy.IsDefault())
+	suite.Nil(policy.PolicyID())
+	suite.Equal(DefaultAwardQueuePolicy(), policy)
+}
+
+func (suite *ModelSuite) TestNextTSPPerformanceInQualityBandRespectsOfferLimit() {
+	tdl := testdatagen.MakeDefaultTDL(suite.DB())
+	busyTSP := testdatagen.MakeDefaultTSP(suite.DB())
+	otherTSP := testdatagen.MakeDefaultTSP(suite.DB())
+
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), busyTSP, tdl, swag.Int(1), mps+5, 2, .4, .4)
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), otherTSP, tdl, swag.Int(1), mps+1, 3, .4, .4)
+
+	// With no limit, the TSP with the fewest offers is next
+	tspp, err := NextTSPPerformanceInQualityBand(suite.DB(), tdl.ID, 1, testdatagen.DateInsidePerformancePeriod,
+		testdatagen.DateInsidePeakRateCycle, nil, uuid.Nil)
+	suite.NoError(err)
+	suite.Equal(busyTSP.ID, tspp.TransportationServiceProviderID)
+
+	// A TSP that has reached the limit is skipped
+	tspp, err = NextTSPPerformanceInQualityBand(suite.DB(), tdl.ID, 1, testdatagen.DateInsidePerformancePeriod,
+		testdatagen.DateInsidePeakRateCycle, swag.Int(3), uuid.Nil)
+	suite.NoError(err)
+	suite.Equal(busyTSP.ID, tspp.TransportationServiceProviderID)
+
+	_, err = NextTSPPerformanceInQualityBand(suite.DB(), tdl.ID, 1, testdatagen.DateInsidePerformancePeriod,
+		testdatagen.DateInsidePeakRateCycle, swag.Int(2), uuid.Nil)
+	suite.Error(err)
+}
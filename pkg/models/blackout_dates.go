@@ -25,11 +25,18 @@ type BlackoutDate struct {
 	VolumeMove                      *bool      `json:"volume_move" db:"volume_move"`
 }
 
-// FetchTSPBlackoutDates runs a SQL query to find all blackout_date records connected to a TSP ID.
+// FetchTSPBlackoutDates runs a SQL query to find all blackout_date records connected to a TSP ID that cover the
+// shipment's requested pickup date. Blackouts restricted to a zip3 only apply to shipments picked up in it.
 func FetchTSPBlackoutDates(tx *pop.Connection, tspID uuid.UUID, shipment Shipment) ([]BlackoutDate, error) {
 	blackoutDates := []BlackoutDate{}
 	var err error
-	query := tx.Where("transportation_service_provider_id = ?", tspID).Where("? BETWEEN start_blackout_date and end_blackout_date", shipment.ActualPickupDate)
+	query := tx.Where("transportation_service_provider_id = ?", tspID).Where("? BETWEEN start_blackout_date and end_blackout_date", shipment.RequestedPickupDate)
+
+	if zip3 := shipment.PickupZip3(); zip3 != nil {
+		query = query.Where("(zip3 IS NULL OR zip3 = ?)", *zip3)
+	} else {
+		query = query.Where("zip3 IS NULL")
+	}
 
 	if shipment.Market != nil {
 		query = query.Where("market = ?", *shipment.Market)
@@ -47,6 +54,133 @@ func FetchTSPBlackoutDates(tx *pop.Connection, tspID uuid.UUID, shipment Shipmen
 	return blackoutDates, err
 }
 
+// FetchBlackoutDatesForTSPs returns the blackout dates of the given TSPs that overlap start to end. Use
+// AppliesTo to find which of them FetchTSPBlackoutDates would return for a shipment.
+func FetchBlackoutDatesForTSPs(tx *pop.Connection, tspIDs []uuid.UUID, start time.Time, end time.Time) (BlackoutDates, error) {
+	var blackoutDates BlackoutDates
+	if len(tspIDs) == 0 {
+		return blackoutDates, nil
+	}
+
+	ids := make([]interface{}, len(tspIDs))
+	for i, id := range tspIDs {
+		ids[i] = id
+	}
+	err := tx.Where("transportation_service_provider_id IN (?)", ids...).
+		Where("start_blackout_date <= ?", end).
+		Where("end_blackout_date >= ?", start).
+		All(&blackoutDates)
+	if err != nil {
+		return nil, errors.Wrap(err, "Blackout dates query failed")
+	}
+
+	return blackoutDates, nil
+}
+
+// FetchOverlappingBlackoutDates returns the TSP's other blackout dates with the same TDL, market, GBLOC, zip3 and
+// volume move restrictions as blackout whose dates overlap or are next to its dates.
+func FetchOverlappingBlackoutDates(tx *pop.Connection, blackout BlackoutDate) (BlackoutDates, error) {
+	var blackoutDates BlackoutDates
+	err := tx.Where("transportation_service_provider_id = ?", blackout.TransportationServiceProviderID).
+		Where("id != ?", blackout.ID).
+		Where("traffic_distribution_list_id IS NOT DISTINCT FROM ?", blackout.TrafficDistributionListID).
+		Where("market IS NOT DISTINCT FROM ?", blackout.Market).
+		Where("source_gbloc IS NOT DISTINCT FROM ?", blackout.SourceGBLOC).
+		Where("zip3 IS NOT DISTINCT FROM ?", blackout.Zip3).
+		Where("volume_move IS NOT DISTINCT FROM ?", blackout.VolumeMove).
+		Where("start_blackout_date <= ?", blackout.EndBlackoutDate.AddDate(0, 0, 1)).
+		Where("end_blackout_date >= ?", blackout.StartBlackoutDate.AddDate(0, 0, -1)).
+		Order("start_blackout_date ASC").
+		All(&blackoutDates)
+	if err != nil {
+		return nil, errors.Wrap(err, "Overlapping blackout dates query failed")
+	}
+	return blackoutDates, nil
+}
+
+// SaveBlackoutDate validates and saves a blackout date, merging it with any of the TSP's blackout dates with the
+// same restrictions that it overlaps or adjoins. The blackout is extended to cover the merged dates, and the
+// blackout dates merged into it are deleted and returned. It should be called in a transaction.
+func SaveBlackoutDate(tx *pop.Connection, blackout *BlackoutDate) (BlackoutDates, *validate.Errors, error) {
+	verrs, err := blackout.Validate(tx)
+	if verrs.HasAny() || err != nil {
+		return nil, verrs, err
+	}
+
+	merged, err := FetchOverlappingBlackoutDates(tx, *blackout)
+	if err != nil {
+		return nil, verrs, err
+	}
+	for _, other := range merged {
+		if other.StartBlackoutDate.Before(blackout.StartBlackoutDate) {
+			blackout.StartBlackoutDate = other.StartBlackoutDate
+		}
+		if other.EndBlackoutDate.After(blackout.EndBlackoutDate) {
+			blackout.EndBlackoutDate = other.EndBlackoutDate
+		}
+		if err := tx.Destroy(&other); err != nil {
+			return nil, verrs, errors.Wrap(err, "Could not delete merged blackout date")
+		}
+	}
+
+	verrs, err = tx.ValidateAndSave(blackout)
+	return merged, verrs, err
+}
+
+// FetchShipmentsAffectedByBlackout returns the unoffered shipments that the award queue would offer to the
+// blackout's TSP administratively if the blackout were saved. Only shipments in TDLs where the TSP has a
+// performance record are included, and they are matched the same way as FetchTSPBlackoutDates.
+func FetchShipmentsAffectedByBlackout(tx *pop.Connection, blackout BlackoutDate) (Shipments, error) {
+	unoffered, err := FetchUnofferedShipments(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unoffered shipments query failed")
+	}
+
+	var perfs TransportationServiceProviderPerformances
+	err = tx.Where("transportation_service_provider_id = ?", blackout.TransportationServiceProviderID).All(&perfs)
+	if err != nil {
+		return nil, errors.Wrap(err, "TSP performances query failed")
+	}
+	tspTDLs := map[uuid.UUID]bool{}
+	for _, perf := range perfs {
+		tspTDLs[perf.TrafficDistributionListID] = true
+	}
+
+	affected := Shipments{}
+	for _, shipment := range unoffered {
+		if shipment.TrafficDistributionListID == nil || !tspTDLs[*shipment.TrafficDistributionListID] {
+			continue
+		}
+		if blackout.AppliesTo(shipment) {
+			affected = append(affected, shipment)
+		}
+	}
+	return affected, nil
+}
+
+// AppliesTo reports whether FetchTSPBlackoutDates would find the blackout for shipment: the shipment's requested
+// pickup date is during the blackout, the blackout's market and GBLOC match the shipment's where the shipment has
+// them, and the shipment is picked up in the blackout's zip3 if it has one.
+func (b BlackoutDate) AppliesTo(shipment Shipment) bool {
+	if shipment.RequestedPickupDate == nil || shipment.RequestedPickupDate.Before(b.StartBlackoutDate) ||
+		shipment.RequestedPickupDate.After(b.EndBlackoutDate) {
+		return false
+	}
+	if b.Zip3 != nil {
+		zip3 := shipment.PickupZip3()
+		if zip3 == nil || *zip3 != *b.Zip3 {
+			return false
+		}
+	}
+	if shipment.Market != nil && (b.Market == nil || *b.Market != *shipment.Market) {
+		return false
+	}
+	if shipment.SourceGBLOC != nil && (b.SourceGBLOC == nil || *b.SourceGBLOC != *shipment.SourceGBLOC) {
+		return false
+	}
+	return true
+}
+
 // BlackoutDates is not required by pop and may be deleted
 type BlackoutDates []BlackoutDate
 
@@ -56,6 +190,9 @@ func (b *BlackoutDate) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	return validate.Validate(
 		&validators.TimeIsPresent{Field: b.StartBlackoutDate, Name: "StartBlackoutDate"},
 		&validators.TimeIsPresent{Field: b.EndBlackoutDate, Name: "EndBlackoutDate"},
+		&validators.TimeAfterTime{
+			FirstTime: b.EndBlackoutDate, FirstName: "EndBlackoutDate",
+			SecondTime: b.StartBlackoutDate, SecondName: "StartBlackoutDate"},
 		// &validators.StringIsPresent{Field: b.CodeOfService, Name: "CodeOfService"},
 		// TODO: write our own validator that can validate pointers; Pop lacks that
 	), nil
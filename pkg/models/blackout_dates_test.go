@@ -3,6 +3,8 @@ package models_test
 import (
 	"time"
 
+	"github.com/gofrs/uuid"
+
 	"github.com/transcom/mymove/pkg/dates"
 	"github.com/transcom/mymove/pkg/models"
 	. "github.com/transcom/mymove/pkg/models"
@@ -34,17 +36,17 @@ func (suite *ModelSuite) Test_FetchTSPBlackoutDates() {
 
 	shipmentDomesticMarket := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
 		Shipment: models.Shipment{
-			ActualPickupDate: &pickupDate,
-			BookDate:         &testdatagen.DateInsidePerformancePeriod,
-			Status:           models.ShipmentStatusSUBMITTED,
+			RequestedPickupDate: &pickupDate,
+			BookDate:            &testdatagen.DateInsidePerformancePeriod,
+			Status:              models.ShipmentStatusSUBMITTED,
 		},
 	})
 
 	shipmentInternationalMarket := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
 		Shipment: models.Shipment{
-			ActualPickupDate: &pickupDate,
-			BookDate:         &testdatagen.DateInsidePerformancePeriod,
-			Status:           models.ShipmentStatusSUBMITTED,
+			RequestedPickupDate: &pickupDate,
+			BookDate:            &testdatagen.DateInsidePerformancePeriod,
+			Status:              models.ShipmentStatusSUBMITTED,
 		},
 	})
 
@@ -92,22 +94,22 @@ func (suite *ModelSuite) Test_FetchTSPBlackoutDatesWithGBLOC() {
 
 	shipmentInGBLOC1 := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
 		Shipment: models.Shipment{
-			ActualPickupDate: &pickupDate,
-			SourceGBLOC:      &sourceGBLOC1,
-			DestinationGBLOC: &destinationGBLOC1,
-			Market:           &market1,
-			BookDate:         &testdatagen.DateInsidePerformancePeriod,
-			Status:           models.ShipmentStatusSUBMITTED,
+			RequestedPickupDate: &pickupDate,
+			SourceGBLOC:         &sourceGBLOC1,
+			DestinationGBLOC:    &destinationGBLOC1,
+			Market:              &market1,
+			BookDate:            &testdatagen.DateInsidePerformancePeriod,
+			Status:              models.ShipmentStatusSUBMITTED,
 		},
 	})
 
 	shipmentInGBLOC2 := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
 		Shipment: models.Shipment{
-			ActualPickupDate: &pickupDate,
-			SourceGBLOC:      &sourceGBLOC2,
-			DestinationGBLOC: &destinationGBLOC2,
-			BookDate:         &testdatagen.DateInsidePerformancePeriod,
-			Status:           models.ShipmentStatusSUBMITTED,
+			RequestedPickupDate: &pickupDate,
+			SourceGBLOC:         &sourceGBLOC2,
+			DestinationGBLOC:    &destinationGBLOC2,
+			BookDate:            &testdatagen.DateInsidePerformancePeriod,
+			Status:              models.ShipmentStatusSUBMITTED,
 		},
 	})
 
@@ -125,3 +127,202 @@ func (suite *ModelSuite) Test_FetchTSPBlackoutDatesWithGBLOC() {
 		t.Errorf("Blackout dates query should have returned no results but returned one instead.")
 	}
 }
+
+func (suite *ModelSuite) Test_FetchTSPBlackoutDatesWithZip3() {
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	tdl := testdatagen.MakeDefaultTDL(suite.DB())
+	pickupDate := time.Date(testdatagen.TestYear, time.January, 29, 0, 0, 0, 0, time.UTC)
+	market := "dHHG"
+	sourceGBLOC := "KKFA"
+	pickupAddress := testdatagen.MakeAddress(suite.DB(), testdatagen.Assertions{
+		Address: models.Address{PostalCode: "02138"},
+	})
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			RequestedPickupDate: &pickupDate,
+			SourceGBLOC:         &sourceGBLOC,
+			Market:              &market,
+			PickupAddress:       &pickupAddress,
+			BookDate:            &testdatagen.DateInsidePerformancePeriod,
+			Status:              models.ShipmentStatusSUBMITTED,
+		},
+	})
+
+	otherZip3 := 941
+	otherBlackout := testdatagen.MakeBlackoutDate(suite.DB(), testdatagen.Assertions{
+		BlackoutDate: models.BlackoutDate{
+			TransportationServiceProviderID: tsp.ID,
+			StartBlackoutDate:               pickupDate,
+			EndBlackoutDate:                 pickupDate,
+			TrafficDistributionListID:       &tdl.ID,
+			SourceGBLOC:                     &sourceGBLOC,
+			Market:                          &market,
+			Zip3:                            &otherZip3,
+		},
+	})
+	suite.False(otherBlackout.AppliesTo(shipment))
+	blackouts, err := FetchTSPBlackoutDates(suite.DB(), tsp.ID, shipment)
+	suite.NoError(err)
+	suite.Empty(blackouts)
+
+	// Zips with leading zeroes are stored without them
+	zip3 := 21
+	blackout := testdatagen.MakeBlackoutDate(suite.DB(), testdatagen.Assertions{
+		BlackoutDate: models.BlackoutDate{
+			TransportationServiceProviderID: tsp.ID,
+			StartBlackoutDate:               pickupDate,
+			EndBlackoutDate:                 pickupDate,
+			TrafficDistributionListID:       &tdl.ID,
+			SourceGBLOC:                     &sourceGBLOC,
+			Market:                          &market,
+			Zip3:                            &zip3,
+		},
+	})
+	suite.True(blackout.AppliesTo(shipment))
+	blackouts, err = FetchTSPBlackoutDates(suite.DB(), tsp.ID, shipment)
+	suite.NoError(err)
+	if suite.Len(blackouts, 1) {
+		suite.Equal(blackout.ID, blackouts[0].ID)
+	}
+
+	// The award queue loads the pickup addresses of the shipments it offers
+	unoffered, err := FetchUnofferedShipments(suite.DB())
+	suite.NoError(err)
+	if suite.Len(unoffered, 1) {
+		suite.True(blackout.AppliesTo(unoffered[0]))
+	}
+}
+
+func (suite *ModelSuite) Test_SaveBlackoutDateMergesOverlappingBlackouts() {
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	tdl := testdatagen.MakeDefaultTDL(suite.DB())
+	market := "dHHG"
+	sourceGBLOC := "KKFA"
+	otherGBLOC := "KKNO"
+	day := func(d int) time.Time {
+		return time.Date(testdatagen.TestYear, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	existing := testdatagen.MakeBlackoutDate(suite.DB(), testdatagen.Assertions{
+		BlackoutDate: models.BlackoutDate{
+			TransportationServiceProviderID: tsp.ID,
+			StartBlackoutDate:               day(10),
+			EndBlackoutDate:                 day(15),
+			TrafficDistributionListID:       &tdl.ID,
+			SourceGBLOC:                     &sourceGBLOC,
+			Market:                          &market,
+		},
+	})
+
+	// An overlapping blackout with the same restrictions is merged
+	overlapping := models.BlackoutDate{
+		TransportationServiceProviderID: tsp.ID,
+		StartBlackoutDate:               day(14),
+		EndBlackoutDate:                 day(20),
+		TrafficDistributionListID:       &tdl.ID,
+		SourceGBLOC:                     &sourceGBLOC,
+		Market:                          &market,
+	}
+	merged, verrs, err := SaveBlackoutDate(suite.DB(), &overlapping)
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+	suite.Len(merged, 1)
+	suite.Equal(existing.ID, merged[0].ID)
+	suite.True(overlapping.StartBlackoutDate.Equal(day(10)))
+	suite.True(overlapping.EndBlackoutDate.Equal(day(20)))
+
+	// So is one that starts the day after it ends
+	adjoining := overlapping
+	adjoining.ID = uuid.Nil
+	adjoining.StartBlackoutDate = day(21)
+	adjoining.EndBlackoutDate = day(25)
+	merged, verrs, err = SaveBlackoutDate(suite.DB(), &adjoining)
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+	suite.Len(merged, 1)
+	suite.True(adjoining.StartBlackoutDate.Equal(day(10)))
+	suite.True(adjoining.EndBlackoutDate.Equal(day(25)))
+
+	// A blackout in another GBLOC is kept apart
+	otherGBLOCBlackout := models.BlackoutDate{
+		TransportationServiceProviderID: tsp.ID,
+		StartBlackoutDate:               day(12),
+		EndBlackoutDate:                 day(18),
+		TrafficDistributionListID:       &tdl.ID,
+		SourceGBLOC:                     &otherGBLOC,
+		Market:                          &market,
+	}
+	merged, verrs, err = SaveBlackoutDate(suite.DB(), &otherGBLOCBlackout)
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+	suite.Empty(merged)
+
+	count, err := suite.DB().Where("transportation_service_provider_id = ?", tsp.ID).Count(&models.BlackoutDate{})
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
+func (suite *ModelSuite) Test_SaveBlackoutDateRejectsEndBeforeStart() {
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	blackout := models.BlackoutDate{
+		TransportationServiceProviderID: tsp.ID,
+		StartBlackoutDate:               time.Date(testdatagen.TestYear, time.January, 10, 0, 0, 0, 0, time.UTC),
+		EndBlackoutDate:                 time.Date(testdatagen.TestYear, time.January, 9, 0, 0, 0, 0, time.UTC),
+	}
+	_, verrs, err := SaveBlackoutDate(suite.DB(), &blackout)
+	suite.NoError(err)
+	suite.True(verrs.HasAny())
+}
+
+func (suite *ModelSuite) Test_FetchShipmentsAffectedByBlackout() {
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	market := "dHHG"
+	sourceGBLOC := "KKFA"
+	otherGBLOC := "KKNO"
+	blackoutStartDate := time.Date(testdatagen.TestYear, time.January, 10, 0, 0, 0, 0, time.UTC)
+	blackoutEndDate := time.Date(testdatagen.TestYear, time.January, 20, 0, 0, 0, 0, time.UTC)
+	insideBlackout := time.Date(testdatagen.TestYear, time.January, 15, 0, 0, 0, 0, time.UTC)
+	outsideBlackout := time.Date(testdatagen.TestYear, time.January, 25, 0, 0, 0, 0, time.UTC)
+
+	makeShipment := func(tdl *models.TrafficDistributionList, pickupDate time.Time, gbloc string) models.Shipment {
+		return testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+			Shipment: models.Shipment{
+				TrafficDistributionList: tdl,
+				RequestedPickupDate:     &pickupDate,
+				SourceGBLOC:             &gbloc,
+				Market:                  &market,
+				BookDate:                &testdatagen.DateInsidePerformancePeriod,
+				Status:                  models.ShipmentStatusSUBMITTED,
+			},
+		})
+	}
+
+	affected := makeShipment(nil, insideBlackout, sourceGBLOC)
+	tdl := *affected.TrafficDistributionList
+	_, err := testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp, tdl, nil, 0.5, 0, .3, .3)
+	suite.NoError(err)
+
+	// Shipments picked up after the blackout, from another GBLOC, or in a TDL the TSP isn't in are unaffected
+	makeShipment(&tdl, outsideBlackout, sourceGBLOC)
+	makeShipment(&tdl, insideBlackout, otherGBLOC)
+	otherTDL := testdatagen.MakeTDL(suite.DB(), testdatagen.Assertions{
+		TrafficDistributionList: models.TrafficDistributionList{
+			SourceRateArea:    "US1",
+			DestinationRegion: "1",
+			CodeOfService:     "2",
+		},
+	})
+	makeShipment(&otherTDL, insideBlackout, sourceGBLOC)
+
+	blackout := models.BlackoutDate{
+		TransportationServiceProviderID: tsp.ID,
+		StartBlackoutDate:               blackoutStartDate,
+		EndBlackoutDate:                 blackoutEndDate,
+		SourceGBLOC:                     &sourceGBLOC,
+		Market:                          &market,
+	}
+	shipments, err := FetchShipmentsAffectedByBlackout(suite.DB(), blackout)
+	suite.NoError(err)
+	suite.Len(shipments, 1)
+	suite.Equal(affected.ID, shipments[0].ID)
+}
@@ -28,23 +28,39 @@ const (
 	// This status indicates that the invoice was successfully submitted, but the updating of the invoice
 	// and associated shipment line items failed.
 	InvoiceStatusUPDATEFAILURE InvoiceStatus = "UPDATE_FAILURE"
+	// InvoiceStatusACCEPTED captures enum value "ACCEPTED"
+	// This status indicates that Syncada acknowledged the submitted invoice with a 997 accepting it.
+	InvoiceStatusACCEPTED InvoiceStatus = "ACCEPTED"
+	// InvoiceStatusREJECTED captures enum value "REJECTED"
+	// This status indicates that Syncada acknowledged the submitted invoice with a 997 rejecting it, or that it
+	// failed Syncada's business validation and was rejected in an 824 application advice. The shipment can then
+	// be invoiced again.
+	InvoiceStatusREJECTED InvoiceStatus = "REJECTED"
 )
 
-// Invoice is a collection of line item charges to be sent for payment
+// Invoice is a collection of line item charges to be sent for payment. Its InterchangeControlNumber is the
+// control number of the 858C interchange it was sent in, which the 997 acknowledging it refers to. Since an
+// interchange can invoice a batch of shipments, TransactionSetControlNumber identifies the invoice's own
+// transaction set within it. RejectionNotes explain why an 824 application advice rejected the invoice.
 type Invoice struct {
-	ID                uuid.UUID         `json:"id" db:"id"`
-	ApproverID        uuid.UUID         `json:"approver_id" db:"approver_id"`
-	Approver          OfficeUser        `belongs_to:"office_user"`
-	Status            InvoiceStatus     `json:"status" db:"status"`
-	InvoiceNumber     string            `json:"invoice_number" db:"invoice_number"`
-	InvoicedDate      time.Time         `json:"invoiced_date" db:"invoiced_date"`
-	ShipmentID        uuid.UUID         `json:"shipment_id" db:"shipment_id"`
-	Shipment          Shipment          `belongs_to:"shipments"`
-	ShipmentLineItems ShipmentLineItems `has_many:"shipment_line_items"`
-	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time         `json:"updated_at" db:"updated_at"`
-	UploadID          *uuid.UUID        `json:"upload_id" db:"upload_id"`
-	Upload            *Upload           `belongs_to:"uploads"`
+	ID                          uuid.UUID         `json:"id" db:"id"`
+	ApproverID                  uuid.UUID         `json:"approver_id" db:"approver_id"`
+	Approver                    OfficeUser        `belongs_to:"office_user"`
+	Status                      InvoiceStatus     `json:"status" db:"status"`
+	InvoiceNumber               string            `json:"invoice_number" db:"invoice_number"`
+	InvoicedDate                time.Time         `json:"invoiced_date" db:"invoiced_date"`
+	ShipmentID                  uuid.UUID         `json:"shipment_id" db:"shipment_id"`
+	Shipment                    Shipment          `belongs_to:"shipments"`
+	ShipmentLineItems           ShipmentLineItems `has_many:"shipment_line_items"`
+	CreatedAt                   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt                   time.Time         `json:"updated_at" db:"updated_at"`
+	UploadID                    *uuid.UUID        `json:"upload_id" db:"upload_id"`
+	Upload                      *Upload           `belongs_to:"uploads"`
+	InterchangeControlNumber    *int64            `json:"interchange_control_number" db:"interchange_control_number"`
+	AcknowledgedAt              *time.Time        `json:"acknowledged_at" db:"acknowledged_at"`
+	AcknowledgementErrors       *string           `json:"acknowledgement_errors" db:"acknowledgement_errors"`
+	TransactionSetControlNumber *string           `json:"transaction_set_control_number" db:"transaction_set_control_number"`
+	RejectionNotes              *string           `json:"rejection_notes" db:"rejection_notes"`
 }
 
 // Invoices is an array of invoices
@@ -64,6 +80,48 @@ func (i *Invoice) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	), nil
 }
 
+// State Machinery
+// Avoid calling Invoice.Status = ... for acknowledgements. Use these methods to change the state.
+
+// Accept marks the invoice as accepted by Syncada. Errors it noted while accepting the invoice are kept.
+func (i *Invoice) Accept(acknowledgedAt time.Time, acknowledgementErrors *string) error {
+	if !i.awaitingAcknowledgement() {
+		return errors.Wrap(ErrInvalidTransition, "Accept")
+	}
+	i.Status = InvoiceStatusACCEPTED
+	i.AcknowledgedAt = &acknowledgedAt
+	i.AcknowledgementErrors = acknowledgementErrors
+	return nil
+}
+
+// Reject marks the invoice as rejected by Syncada, keeping the errors it reported.
+func (i *Invoice) Reject(acknowledgedAt time.Time, acknowledgementErrors *string) error {
+	if !i.awaitingAcknowledgement() {
+		return errors.Wrap(ErrInvalidTransition, "Reject")
+	}
+	i.Status = InvoiceStatusREJECTED
+	i.AcknowledgedAt = &acknowledgedAt
+	i.AcknowledgementErrors = acknowledgementErrors
+	return nil
+}
+
+// RejectApplication marks the invoice as rejected by Syncada's business validation, keeping the notes explaining
+// why. An 824 application advice can reject an invoice after a 997 accepted it.
+func (i *Invoice) RejectApplication(rejectionNotes string) error {
+	if !i.awaitingAcknowledgement() && i.Status != InvoiceStatusACCEPTED {
+		return errors.Wrap(ErrInvalidTransition, "RejectApplication")
+	}
+	i.Status = InvoiceStatusREJECTED
+	i.RejectionNotes = &rejectionNotes
+	return nil
+}
+
+// awaitingAcknowledgement reports whether the invoice was sent to Syncada and hasn't been acknowledged yet.
+// An invoice whose status failed to update after it was sent is still waiting for its acknowledgement.
+func (i *Invoice) awaitingAcknowledgement() bool {
+	return i.Status == InvoiceStatusSUBMITTED || i.Status == InvoiceStatusUPDATEFAILURE
+}
+
 // FetchInvoice fetches and validates an invoice model
 func FetchInvoice(db *pop.Connection, session *auth.Session, id uuid.UUID) (*Invoice, error) {
 
@@ -97,3 +155,24 @@ func FetchInvoicesForShipment(db *pop.Connection, shipmentID uuid.UUID) (Invoice
 	err := db.Where("shipment_id = ?", shipmentID).Eager("Approver").All(&invoices)
 	return invoices, err
 }
+
+// FetchInvoiceByInvoiceNumber fetches the invoice with the given invoice number
+func FetchInvoiceByInvoiceNumber(db *pop.Connection, invoiceNumber string) (*Invoice, error) {
+	var invoice Invoice
+	err := db.Where("invoice_number = ?", invoiceNumber).First(&invoice)
+	if err != nil {
+		if errors.Cause(err).Error() == recordNotFoundErrorString {
+			return nil, ErrFetchNotFound
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// FetchInvoicesByInterchangeControlNumber fetches the invoices sent in the 858C interchange with the given
+// interchange control number
+func FetchInvoicesByInterchangeControlNumber(db *pop.Connection, interchangeControlNumber int64) (Invoices, error) {
+	var invoices Invoices
+	err := db.Where("interchange_control_number = ?", interchangeControlNumber).Order("transaction_set_control_number").All(&invoices)
+	return invoices, err
+}
@@ -1,6 +1,8 @@
 package models_test
 
 import (
+	"github.com/go-openapi/swag"
+
 	"github.com/transcom/mymove/pkg/auth"
 	. "github.com/transcom/mymove/pkg/models"
 	"github.com/transcom/mymove/pkg/testdatagen"
@@ -102,3 +104,60 @@ func (suite *ModelSuite) TestFetchInvoicesForShipment() {
 		suite.Equal(extantInvoices[0].ID, invoice1.ID)
 	}
 }
+
+func (suite *ModelSuite) TestFetchInvoicesByInterchangeControlNumber() {
+	for _, controlNumber := range []string{"0002", "0001"} {
+		testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: Invoice{
+				InterchangeControlNumber:    swag.Int64(1001),
+				TransactionSetControlNumber: swag.String(controlNumber),
+			},
+		})
+	}
+	testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+		Invoice: Invoice{
+			InterchangeControlNumber:    swag.Int64(1002),
+			TransactionSetControlNumber: swag.String("0001"),
+		},
+	})
+
+	// Invoices sent in the same interchange are returned in transaction set order
+	invoices, err := FetchInvoicesByInterchangeControlNumber(suite.DB(), 1001)
+	if suite.NoError(err) && suite.Len(invoices, 2) {
+		suite.Equal("0001", *invoices[0].TransactionSetControlNumber)
+		suite.Equal("0002", *invoices[1].TransactionSetControlNumber)
+	}
+
+	invoices, err = FetchInvoicesByInterchangeControlNumber(suite.DB(), 1003)
+	suite.NoError(err)
+	suite.Empty(invoices)
+}
+
+func (suite *ModelSuite) TestFetchInvoiceByInvoiceNumber() {
+	invoice := testdatagen.MakeDefaultInvoice(suite.DB())
+	testdatagen.MakeDefaultInvoice(suite.DB())
+
+	fetched, err := FetchInvoiceByInvoiceNumber(suite.DB(), invoice.InvoiceNumber)
+	if suite.NoError(err) {
+		suite.Equal(invoice.ID, fetched.ID)
+	}
+
+	_, err = FetchInvoiceByInvoiceNumber(suite.DB(), "XXXX190000")
+	suite.Equal(ErrFetchNotFound, err)
+}
+
+func (suite *ModelSuite) TestInvoiceRejectApplication() {
+	for _, status := range []InvoiceStatus{InvoiceStatusSUBMITTED, InvoiceStatusUPDATEFAILURE, InvoiceStatusACCEPTED} {
+		invoice := Invoice{Status: status}
+		if suite.NoError(invoice.RejectApplication("TAC NOT VALID")) {
+			suite.Equal(InvoiceStatusREJECTED, invoice.Status)
+			suite.Equal("TAC NOT VALID", *invoice.RejectionNotes)
+		}
+	}
+
+	for _, status := range []InvoiceStatus{InvoiceStatusINPROCESS, InvoiceStatusSUBMISSIONFAILURE, InvoiceStatusREJECTED} {
+		invoice := Invoice{Status: status}
+		suite.Error(invoice.RejectApplication("TAC NOT VALID"))
+		suite.Equal(status, invoice.Status)
+	}
+}
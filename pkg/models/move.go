@@ -70,6 +70,7 @@ type Move struct {
 	SelectedMoveType        *SelectedMoveType       `json:"selected_move_type" db:"selected_move_type"`
 	PersonallyProcuredMoves PersonallyProcuredMoves `has_many:"personally_procured_moves" order_by:"created_at desc"`
 	Shipments               Shipments               `has_many:"shipments"`
+	PrivatelyOwnedVehicles  PrivatelyOwnedVehicles  `has_many:"privately_owned_vehicles" order_by:"created_at asc"`
 	MoveDocuments           MoveDocuments           `has_many:"move_documents" order_by:"created_at desc"`
 	Status                  MoveStatus              `json:"status" db:"status"`
 	SignedCertifications    SignedCertifications    `has_many:"signed_certifications" order_by:"created_at desc"`
@@ -127,6 +128,14 @@ func (m *Move) Submit() error {
 		}
 	}
 
+	// Update POV status too
+	for i := range m.PrivatelyOwnedVehicles {
+		err := m.PrivatelyOwnedVehicles[i].Submit()
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, ppm := range m.PersonallyProcuredMoves {
 		if ppm.Advance != nil {
 			err := ppm.Advance.Request()
@@ -145,6 +154,18 @@ func (m *Move) Approve() error {
 	}
 
 	m.Status = MoveStatusAPPROVED
+
+	// POVs are approved along with the move, once the office has reviewed the orders
+	now := time.Now()
+	for i := range m.PrivatelyOwnedVehicles {
+		if m.PrivatelyOwnedVehicles[i].Status != POVStatusSUBMITTED {
+			continue
+		}
+		err := m.PrivatelyOwnedVehicles[i].Approve(now)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -181,6 +202,18 @@ func (m *Move) Cancel(reason string) error {
 		}
 	}
 
+	// Vehicles that have already been picked up can't be canceled
+	for i := range m.PrivatelyOwnedVehicles {
+		pov := &m.PrivatelyOwnedVehicles[i]
+		if pov.Status == POVStatusPICKEDUP || pov.Status == POVStatusCANCELED {
+			continue
+		}
+		err := pov.Cancel()
+		if err != nil {
+			return err
+		}
+	}
+
 	// TODO: Orders can exist after related moves are canceled
 	err := m.Orders.Cancel()
 	if err != nil {
@@ -198,7 +231,8 @@ func FetchMove(db *pop.Connection, session *auth.Session, id uuid.UUID) (*Move,
 		"Orders",
 		"MoveDocuments.Document",
 		"Shipments.TrafficDistributionList",
-		"Shipments.ServiceAgents").Find(&move, id)
+		"Shipments.ServiceAgents",
+		"PrivatelyOwnedVehicles").Find(&move, id)
 
 	if err != nil {
 		if errors.Cause(err).Error() == recordNotFoundErrorString {
@@ -283,7 +317,19 @@ func (m Move) createMoveDocumentWithoutTransaction(
 	}
 
 	var newMoveDocument *MoveDocument
-	if moveType == SelectedMoveTypeHHG || moveType == SelectedMoveTypeHHGPPM {
+	if moveType == SelectedMoveTypePOV {
+		newMoveDocument = &MoveDocument{
+			Move:                    m,
+			MoveID:                  m.ID,
+			Document:                newDoc,
+			DocumentID:              newDoc.ID,
+			PrivatelyOwnedVehicleID: modelID,
+			MoveDocumentType:        moveDocumentType,
+			Title:                   title,
+			Status:                  MoveDocumentStatusAWAITINGREVIEW,
+			Notes:                   notes,
+		}
+	} else if moveType == SelectedMoveTypeHHG || moveType == SelectedMoveTypeHHGPPM || moveType == SelectedMoveTypeUB {
 		newMoveDocument = &MoveDocument{
 			Move:             m,
 			MoveID:           m.ID,
@@ -453,6 +499,49 @@ func (m Move) CreatePPM(db *pop.Connection,
 	return &newPPM, verrs, nil
 }
 
+// CreatePOV creates a new POV shipment associated with this move, as long as the move's orders authorize one
+func (m Move) CreatePOV(db *pop.Connection,
+	vin string,
+	vehicleMake string,
+	vehicleModel string,
+	year int,
+	requestedDropOffDate *time.Time,
+	dropOffVPCID *uuid.UUID,
+	pickupVPCID *uuid.UUID) (*PrivatelyOwnedVehicle, *validate.Errors, error) {
+
+	var orders Order
+	err := db.Eager("ServiceMember.DutyStation.Address", "NewDutyStation.Address").Find(&orders, m.OrdersID)
+	if err != nil {
+		return nil, validate.NewErrors(), errors.Wrap(err, "Error fetching orders for POV")
+	}
+
+	if authorized, reason := orders.AuthorizesPOVShipment(); !authorized {
+		verrs := validate.NewErrors()
+		verrs.Add("orders_type", reason)
+		return nil, verrs, nil
+	}
+
+	newPOV := PrivatelyOwnedVehicle{
+		MoveID:               m.ID,
+		Move:                 m,
+		Status:               POVStatusDRAFT,
+		VIN:                  vin,
+		Make:                 vehicleMake,
+		Model:                vehicleModel,
+		Year:                 year,
+		RequestedDropOffDate: requestedDropOffDate,
+		DropOffVPCID:         dropOffVPCID,
+		PickupVPCID:          pickupVPCID,
+	}
+
+	verrs, err := db.ValidateAndCreate(&newPOV)
+	if err != nil || verrs.HasAny() {
+		return nil, verrs, err
+	}
+
+	return &newPOV, verrs, nil
+}
+
 // CreateSignedCertification creates a new SignedCertification associated with this move
 func (m Move) CreateSignedCertification(db *pop.Connection,
 	submittingUserID uuid.UUID,
@@ -570,6 +659,14 @@ func SaveMoveDependencies(db *pop.Connection, move *Move) (*validate.Errors, err
 			}
 		}
 
+		for _, pov := range move.PrivatelyOwnedVehicles {
+			if verrs, err := db.ValidateAndSave(&pov); verrs.HasAny() || err != nil {
+				responseVErrors.Append(verrs)
+				responseError = errors.Wrap(err, "Error Saving POV")
+				return transactionError
+			}
+		}
+
 		if move.Status == MoveStatusSUBMITTED {
 
 			// Save Shipment GBLOCs
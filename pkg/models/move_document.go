@@ -75,6 +75,11 @@ const (
 	MoveDocumentTypeFIREARMSCHAINOFCUSTODY = "FIREARMS_CHAIN_OF_CUSTODY"
 	// MoveDocumentTypePHOTO captures enum value "PHOTO"
 	MoveDocumentTypePHOTO = "PHOTO"
+
+	// POV Doc Types
+
+	// MoveDocumentTypeVEHICLESHIPPINGDOCUMENT captures enum value "VEHICLE_SHIPPING_DOCUMENT"
+	MoveDocumentTypeVEHICLESHIPPINGDOCUMENT MoveDocumentType = "VEHICLE_SHIPPING_DOCUMENT"
 )
 
 // MoveDocumentSaveAction represents actions that can be taken during save
@@ -98,6 +103,7 @@ type MoveDocument struct {
 	PersonallyProcuredMove   PersonallyProcuredMove `belongs_to:"personally_procured_moves"`
 	ShipmentID               *uuid.UUID             `json:"shipment_id" db:"shipment_id"`
 	Shipment                 Shipment               `belongs_to:"shipments"`
+	PrivatelyOwnedVehicleID  *uuid.UUID             `json:"privately_owned_vehicle_id" db:"privately_owned_vehicle_id"`
 	Title                    string                 `json:"title" db:"title"`
 	Status                   MoveDocumentStatus     `json:"status" db:"status"`
 	MoveDocumentType         MoveDocumentType       `json:"move_document_type" db:"move_document_type"`
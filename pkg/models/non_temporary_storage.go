@@ -0,0 +1,171 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gobuffalo/validate/validators"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// NonTemporaryStorageStatus represents the status of an NTS shipment's time in storage
+type NonTemporaryStorageStatus string
+
+const (
+	// NonTemporaryStorageStatusINSTORAGE represents goods that are being held in the storage facility
+	NonTemporaryStorageStatusINSTORAGE NonTemporaryStorageStatus = "IN_STORAGE"
+	// NonTemporaryStorageStatusRELEASEREQUESTED represents goods that have been requested for release to a new address
+	NonTemporaryStorageStatusRELEASEREQUESTED NonTemporaryStorageStatus = "RELEASE_REQUESTED"
+	// NonTemporaryStorageStatusRELEASED represents goods that have left the storage facility
+	NonTemporaryStorageStatusRELEASED NonTemporaryStorageStatus = "RELEASED"
+)
+
+var nonTemporaryStorageStatuses = []string{
+	string(NonTemporaryStorageStatusINSTORAGE),
+	string(NonTemporaryStorageStatusRELEASEREQUESTED),
+	string(NonTemporaryStorageStatusRELEASED),
+}
+
+// NonTemporaryStorage represents the long-term storage of an NTS shipment's goods in a warehouse
+type NonTemporaryStorage struct {
+	ID                   uuid.UUID                 `json:"id" db:"id"`
+	CreatedAt            time.Time                 `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time                 `json:"updated_at" db:"updated_at"`
+	ShipmentID           uuid.UUID                 `json:"shipment_id" db:"shipment_id"`
+	Status               NonTemporaryStorageStatus `json:"status" db:"status"`
+	StorageStartDate     time.Time                 `json:"storage_start_date" db:"storage_start_date"`
+	RequestedReleaseDate *time.Time                `json:"requested_release_date" db:"requested_release_date"`
+	ActualReleaseDate    *time.Time                `json:"actual_release_date" db:"actual_release_date"`
+	ReleaseAddressID     *uuid.UUID                `json:"release_address_id" db:"release_address_id"`
+	StorageFeeCents      *unit.Cents               `json:"storage_fee_cents" db:"storage_fee_cents"`
+	Notes                *string                   `json:"notes" db:"notes"`
+	WarehouseID          string                    `json:"warehouse_id" db:"warehouse_id"`
+	WarehouseName        string                    `json:"warehouse_name" db:"warehouse_name"`
+	WarehouseAddressID   uuid.UUID                 `json:"warehouse_address_id" db:"warehouse_address_id"`
+	WarehousePhone       *string                   `json:"warehouse_phone" db:"warehouse_phone"`
+	WarehouseEmail       *string                   `json:"warehouse_email" db:"warehouse_email"`
+
+	// Associations
+	Shipment         Shipment `belongs_to:"shipment"`
+	WarehouseAddress Address  `belongs_to:"address"`
+	ReleaseAddress   *Address `belongs_to:"address"`
+}
+
+// NonTemporaryStorages is not required by pop and may be deleted
+type NonTemporaryStorages []NonTemporaryStorage
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (n *NonTemporaryStorage) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	verrs := validate.Validate(
+		&validators.UUIDIsPresent{Field: n.ShipmentID, Name: "ShipmentID"},
+		&validators.StringInclusion{Field: string(n.Status), Name: "Status", List: nonTemporaryStorageStatuses},
+		&validators.TimeIsPresent{Field: n.StorageStartDate, Name: "StorageStartDate"},
+		&OptionalTimeIsPresent{Field: n.RequestedReleaseDate, Name: "RequestedReleaseDate"},
+		&OptionalTimeIsPresent{Field: n.ActualReleaseDate, Name: "ActualReleaseDate"},
+		&StringIsNilOrNotBlank{Field: n.Notes, Name: "Notes"},
+		&validators.StringIsPresent{Field: n.WarehouseID, Name: "WarehouseID"},
+		&validators.StringIsPresent{Field: n.WarehouseName, Name: "WarehouseName"},
+		&validators.UUIDIsPresent{Field: n.WarehouseAddressID, Name: "WarehouseAddressID"},
+		&StringIsNilOrNotBlank{Field: n.WarehousePhone, Name: "WarehousePhone"},
+		&StringIsNilOrNotBlank{Field: n.WarehouseEmail, Name: "WarehouseEmail"},
+	)
+
+	if n.ActualReleaseDate != nil && n.ActualReleaseDate.Before(n.StorageStartDate) {
+		verrs.Add("actual_release_date", "ActualReleaseDate cannot be before StorageStartDate.")
+	}
+
+	return verrs, nil
+}
+
+// State Machinery
+// Avoid calling NonTemporaryStorage.Status = ... ever. Use these methods to change the state.
+
+// RequestRelease asks for the stored goods to be released to the given address. Must be in an In Storage state,
+// or already have a release requested so that the request can be changed.
+func (n *NonTemporaryStorage) RequestRelease(requestedReleaseDate time.Time, releaseAddress *Address) error {
+	if n.Status != NonTemporaryStorageStatusINSTORAGE && n.Status != NonTemporaryStorageStatusRELEASEREQUESTED {
+		return errors.Wrap(ErrInvalidTransition, "RequestRelease")
+	}
+	n.Status = NonTemporaryStorageStatusRELEASEREQUESTED
+	n.RequestedReleaseDate = &requestedReleaseDate
+	n.ReleaseAddress = releaseAddress
+	return nil
+}
+
+// Release marks the stored goods as having left the storage facility. Must be in a Release Requested state.
+func (n *NonTemporaryStorage) Release(actualReleaseDate time.Time) error {
+	if n.Status != NonTemporaryStorageStatusRELEASEREQUESTED {
+		return errors.Wrap(ErrInvalidTransition, "Release")
+	}
+	n.Status = NonTemporaryStorageStatusRELEASED
+	n.ActualReleaseDate = &actualReleaseDate
+	return nil
+}
+
+// DaysInStorage returns the number of days the goods have been in storage as of the given date,
+// or in total once they have been released. The day the goods enter storage counts as the first day.
+func (n *NonTemporaryStorage) DaysInStorage(asOf time.Time) int {
+	end := asOf
+	if n.ActualReleaseDate != nil {
+		end = *n.ActualReleaseDate
+	}
+	if end.Before(n.StorageStartDate) {
+		return 0
+	}
+	return int(end.Sub(n.StorageStartDate).Hours()/24) + 1
+}
+
+// FetchNonTemporaryStorageForShipment retrieves the NTS record and its addresses for a shipment
+func FetchNonTemporaryStorageForShipment(tx *pop.Connection, shipmentID uuid.UUID) (*NonTemporaryStorage, error) {
+	var nts NonTemporaryStorage
+	err := tx.Eager("WarehouseAddress", "ReleaseAddress").Where("shipment_id = $1", shipmentID).First(&nts)
+
+	if err != nil {
+		if errors.Cause(err).Error() == recordNotFoundErrorString {
+			return nil, ErrFetchNotFound
+		}
+		return nil, err
+	}
+
+	return &nts, nil
+}
+
+// SaveNonTemporaryStorageAndAddresses saves a NonTemporaryStorage and its addresses atomically.
+func SaveNonTemporaryStorageAndAddresses(db *pop.Connection, nts *NonTemporaryStorage) (*validate.Errors, error) {
+	responseVErrors := validate.NewErrors()
+	var responseError error
+
+	db.Transaction(func(db *pop.Connection) error {
+		transactionError := errors.New("rollback")
+
+		if verrs, err := db.ValidateAndSave(&nts.WarehouseAddress); verrs.HasAny() || err != nil {
+			responseVErrors.Append(verrs)
+			responseError = errors.Wrap(err, "Error saving warehouse address")
+			return transactionError
+		}
+		nts.WarehouseAddressID = nts.WarehouseAddress.ID
+
+		if nts.ReleaseAddress != nil {
+			if verrs, err := db.ValidateAndSave(nts.ReleaseAddress); verrs.HasAny() || err != nil {
+				responseVErrors.Append(verrs)
+				responseError = errors.Wrap(err, "Error saving release address")
+				return transactionError
+			}
+			nts.ReleaseAddressID = &nts.ReleaseAddress.ID
+		}
+
+		if verrs, err := db.ValidateAndSave(nts); verrs.HasAny() || err != nil {
+			responseVErrors.Append(verrs)
+			responseError = errors.Wrap(err, "Error saving non-temporary storage")
+			return transactionError
+		}
+
+		return nil
+	})
+
+	return responseVErrors, responseError
+}
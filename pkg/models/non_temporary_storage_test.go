@@ -0,0 +1,99 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *ModelSuite) TestNonTemporaryStorageValidations() {
+	suite.T().Run("test valid non-temporary storage", func(t *testing.T) {
+		validNTS := testdatagen.MakeDefaultNonTemporaryStorage(suite.DB())
+		expErrors := map[string][]string{}
+		suite.verifyValidationErrors(&validNTS, expErrors)
+	})
+
+	suite.T().Run("test invalid/empty non-temporary storage", func(t *testing.T) {
+		invalidNTS := &models.NonTemporaryStorage{}
+		expErrors := map[string][]string{
+			"shipment_id":          {"ShipmentID can not be blank."},
+			"status":               {"Status is not in the list [IN_STORAGE, RELEASE_REQUESTED, RELEASED]."},
+			"storage_start_date":   {"StorageStartDate can not be blank."},
+			"warehouse_id":         {"WarehouseID can not be blank."},
+			"warehouse_name":       {"WarehouseName can not be blank."},
+			"warehouse_address_id": {"WarehouseAddressID can not be blank."},
+		}
+		suite.verifyValidationErrors(invalidNTS, expErrors)
+	})
+
+	suite.T().Run("test release before storage start", func(t *testing.T) {
+		nts := testdatagen.MakeDefaultNonTemporaryStorage(suite.DB())
+		releaseDate := nts.StorageStartDate.AddDate(0, 0, -1)
+		nts.ActualReleaseDate = &releaseDate
+		expErrors := map[string][]string{
+			"actual_release_date": {"ActualReleaseDate cannot be before StorageStartDate."},
+		}
+		suite.verifyValidationErrors(&nts, expErrors)
+	})
+}
+
+func (suite *ModelSuite) TestNonTemporaryStorageStateMachine() {
+	nts := testdatagen.MakeDefaultNonTemporaryStorage(suite.DB())
+	suite.Equal(models.NonTemporaryStorageStatusINSTORAGE, nts.Status)
+	suite.Equal(models.ShipmentTypeNTS, nts.Shipment.ShipmentType)
+
+	// Can't release goods nobody has asked for
+	err := nts.Release(testdatagen.DateInsidePeakRateCycle)
+	suite.Equal(models.ErrInvalidTransition, errors.Cause(err))
+
+	releaseAddress := testdatagen.MakeDefaultAddress(suite.DB())
+	requestedDate := nts.StorageStartDate.AddDate(0, 6, 0)
+	suite.NoError(nts.RequestRelease(requestedDate, &releaseAddress))
+	suite.Equal(models.NonTemporaryStorageStatusRELEASEREQUESTED, nts.Status)
+
+	// The request can be changed until the goods are released
+	requestedDate = requestedDate.AddDate(0, 0, 7)
+	suite.NoError(nts.RequestRelease(requestedDate, &releaseAddress))
+	suite.Equal(requestedDate, *nts.RequestedReleaseDate)
+
+	suite.NoError(nts.Release(requestedDate))
+	suite.Equal(models.NonTemporaryStorageStatusRELEASED, nts.Status)
+
+	err = nts.RequestRelease(requestedDate, &releaseAddress)
+	suite.Equal(models.ErrInvalidTransition, errors.Cause(err))
+
+	verrs, err := models.SaveNonTemporaryStorageAndAddresses(suite.DB(), &nts)
+	suite.NoError(err)
+	suite.NoVerrs(verrs)
+
+	fetched, err := models.FetchNonTemporaryStorageForShipment(suite.DB(), nts.ShipmentID)
+	suite.NoError(err)
+	suite.Equal(models.NonTemporaryStorageStatusRELEASED, fetched.Status)
+	suite.Equal(releaseAddress.ID, *fetched.ReleaseAddressID)
+	suite.Equal(releaseAddress.StreetAddress1, fetched.ReleaseAddress.StreetAddress1)
+}
+
+func (suite *ModelSuite) TestNonTemporaryStorageDaysInStorage() {
+	start := time.Date(2019, time.January, 10, 0, 0, 0, 0, time.UTC)
+	nts := models.NonTemporaryStorage{StorageStartDate: start}
+
+	suite.Equal(0, nts.DaysInStorage(start.AddDate(0, 0, -1)))
+	suite.Equal(1, nts.DaysInStorage(start))
+	suite.Equal(31, nts.DaysInStorage(start.AddDate(0, 0, 30)))
+
+	// Once released, the release date ends the storage period
+	released := start.AddDate(0, 0, 99)
+	nts.ActualReleaseDate = &released
+	suite.Equal(100, nts.DaysInStorage(start.AddDate(1, 0, 0)))
+}
+
+func (suite *ModelSuite) TestFetchNonTemporaryStorageForShipmentNotFound() {
+	shipment := testdatagen.MakeDefaultShipment(suite.DB())
+
+	_, err := models.FetchNonTemporaryStorageForShipment(suite.DB(), shipment.ID)
+	suite.Equal(models.ErrFetchNotFound, err)
+}
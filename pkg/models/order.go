@@ -243,3 +243,28 @@ func (o *Order) IsCompleteForGBL() bool {
 	}
 	return true
 }
+
+// ordersTypeDetailsWithoutPOV are the orders type details under which a vehicle may not be shipped
+// at government expense, because the assignment is too short or household goods can't be moved
+var ordersTypeDetailsWithoutPOV = map[internalmessages.OrdersTypeDetail]bool{
+	internalmessages.OrdersTypeDetailPCSTDY:                  true,
+	internalmessages.OrdersTypeDetailINSTRUCTION20WEEKS:      true,
+	internalmessages.OrdersTypeDetailHHGPROHIBITED20WEEKS:    true,
+	internalmessages.OrdersTypeDetailHHGRESTRICTEDPROHIBITED: true,
+}
+
+// AuthorizesPOVShipment checks whether these orders allow a privately owned vehicle to be shipped, returning
+// the reason if they don't. Vehicles are only shipped for permanent change of station orders to or from a
+// duty station outside the continental US, so the duty station addresses must be loaded.
+func (o *Order) AuthorizesPOVShipment() (bool, string) {
+	if o.OrdersType != internalmessages.OrdersTypePERMANENTCHANGEOFSTATION {
+		return false, "Only permanent change of station orders authorize a POV shipment."
+	}
+	if o.OrdersTypeDetail != nil && ordersTypeDetailsWithoutPOV[*o.OrdersTypeDetail] {
+		return false, "These orders do not authorize a POV shipment."
+	}
+	if !o.ServiceMember.DutyStation.Address.IsOCONUS() && !o.NewDutyStation.Address.IsOCONUS() {
+		return false, "A POV shipment is only authorized for moves to or from a duty station outside the continental US."
+	}
+	return true, ""
+}
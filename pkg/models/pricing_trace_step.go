@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gobuffalo/validate/validators"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+)
+
+// PricingTraceStepKind represents the type of step recorded in a pricing trace
+type PricingTraceStepKind string
+
+const (
+	// PricingTraceStepKindRATELOOKUP captures enum value "RATE_LOOKUP"
+	PricingTraceStepKindRATELOOKUP PricingTraceStepKind = "RATE_LOOKUP"
+	// PricingTraceStepKindDISCOUNT captures enum value "DISCOUNT"
+	PricingTraceStepKindDISCOUNT PricingTraceStepKind = "DISCOUNT"
+	// PricingTraceStepKindPRORATE captures enum value "PRORATE"
+	PricingTraceStepKindPRORATE PricingTraceStepKind = "PRORATE"
+	// PricingTraceStepKindROUNDING captures enum value "ROUNDING"
+	PricingTraceStepKindROUNDING PricingTraceStepKind = "ROUNDING"
+	// PricingTraceStepKindCHARGE captures enum value "CHARGE"
+	PricingTraceStepKindCHARGE PricingTraceStepKind = "CHARGE"
+)
+
+// PricingTraceStep records a single rate table lookup or calculation used when pricing a shipment line item
+type PricingTraceStep struct {
+	ID                 uuid.UUID            `json:"id" db:"id"`
+	ShipmentLineItemID uuid.UUID            `json:"shipment_line_item_id" db:"shipment_line_item_id"`
+	Position           int                  `json:"position" db:"position"`
+	Kind               PricingTraceStepKind `json:"kind" db:"kind"`
+	Description        string               `json:"description" db:"description"`
+	RateTable          *string              `json:"rate_table" db:"rate_table"`
+	RowID              *uuid.UUID           `json:"row_id" db:"row_id"`
+	Value              string               `json:"value" db:"value"`
+	CreatedAt          time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at" db:"updated_at"`
+
+	// ItemCode is the tariff 400ng item code the step contributed to, if any. It is used to split the
+	// trace of a whole shipment computation across its line items and is not persisted.
+	ItemCode string `json:"-" db:"-"`
+}
+
+// PricingTraceSteps is a slice of PricingTraceStep objects
+type PricingTraceSteps []PricingTraceStep
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (p *PricingTraceStep) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	validKinds := []string{
+		string(PricingTraceStepKindRATELOOKUP),
+		string(PricingTraceStepKindDISCOUNT),
+		string(PricingTraceStepKindPRORATE),
+		string(PricingTraceStepKindROUNDING),
+		string(PricingTraceStepKindCHARGE),
+	}
+
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: p.ShipmentLineItemID, Name: "ShipmentLineItemID"},
+		&validators.StringInclusion{Field: string(p.Kind), Name: "Kind", List: validKinds},
+		&validators.StringIsPresent{Field: p.Description, Name: "Description"},
+	), nil
+}
+
+// SavePricingTraceSteps replaces any existing pricing trace for a shipment line item with the given steps
+func SavePricingTraceSteps(tx *pop.Connection, shipmentLineItemID uuid.UUID, steps PricingTraceSteps) (*validate.Errors, error) {
+	responseVErrors := validate.NewErrors()
+
+	err := tx.RawQuery("DELETE FROM pricing_trace_steps WHERE shipment_line_item_id = $1", shipmentLineItemID).Exec()
+	if err != nil {
+		return responseVErrors, errors.Wrap(err, "Error deleting existing pricing trace steps")
+	}
+
+	for i, step := range steps {
+		step.ID = uuid.Nil
+		step.ShipmentLineItemID = shipmentLineItemID
+		step.Position = i
+		verrs, err := tx.ValidateAndCreate(&step)
+		if err != nil || verrs.HasAny() {
+			responseVErrors.Append(verrs)
+			return responseVErrors, errors.Wrap(err, "Error saving pricing trace step")
+		}
+	}
+
+	return responseVErrors, nil
+}
+
+// FetchPricingTraceStepsForShipmentLineItem returns the pricing trace recorded for a shipment line item, in order
+func FetchPricingTraceStepsForShipmentLineItem(db *pop.Connection, shipmentLineItemID uuid.UUID) (PricingTraceSteps, error) {
+	var steps PricingTraceSteps
+	err := db.Where("shipment_line_item_id = ?", shipmentLineItemID).
+		Order("position asc").
+		All(&steps)
+	if err != nil {
+		return PricingTraceSteps{}, err
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,192 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gobuffalo/validate/validators"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/auth"
+)
+
+// POVStatus represents the status of a privately owned vehicle shipment
+type POVStatus string
+
+const (
+	// POVStatusDRAFT captures enum value "DRAFT"
+	POVStatusDRAFT POVStatus = "DRAFT"
+	// POVStatusSUBMITTED captures enum value "SUBMITTED"
+	POVStatusSUBMITTED POVStatus = "SUBMITTED"
+	// POVStatusAPPROVED captures enum value "APPROVED"
+	POVStatusAPPROVED POVStatus = "APPROVED"
+	// POVStatusDROPPEDOFF captures enum value "DROPPED_OFF"
+	POVStatusDROPPEDOFF POVStatus = "DROPPED_OFF"
+	// POVStatusPICKEDUP captures enum value "PICKED_UP"
+	POVStatusPICKEDUP POVStatus = "PICKED_UP"
+	// POVStatusCANCELED captures enum value "CANCELED"
+	POVStatusCANCELED POVStatus = "CANCELED"
+)
+
+var povStatuses = []string{
+	string(POVStatusDRAFT),
+	string(POVStatusSUBMITTED),
+	string(POVStatusAPPROVED),
+	string(POVStatusDROPPEDOFF),
+	string(POVStatusPICKEDUP),
+	string(POVStatusCANCELED),
+}
+
+// vinExpr matches a 17 character vehicle identification number, which never uses the letters I, O or Q
+const vinExpr = "^[A-HJ-NPR-Z0-9]{17}$"
+
+// VehicleProcessingCenter is a facility where service members drop off and pick up vehicles being shipped
+type VehicleProcessingCenter struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Name      string    `json:"name" db:"name"`
+	Code      string    `json:"code" db:"code"`
+	AddressID uuid.UUID `json:"address_id" db:"address_id"`
+	Address   Address   `belongs_to:"address"`
+}
+
+// VehicleProcessingCenters is not required by pop and may be deleted
+type VehicleProcessingCenters []VehicleProcessingCenter
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (v *VehicleProcessingCenter) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: v.Name, Name: "Name"},
+		&validators.StringIsPresent{Field: v.Code, Name: "Code"},
+		&validators.UUIDIsPresent{Field: v.AddressID, Name: "AddressID"},
+	), nil
+}
+
+// FetchVehicleProcessingCenters returns all vehicle processing centers with their addresses, ordered by name
+func FetchVehicleProcessingCenters(db *pop.Connection) (VehicleProcessingCenters, error) {
+	var centers VehicleProcessingCenters
+	err := db.Eager("Address").Order("name asc").All(&centers)
+	return centers, err
+}
+
+// PrivatelyOwnedVehicle is the shipment of a service member's vehicle through vehicle processing centers
+type PrivatelyOwnedVehicle struct {
+	ID                   uuid.UUID                `json:"id" db:"id"`
+	CreatedAt            time.Time                `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time                `json:"updated_at" db:"updated_at"`
+	MoveID               uuid.UUID                `json:"move_id" db:"move_id"`
+	Move                 Move                     `belongs_to:"move"`
+	Status               POVStatus                `json:"status" db:"status"`
+	VIN                  string                   `json:"vin" db:"vin"`
+	Make                 string                   `json:"make" db:"make"`
+	Model                string                   `json:"model" db:"model"`
+	Year                 int                      `json:"year" db:"year"`
+	RequestedDropOffDate *time.Time               `json:"requested_drop_off_date" db:"requested_drop_off_date"`
+	DropOffVPCID         *uuid.UUID               `json:"drop_off_vpc_id" db:"drop_off_vpc_id"`
+	DropOffVPC           *VehicleProcessingCenter `belongs_to:"vehicle_processing_center"`
+	PickupVPCID          *uuid.UUID               `json:"pickup_vpc_id" db:"pickup_vpc_id"`
+	PickupVPC            *VehicleProcessingCenter `belongs_to:"vehicle_processing_center"`
+	ApproveDate          *time.Time               `json:"approve_date" db:"approve_date"`
+	ActualDropOffDate    *time.Time               `json:"actual_drop_off_date" db:"actual_drop_off_date"`
+	ActualPickupDate     *time.Time               `json:"actual_pickup_date" db:"actual_pickup_date"`
+}
+
+// PrivatelyOwnedVehicles is not required by pop and may be deleted
+type PrivatelyOwnedVehicles []PrivatelyOwnedVehicle
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (p *PrivatelyOwnedVehicle) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: p.MoveID, Name: "MoveID"},
+		&validators.StringInclusion{Field: string(p.Status), Name: "Status", List: povStatuses},
+		&validators.RegexMatch{Field: p.VIN, Name: "VIN", Expr: vinExpr},
+		&validators.StringIsPresent{Field: p.Make, Name: "Make"},
+		&validators.StringIsPresent{Field: p.Model, Name: "Model"},
+		&validators.IntIsGreaterThan{Field: p.Year, Name: "Year", Compared: 1885},
+		&OptionalTimeIsPresent{Field: p.RequestedDropOffDate, Name: "RequestedDropOffDate"},
+		&OptionalTimeIsPresent{Field: p.ActualDropOffDate, Name: "ActualDropOffDate"},
+		&OptionalTimeIsPresent{Field: p.ActualPickupDate, Name: "ActualPickupDate"},
+	), nil
+}
+
+// State Machinery
+// Avoid calling PrivatelyOwnedVehicle.Status = ... ever. Use these methods to change the state.
+
+// Submit marks the POV shipment request for review
+func (p *PrivatelyOwnedVehicle) Submit() error {
+	if p.Status != POVStatusDRAFT {
+		return errors.Wrap(ErrInvalidTransition, "Submit")
+	}
+
+	p.Status = POVStatusSUBMITTED
+	return nil
+}
+
+// Approve approves the POV shipment to go forward. Must be in a Submitted state.
+func (p *PrivatelyOwnedVehicle) Approve(approveDate time.Time) error {
+	if p.Status != POVStatusSUBMITTED {
+		return errors.Wrap(ErrInvalidTransition, "Approve")
+	}
+
+	p.Status = POVStatusAPPROVED
+	p.ApproveDate = &approveDate
+	return nil
+}
+
+// DropOff records the vehicle being left at a vehicle processing center for shipment. Must be in an Approved state.
+func (p *PrivatelyOwnedVehicle) DropOff(actualDropOffDate time.Time, dropOffVPCID uuid.UUID) error {
+	if p.Status != POVStatusAPPROVED {
+		return errors.Wrap(ErrInvalidTransition, "DropOff")
+	}
+
+	p.Status = POVStatusDROPPEDOFF
+	p.ActualDropOffDate = &actualDropOffDate
+	p.DropOffVPCID = &dropOffVPCID
+	return nil
+}
+
+// PickUp records the service member collecting the vehicle at the destination vehicle processing center.
+// Must be in a Dropped Off state.
+func (p *PrivatelyOwnedVehicle) PickUp(actualPickupDate time.Time, pickupVPCID uuid.UUID) error {
+	if p.Status != POVStatusDROPPEDOFF {
+		return errors.Wrap(ErrInvalidTransition, "PickUp")
+	}
+
+	p.Status = POVStatusPICKEDUP
+	p.ActualPickupDate = &actualPickupDate
+	p.PickupVPCID = &pickupVPCID
+	return nil
+}
+
+// Cancel marks the POV shipment as Canceled
+func (p *PrivatelyOwnedVehicle) Cancel() error {
+	if p.Status == POVStatusPICKEDUP || p.Status == POVStatusCANCELED {
+		return errors.Wrap(ErrInvalidTransition, "Cancel")
+	}
+
+	p.Status = POVStatusCANCELED
+	return nil
+}
+
+// FetchPrivatelyOwnedVehicle fetches a POV shipment and its vehicle processing centers, checking that
+// a service member only sees their own
+func FetchPrivatelyOwnedVehicle(db *pop.Connection, session *auth.Session, id uuid.UUID) (*PrivatelyOwnedVehicle, error) {
+	var pov PrivatelyOwnedVehicle
+	err := db.Q().Eager("Move.Orders.ServiceMember", "DropOffVPC.Address", "PickupVPC.Address").Find(&pov, id)
+	if err != nil {
+		if errors.Cause(err).Error() == recordNotFoundErrorString {
+			return nil, ErrFetchNotFound
+		}
+		// Otherwise, it's an unexpected err so we return that.
+		return nil, err
+	}
+
+	if session.IsMilApp() && pov.Move.Orders.ServiceMember.ID != session.ServiceMemberID {
+		return nil, ErrFetchForbidden
+	}
+
+	return &pov, nil
+}
@@ -0,0 +1,122 @@
+package models_test
+
+import (
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/auth"
+	"github.com/transcom/mymove/pkg/gen/internalmessages"
+	. "github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *ModelSuite) TestPrivatelyOwnedVehicleValidation() {
+	pov := &PrivatelyOwnedVehicle{VIN: "1HGCM82633A00435O"}
+
+	expErrors := map[string][]string{
+		"move_id": {"MoveID can not be blank."},
+		"status":  {"Status is not in the list [DRAFT, SUBMITTED, APPROVED, DROPPED_OFF, PICKED_UP, CANCELED]."},
+		"vin":     {"VIN does not match the expected format."},
+		"make":    {"Make can not be blank."},
+		"model":   {"Model can not be blank."},
+		"year":    {"0 is not greater than 1885."},
+	}
+
+	suite.verifyValidationErrors(pov, expErrors)
+}
+
+func (suite *ModelSuite) TestPrivatelyOwnedVehicleStateMachine() {
+	pov := testdatagen.MakeDefaultPrivatelyOwnedVehicle(suite.DB())
+	center := testdatagen.MakeVehicleProcessingCenter(suite.DB(), testdatagen.Assertions{})
+
+	// Can't drop off a vehicle the office hasn't approved
+	err := pov.DropOff(testdatagen.DateInsidePeakRateCycle, center.ID)
+	suite.Equal(ErrInvalidTransition, errors.Cause(err))
+
+	suite.NoError(pov.Submit())
+	suite.NoError(pov.Approve(testdatagen.DateInsidePeakRateCycle))
+	suite.Equal(POVStatusAPPROVED, pov.Status)
+	suite.NotNil(pov.ApproveDate)
+
+	suite.NoError(pov.DropOff(testdatagen.DateInsidePeakRateCycle, center.ID))
+	suite.Equal(center.ID, *pov.DropOffVPCID)
+
+	pickupDate := testdatagen.DateInsidePeakRateCycle.AddDate(0, 0, 45)
+	suite.NoError(pov.PickUp(pickupDate, center.ID))
+	suite.Equal(POVStatusPICKEDUP, pov.Status)
+
+	err = pov.Cancel()
+	suite.Equal(ErrInvalidTransition, errors.Cause(err))
+
+	suite.MustSave(&pov)
+}
+
+func (suite *ModelSuite) TestOrdersAuthorizesPOVShipment() {
+	conus := Address{State: "CA", Country: swag.String("US")}
+	oconus := Address{State: "AK", Country: swag.String("US")}
+
+	orders := Order{OrdersType: internalmessages.OrdersTypePERMANENTCHANGEOFSTATION}
+	orders.ServiceMember.DutyStation.Address = conus
+	orders.NewDutyStation.Address = conus
+
+	authorized, reason := orders.AuthorizesPOVShipment()
+	suite.False(authorized)
+	suite.NotEmpty(reason)
+
+	orders.NewDutyStation.Address = oconus
+	authorized, _ = orders.AuthorizesPOVShipment()
+	suite.True(authorized)
+
+	tdy := internalmessages.OrdersTypeDetailPCSTDY
+	orders.OrdersTypeDetail = &tdy
+	authorized, _ = orders.AuthorizesPOVShipment()
+	suite.False(authorized)
+}
+
+func (suite *ModelSuite) TestCreatePOVChecksOrders() {
+	move := testdatagen.MakeDefaultMove(suite.DB())
+
+	// The default orders move the service member within the continental US
+	pov, verrs, err := move.CreatePOV(suite.DB(), "1HGCM82633A004352", "Honda", "Accord", 2003, nil, nil, nil)
+	suite.NoError(err)
+	suite.True(verrs.HasAny())
+	suite.Nil(pov)
+
+	alaska := testdatagen.MakeDutyStation(suite.DB(), testdatagen.Assertions{
+		DutyStation: DutyStation{Name: "JB Elmendorf-Richardson"},
+		Address: Address{
+			StreetAddress1: "10480 Sijan Ave",
+			City:           "Anchorage",
+			State:          "AK",
+			PostalCode:     "99506",
+		},
+	})
+	move.Orders.NewDutyStationID = alaska.ID
+	move.Orders.NewDutyStation = alaska
+	suite.MustSave(&move.Orders)
+
+	pov, verrs, err = move.CreatePOV(suite.DB(), "1HGCM82633A004352", "Honda", "Accord", 2003, nil, nil, nil)
+	suite.NoError(err)
+	suite.NoVerrs(verrs)
+	suite.Equal(POVStatusDRAFT, pov.Status)
+}
+
+func (suite *ModelSuite) TestMoveSubmitAndApproveIncludePOV() {
+	pov := testdatagen.MakeDefaultPrivatelyOwnedVehicle(suite.DB())
+	move := pov.Move
+
+	suite.NoError(move.Submit())
+	suite.Equal(POVStatusSUBMITTED, move.PrivatelyOwnedVehicles[0].Status)
+
+	suite.NoError(move.Approve())
+	suite.Equal(POVStatusAPPROVED, move.PrivatelyOwnedVehicles[0].Status)
+	suite.NotNil(move.PrivatelyOwnedVehicles[0].ApproveDate)
+
+	verrs, err := SaveMoveDependencies(suite.DB(), &move)
+	suite.NoError(err)
+	suite.NoVerrs(verrs)
+
+	fetched, err := FetchPrivatelyOwnedVehicle(suite.DB(), &auth.Session{}, pov.ID)
+	suite.NoError(err)
+	suite.Equal(POVStatusAPPROVED, fetched.Status)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gobuffalo/pop"
@@ -39,6 +40,34 @@ const (
 	ShipmentStatusCOMPLETED ShipmentStatus = "COMPLETED"
 )
 
+// ShipmentType is the kind of goods carried by a Shipment
+type ShipmentType string
+
+const (
+	// ShipmentTypeHHG captures enum value "HHG", a household goods shipment
+	ShipmentTypeHHG ShipmentType = "HHG"
+	// ShipmentTypeUB captures enum value "UB", an unaccompanied baggage shipment
+	ShipmentTypeUB ShipmentType = "UB"
+	// ShipmentTypeNTS captures enum value "NTS", a shipment into non-temporary storage
+	ShipmentTypeNTS ShipmentType = "NTS"
+)
+
+var validShipmentTypes = []string{
+	string(ShipmentTypeHHG),
+	string(ShipmentTypeUB),
+	string(ShipmentTypeNTS),
+}
+
+const (
+	// ShipmentMarketDHHG is the market for domestic household goods shipments within the continental US
+	ShipmentMarketDHHG = "dHHG"
+	// ShipmentMarketIHHG is the market for international household goods shipments, including those to or from
+	// Alaska and Hawaii
+	ShipmentMarketIHHG = "iHHG"
+	// ShipmentMarketIUB is the market for international unaccompanied baggage shipments
+	ShipmentMarketIUB = "iUB"
+)
+
 var (
 	// ShipmentAssociationsDEFAULT declares the default eager associations for a shipment
 	ShipmentAssociationsDEFAULT = EagerAssociations{
@@ -60,6 +89,7 @@ var (
 type Shipment struct {
 	ID               uuid.UUID      `json:"id" db:"id"`
 	Status           ShipmentStatus `json:"status" db:"status"`
+	ShipmentType     ShipmentType   `json:"shipment_type" db:"shipment_type"`
 	SourceGBLOC      *string        `json:"source_gbloc" db:"source_gbloc"`
 	DestinationGBLOC *string        `json:"destination_gbloc" db:"destination_gbloc"`
 	GBLNumber        *string        `json:"gbl_number" db:"gbl_number"`
@@ -168,13 +198,49 @@ var BaseShipmentLineItems = []BaseShipmentLineItem{
 	},
 }
 
+// UBBaseShipmentLineItems lists all of the mandatory Shipment Line Items for an unaccompanied baggage shipment
+var UBBaseShipmentLineItems = []BaseShipmentLineItem{
+	{
+		Code:        "UBL",
+		Description: "Unaccompanied baggage linehaul charges",
+	},
+	{
+		Code:        "135A",
+		Description: "Origin service fee",
+	},
+	{
+		Code:        "135B",
+		Description: "Destination service",
+	},
+	{
+		Code:        "105A",
+		Description: "Pack Fee",
+	},
+	{
+		Code:        "105C",
+		Description: "Unpack Fee",
+	},
+	{
+		Code:        "16A",
+		Description: "Fuel Surcharge",
+	},
+}
+
 // Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
 func (s *Shipment) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	calendar := dates.NewUSCalendar()
 
+	// An empty shipment type is defaulted to HHG before saving
+	var shipmentType *string
+	if s.ShipmentType != "" {
+		t := string(s.ShipmentType)
+		shipmentType = &t
+	}
+
 	return validate.Validate(
 		&validators.UUIDIsPresent{Field: s.MoveID, Name: "move_id"},
 		&validators.StringIsPresent{Field: string(s.Status), Name: "status"},
+		&OptionalStringInclusion{Field: shipmentType, Name: "shipment_type", List: validShipmentTypes},
 		&OptionalInt64IsPositive{Field: s.EstimatedPackDays, Name: "estimated_pack_days"},
 		&OptionalInt64IsPositive{Field: s.EstimatedTransitDays, Name: "estimated_transit_days"},
 		&OptionalPoundIsNonNegative{Field: s.WeightEstimate, Name: "weight_estimate"},
@@ -219,19 +285,86 @@ func (s *Shipment) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	), nil
 }
 
-// CurrentTransportationServiceProviderID returns the id for the current TSP for a shipment
-// Assume that the last shipmentOffer contains the current TSP
-// This might be a bad assumption, but TSPs can't currently reject offers
+// CurrentTransportationServiceProviderID returns the id for the current TSP for a shipment, which is the TSP
+// that accepted it, or until then the TSP with the pending offer. TSPs that rejected an offer or let it expire
+// are never the current TSP.
 func (s *Shipment) CurrentTransportationServiceProviderID() uuid.UUID {
 	var id uuid.UUID
-	shipmentOffersLen := len(s.ShipmentOffers)
-	if shipmentOffersLen > 0 {
-		lastItemIndex := shipmentOffersLen - 1
-		id = s.ShipmentOffers[lastItemIndex].TransportationServiceProviderID
+	if acceptedOffer, err := s.AcceptedShipmentOffer(); err == nil && acceptedOffer != nil {
+		return acceptedOffer.TransportationServiceProviderID
+	}
+	for _, offer := range s.ShipmentOffers {
+		if offer.Accepted == nil {
+			id = offer.TransportationServiceProviderID
+		}
 	}
 	return id
 }
 
+// PickupZip3 returns the first three digits of the shipment's pickup zip, or nil if it has no pickup address
+func (s *Shipment) PickupZip3() *int {
+	if s.PickupAddress == nil || len(s.PickupAddress.PostalCode) < 3 {
+		return nil
+	}
+	zip3, err := strconv.Atoi(s.PickupAddress.PostalCode[:3])
+	if err != nil {
+		return nil
+	}
+	return &zip3
+}
+
+// IsInternational returns true if the shipment is priced in the international market
+func (s *Shipment) IsInternational() bool {
+	return s.Market != nil && (*s.Market == ShipmentMarketIHHG || *s.Market == ShipmentMarketIUB)
+}
+
+// CodeOfService returns the code of service used to pick the TDL a shipment is awarded from
+func (s *Shipment) CodeOfService() string {
+	switch s.ShipmentType {
+	case ShipmentTypeUB:
+		return "T"
+	case ShipmentTypeNTS:
+		return "J"
+	}
+	return "D"
+}
+
+// BaseLineItems returns the mandatory line items for the shipment's type
+func (s *Shipment) BaseLineItems() []BaseShipmentLineItem {
+	if s.ShipmentType == ShipmentTypeUB {
+		return UBBaseShipmentLineItems
+	}
+	return BaseShipmentLineItems
+}
+
+// LinehaulItemCode returns the tariff 400ng item code the shipment's linehaul charge is billed under
+func (s *Shipment) LinehaulItemCode() string {
+	return s.BaseLineItems()[0].Code
+}
+
+// ShipmentMarketForAddresses returns the market for a shipment of the given type between two addresses, which is
+// international if either address is outside the continental US
+func ShipmentMarketForAddresses(shipmentType ShipmentType, origin *Address, destination *Address) string {
+	if origin.IsOCONUS() || destination.IsOCONUS() {
+		if shipmentType == ShipmentTypeUB {
+			return ShipmentMarketIUB
+		}
+		return ShipmentMarketIHHG
+	}
+	return ShipmentMarketDHHG
+}
+
+// UpdateMarket sets the shipment's market from its pickup and delivery addresses, falling back to the new duty
+// station for a shipment without a delivery address. Move.Orders.NewDutyStation.Address must be loaded.
+func (s *Shipment) UpdateMarket() {
+	destination := s.DeliveryAddress
+	if destination == nil {
+		destination = &s.Move.Orders.NewDutyStation.Address
+	}
+	market := ShipmentMarketForAddresses(s.ShipmentType, s.PickupAddress, destination)
+	s.Market = &market
+}
+
 // State Machinery
 // Avoid calling Shipment.Status = ... ever. Use these methods to change the state.
 
@@ -323,6 +456,10 @@ func (s *Shipment) Complete() error {
 
 // BeforeSave will run before each create/update of a Shipment.
 func (s *Shipment) BeforeSave(tx *pop.Connection) error {
+	if s.ShipmentType == "" {
+		s.ShipmentType = ShipmentTypeHHG
+	}
+
 	// To be safe, we will always try to determine the correct TDL anytime a shipment record
 	// is created/updated.
 	trafficDistributionList, err := s.DetermineTrafficDistributionList(tx)
@@ -359,7 +496,7 @@ func (s *Shipment) DetermineTrafficDistributionList(db *pop.Connection) (*Traffi
 	// To look up a TDL, we need to try to determine the following:
 	// 1) source_rate_area: Find using the postal code of the pickup address.
 	// 2) destination_region: Find using the postal code of the destination duty station.
-	// 3) code_of_service: For now, always assume "D".
+	// 3) code_of_service: Determined by the shipment type.
 
 	if s.PickupAddressID == nil {
 		// If we're in draft mode, it's OK to not have a pickup address yet.
@@ -399,8 +536,7 @@ func (s *Shipment) DetermineTrafficDistributionList(db *pop.Connection) (*Traffi
 		return nil, errors.Wrapf(err, "Could not fetch region for zip %s", destinationZip)
 	}
 
-	// Code of service -> hard-coded for now.
-	codeOfService := "D"
+	codeOfService := s.CodeOfService()
 
 	// Fetch the TDL (or create it if it doesn't exist already).
 	trafficDistributionList, err := FetchOrCreateTDL(db, rateArea, region, codeOfService)
@@ -557,21 +693,66 @@ func (s *Shipment) AssignGBLNumber(db *pop.Connection) error {
 	return nil
 }
 
-// FetchUnofferedShipments will return submitted shipments that do not already have a shipment offer.
+// FetchUnofferedShipments will return submitted shipments that do not have a pending or accepted shipment offer.
 func FetchUnofferedShipments(db *pop.Connection) (Shipments, error) {
 	var shipments Shipments
+	// A shipment goes back in the queue when a TSP rejects its offer or lets it expire, as long as it
+	// has no offer still waiting on a TSP. Shipments with only administrative offers aren't retried.
 	err := db.Q().
-		LeftJoin("shipment_offers", "shipments.id=shipment_offers.shipment_id").
 		Where("shipments.status = ?", ShipmentStatusSUBMITTED).
-		Where("shipment_offers.id is null").
+		Where(`NOT EXISTS (SELECT 1 FROM shipment_offers
+			WHERE shipment_offers.shipment_id = shipments.id
+			AND shipment_offers.administrative_shipment = false
+			AND (shipment_offers.accepted IS NULL OR shipment_offers.accepted = true))`).
+		Where(`(NOT EXISTS (SELECT 1 FROM shipment_offers WHERE shipment_offers.shipment_id = shipments.id)
+			OR EXISTS (SELECT 1 FROM shipment_offers
+				WHERE shipment_offers.shipment_id = shipments.id
+				AND shipment_offers.administrative_shipment = false
+				AND shipment_offers.accepted = false))`).
 		All(&shipments)
 	if err != nil {
 		return nil, err
 	}
 
+	// Blackouts can be restricted to the zip3 a shipment is picked up in
+	if err := loadPickupAddresses(db, shipments); err != nil {
+		return nil, err
+	}
+
 	return shipments, err
 }
 
+// loadPickupAddresses sets the pickup addresses of shipments with a single query, rather than one per shipment
+func loadPickupAddresses(db *pop.Connection, shipments Shipments) error {
+	var ids []interface{}
+	for _, shipment := range shipments {
+		if shipment.PickupAddressID != nil {
+			ids = append(ids, *shipment.PickupAddressID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var addresses Addresses
+	if err := db.Where("id IN (?)", ids...).All(&addresses); err != nil {
+		return errors.Wrap(err, "Pickup addresses query failed")
+	}
+	addressesByID := make(map[uuid.UUID]Address, len(addresses))
+	for _, address := range addresses {
+		addressesByID[address.ID] = address
+	}
+	for i := range shipments {
+		if shipments[i].PickupAddressID == nil {
+			continue
+		}
+		if address, ok := addressesByID[*shipments[i].PickupAddressID]; ok {
+			shipments[i].PickupAddress = &address
+		}
+	}
+	return nil
+}
+
 // FetchShipmentsByTSP looks up all shipments belonging to a TSP ID
 func FetchShipmentsByTSP(tx *pop.Connection, tspID uuid.UUID, status []string, orderBy *string) ([]Shipment, error) {
 
@@ -779,6 +960,35 @@ func AcceptShipmentForTSP(db *pop.Connection, tspID uuid.UUID, shipmentID uuid.U
 	return saveShipmentAndOffer(db, shipment, shipmentOffer)
 }
 
+// RejectShipmentForTSP rejects a shipment_offer and returns the shipment to the award queue to be offered to another TSP
+func RejectShipmentForTSP(db *pop.Connection, tspID uuid.UUID, shipmentID uuid.UUID, rejectionReason string) (*Shipment, *ShipmentOffer, *validate.Errors, error) {
+
+	// Get the Shipment and Shipment Offer
+	shipment, err := FetchShipmentByTSP(db, tspID, shipmentID)
+	if err != nil {
+		return shipment, nil, nil, err
+	}
+
+	shipmentOffer, err := FetchShipmentOfferByTSP(db, tspID, shipmentID)
+	if err != nil {
+		return shipment, shipmentOffer, nil, err
+	}
+
+	// Reject the Shipment Offer and put the Shipment back in the queue
+	err = shipment.Reject()
+	if err != nil {
+		return shipment, shipmentOffer, nil, err
+	}
+
+	err = shipmentOffer.Reject(rejectionReason)
+	if err != nil {
+		return shipment, shipmentOffer, nil, err
+	}
+
+	verrs, err := saveDeclinedShipmentOffer(db, shipment, shipmentOffer)
+	return shipment, shipmentOffer, verrs, err
+}
+
 // SaveDestinationAddress saves a DestinationAddressOnAcceptance
 func SaveDestinationAddress(db *pop.Connection, shipment *Shipment) (*validate.Errors, error) {
 	verrs, err := db.ValidateAndSave(shipment.DestinationAddressOnAcceptance)
@@ -886,6 +1096,14 @@ func (s *Shipment) SaveShipmentAndPricingInfo(db *pop.Connection, baselineLineIt
 					lineItem.ShipmentID, lineItem.Tariff400ngItemID)
 				return transactionError
 			}
+			if len(lineItem.PricingTrace) > 0 {
+				verrs, err = SavePricingTraceSteps(tx, lineItem.ID, lineItem.PricingTrace)
+				if err != nil || verrs.HasAny() {
+					responseVErrors.Append(verrs)
+					responseError = errors.Wrapf(err, "Error saving pricing trace for shipment line item %s", lineItem.ID)
+					return transactionError
+				}
+			}
 		}
 
 		return nil
@@ -909,7 +1127,11 @@ func (s *Shipment) createUniqueShipmentLineItem(tx *pop.Connection, lineItem Shi
 		}
 		return validate.NewErrors(), errors.New("Line item already exists for item " + whichCode)
 	}
-	return tx.ValidateAndCreate(&lineItem)
+	verrs, err := tx.ValidateAndCreate(&lineItem)
+	if err != nil || verrs.HasAny() {
+		return verrs, err
+	}
+	return SavePricingTraceSteps(tx, lineItem.ID, lineItem.PricingTrace)
 }
 
 // FetchShipmentLineItemsByItemID attempts to find line items for this shipment that have a given line item code.
@@ -978,6 +1200,11 @@ func FindBaseShipmentLineItem(code string) bool {
 			return true
 		}
 	}
+	for _, base := range UBBaseShipmentLineItems {
+		if code == base.Code {
+			return true
+		}
+	}
 	return false
 }
 
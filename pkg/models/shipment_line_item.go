@@ -70,6 +70,10 @@ type ShipmentLineItem struct {
 	Address             Address                    `belongs_to:"addresses"`
 	CreatedAt           time.Time                  `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time                  `json:"updated_at" db:"updated_at"`
+
+	// PricingTrace holds the steps used to compute AmountCents when the item is priced by the rate engine.
+	// It is saved separately, see SavePricingTraceSteps.
+	PricingTrace PricingTraceSteps `json:"-" db:"-"`
 }
 
 // ShipmentLineItems is not required by pop and may be deleted
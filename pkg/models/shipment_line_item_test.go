@@ -122,3 +122,51 @@ func (suite *ModelSuite) TestDestroyInvoicedShipmentLineItemFails() {
 	// Then: The destroy action fails
 	suite.EqualError(err, models.ErrDestroyForbidden.Error())
 }
+
+func (suite *ModelSuite) TestSavePricingTraceSteps() {
+	lineItem := testdatagen.MakeDefaultShipmentLineItem(suite.DB())
+	table := "tariff400ng_item_rates"
+	rowID := uuid.Must(uuid.NewV4())
+
+	steps := models.PricingTraceSteps{
+		{
+			Kind:        models.PricingTraceStepKindRATELOOKUP,
+			Description: "Item 4A rate",
+			RateTable:   &table,
+			RowID:       &rowID,
+			Value:       "1000",
+		},
+		{
+			Kind:        models.PricingTraceStepKindCHARGE,
+			Description: "Item 4A charge",
+			Value:       "1000",
+		},
+	}
+
+	verrs, err := models.SavePricingTraceSteps(suite.DB(), lineItem.ID, steps)
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+
+	// Saving again replaces the existing trace
+	verrs, err = models.SavePricingTraceSteps(suite.DB(), lineItem.ID, steps[1:])
+	suite.NoError(err)
+	suite.False(verrs.HasAny())
+
+	fetched, err := models.FetchPricingTraceStepsForShipmentLineItem(suite.DB(), lineItem.ID)
+	if suite.NoError(err) && suite.Len(fetched, 1) {
+		suite.Equal(0, fetched[0].Position)
+		suite.Equal(models.PricingTraceStepKindCHARGE, fetched[0].Kind)
+		suite.Nil(fetched[0].RateTable)
+	}
+}
+
+func (suite *ModelSuite) TestPricingTraceStepValidation() {
+	step := models.PricingTraceStep{Kind: "GUESS"}
+
+	expErrors := map[string][]string{
+		"shipment_line_item_id": {"ShipmentLineItemID can not be blank."},
+		"kind":                  {"Kind is not in the list [RATE_LOOKUP, DISCOUNT, PRORATE, ROUNDING, CHARGE]."},
+		"description":           {"Description can not be blank."},
+	}
+	suite.verifyValidationErrors(&step, expErrors)
+}
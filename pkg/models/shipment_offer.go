@@ -26,6 +26,9 @@ type ShipmentOffer struct {
 	AdministrativeShipment                     bool                                     `json:"administrative_shipment" db:"administrative_shipment"`
 	Accepted                                   *bool                                    `json:"accepted" db:"accepted"`
 	RejectionReason                            *string                                  `json:"rejection_reason" db:"rejection_reason"`
+	AwardQueuePolicyID                         *uuid.UUID                               `json:"award_queue_policy_id" db:"award_queue_policy_id"`
+	AcceptBy                                   *time.Time                               `json:"accept_by" db:"accept_by"`
+	ExpiredAt                                  *time.Time                               `json:"expired_at" db:"expired_at"`
 }
 
 // String is not required by pop and may be deleted
@@ -76,46 +79,183 @@ func (so *ShipmentOffer) Reject(rejectionReason string) error {
 	return nil
 }
 
+// Expire marks a Shipment Offer the TSP didn't respond to in time as not accepted.
+func (so *ShipmentOffer) Expire(expiredAt time.Time) error {
+	if so.Accepted != nil {
+		return errors.Wrap(ErrInvalidTransition, "Expire")
+	}
+	notAccepted := false
+	so.Accepted = &notAccepted
+	so.ExpiredAt = &expiredAt
+	return nil
+}
+
 // CreateShipmentOffer connects a shipment to a transportation service provider. This
-// function assumes that the match has been validated by the caller.
+// function assumes that the match has been validated by the caller. The offer records the
+// award queue policy in effect, and must be accepted within the policy's acceptance window.
 func CreateShipmentOffer(tx *pop.Connection,
 	shipmentID uuid.UUID,
 	tspID uuid.UUID,
 	tsppID uuid.UUID,
-	administrativeShipment bool) (*ShipmentOffer, error) {
+	administrativeShipment bool,
+	policy AwardQueuePolicy) (*ShipmentOffer, error) {
 
 	shipmentOffer := ShipmentOffer{
 		ShipmentID:                                 shipmentID,
 		TransportationServiceProviderID:            tspID,
 		TransportationServiceProviderPerformanceID: tsppID,
 		AdministrativeShipment:                     administrativeShipment,
+		AwardQueuePolicyID:                         policy.PolicyID(),
+	}
+	// Administrative shipments don't award the shipment, so there is nothing for the TSP to accept
+	if !administrativeShipment {
+		acceptBy := time.Now().Add(policy.OfferAcceptanceWindow())
+		shipmentOffer.AcceptBy = &acceptBy
 	}
 	_, err := tx.ValidateAndSave(&shipmentOffer)
 
 	return &shipmentOffer, err
 }
 
-// FetchShipmentOfferByTSP Fetches a shipment belonging to a TSP ID by Shipment ID
+// FetchDeclinedShipmentOffers returns the offers of the given shipments that TSPs rejected or let expire.
+// Administrative offers are left out, since there was nothing for the TSP to accept.
+func FetchDeclinedShipmentOffers(db *pop.Connection, shipmentIDs []uuid.UUID) (ShipmentOffers, error) {
+	var offers ShipmentOffers
+	if len(shipmentIDs) == 0 {
+		return offers, nil
+	}
+
+	ids := make([]interface{}, len(shipmentIDs))
+	for i, id := range shipmentIDs {
+		ids[i] = id
+	}
+	err := db.Where("shipment_id IN (?)", ids...).
+		Where("administrative_shipment = false").
+		Where("accepted = false").
+		All(&offers)
+	if err != nil {
+		return nil, errors.Wrap(err, "Declined shipment offers query failed")
+	}
+
+	return offers, nil
+}
+
+// FetchShipmentOfferByTSP fetches the offer of a shipment to a TSP that the TSP hasn't yet accepted or rejected.
+// A shipment can be offered to the same TSP again after it was declined, in which case the newest offer is returned.
 func FetchShipmentOfferByTSP(tx *pop.Connection, tspID uuid.UUID, shipmentID uuid.UUID) (*ShipmentOffer, error) {
 
 	shipmentOffers := []ShipmentOffer{}
 
 	err := tx.
 		Where("shipment_offers.transportation_service_provider_id = $1 and shipment_offers.shipment_id = $2", tspID, shipmentID).
+		Where("shipment_offers.accepted IS NULL").
+		Order("shipment_offers.created_at DESC").
 		All(&shipmentOffers)
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Unlikely that we see more than one but to be safe this will error.
-	if len(shipmentOffers) != 1 {
+	if len(shipmentOffers) == 0 {
 		return nil, ErrFetchNotFound
 	}
 
 	return &shipmentOffers[0], err
 }
 
+// FetchExpiredShipmentOffers returns the offers for awarded shipments that weren't accepted by their deadline
+func FetchExpiredShipmentOffers(db *pop.Connection, now time.Time) (ShipmentOffers, error) {
+	var offers ShipmentOffers
+	err := db.Q().
+		Join("shipments", "shipments.id = shipment_offers.shipment_id").
+		Where("shipments.status = ?", ShipmentStatusAWARDED).
+		Where("shipment_offers.accepted IS NULL").
+		Where("shipment_offers.administrative_shipment = false").
+		Where("shipment_offers.accept_by < ?", now).
+		All(&offers)
+
+	return offers, err
+}
+
+// FetchShipmentOffersForTDL returns the offers made in a TDL for shipments booked between start and end, inclusive,
+// oldest first. The book date picks the performance period the award queue offers a shipment in.
+// Administrative offers to TSPs in a blackout period are included.
+func FetchShipmentOffersForTDL(db *pop.Connection, tdlID uuid.UUID, start time.Time, end time.Time) (ShipmentOffers, error) {
+	var offers ShipmentOffers
+	err := db.Q().
+		Join("transportation_service_provider_performances AS tspp",
+			"tspp.id = shipment_offers.transportation_service_provider_performance_id").
+		Join("shipments", "shipments.id = shipment_offers.shipment_id").
+		Where("tspp.traffic_distribution_list_id = ?", tdlID).
+		Where("shipments.book_date >= ?", start).
+		Where("shipments.book_date <= ?", end).
+		Order("shipment_offers.created_at ASC").
+		All(&offers)
+
+	return offers, err
+}
+
+// saveDeclinedShipmentOffer saves an offer the TSP rejected or let expire, puts the shipment back in the award queue,
+// and counts the declined offer against the TSP's performance record. A caller that is already in a transaction,
+// like the award queue, owns it; committing it here would release the award queue's lock and undo a dry run.
+func saveDeclinedShipmentOffer(db *pop.Connection, shipment *Shipment, offer *ShipmentOffer) (*validate.Errors, error) {
+	if db.TX != nil {
+		return saveDeclinedShipmentOfferWithoutTransaction(db, shipment, offer)
+	}
+
+	responseVErrors := validate.NewErrors()
+	var responseError error
+	db.Transaction(func(db *pop.Connection) error {
+		transactionError := errors.New("rollback")
+
+		responseVErrors, responseError = saveDeclinedShipmentOfferWithoutTransaction(db, shipment, offer)
+		if responseVErrors.HasAny() || responseError != nil {
+			return transactionError
+		}
+
+		return nil
+	})
+
+	return responseVErrors, responseError
+}
+
+func saveDeclinedShipmentOfferWithoutTransaction(db *pop.Connection, shipment *Shipment, offer *ShipmentOffer) (*validate.Errors, error) {
+	responseVErrors := validate.NewErrors()
+
+	if verrs, err := db.ValidateAndUpdate(shipment); verrs.HasAny() || err != nil {
+		responseVErrors.Append(verrs)
+		return responseVErrors, errors.Wrapf(err, "Error changing shipment status to %s", shipment.Status)
+	}
+
+	if verrs, err := db.ValidateAndUpdate(offer); verrs.HasAny() || err != nil {
+		responseVErrors.Append(verrs)
+		return responseVErrors, errors.Wrap(err, "Error declining shipment offer")
+	}
+
+	if _, err := RecordDeclinedOfferOnTSPPerformance(db, offer.TransportationServiceProviderPerformanceID, offer.ExpiredAt != nil); err != nil {
+		return responseVErrors, errors.Wrap(err, "Error recording declined offer on TSP performance")
+	}
+
+	return responseVErrors, nil
+}
+
+// ExpireShipmentOffer expires an offer that wasn't accepted in time and returns its shipment to the award queue
+func ExpireShipmentOffer(db *pop.Connection, offer *ShipmentOffer, now time.Time) (*validate.Errors, error) {
+	var shipment Shipment
+	if err := db.Find(&shipment, offer.ShipmentID); err != nil {
+		return nil, err
+	}
+
+	if err := shipment.Reject(); err != nil {
+		return nil, err
+	}
+	if err := offer.Expire(now); err != nil {
+		return nil, err
+	}
+
+	return saveDeclinedShipmentOffer(db, &shipment, offer)
+}
+
 // Accepted returns the accepted shipment offers from a slice of shipment offers.
 func (so *ShipmentOffers) Accepted() (ShipmentOffers, error) {
 	var acceptedOffers ShipmentOffers
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
 
 	"github.com/transcom/mymove/pkg/dates"
 	. "github.com/transcom/mymove/pkg/models"
@@ -48,7 +49,7 @@ func (suite *ModelSuite) Test_CreateShipmentOffer() {
 		},
 	})
 
-	shipmentOffer, err := CreateShipmentOffer(suite.DB(), shipment.ID, tsp.ID, tspp.ID, false)
+	shipmentOffer, err := CreateShipmentOffer(suite.DB(), shipment.ID, tsp.ID, tspp.ID, false, DefaultAwardQueuePolicy())
 	suite.Nil(err, "error making ShipmentOffer")
 
 	expectedShipmentOffer := ShipmentOffer{}
@@ -79,6 +80,90 @@ func (suite *ModelSuite) TestShipmentOfferStateMachine() {
 	suite.Equal("DO NOT WANT", *shipmentOffer.RejectionReason)
 }
 
+func (suite *ModelSuite) TestExpireShipmentOffer() {
+	now := time.Now()
+	deadline := now.Add(-time.Hour)
+	expiredOffer := testdatagen.MakeShipmentOffer(suite.DB(), testdatagen.Assertions{
+		Shipment: Shipment{
+			Status: ShipmentStatusAWARDED,
+		},
+		ShipmentOffer: ShipmentOffer{
+			AcceptBy: &deadline,
+		},
+	})
+
+	// Offers that still have time to be accepted aren't expired
+	upcoming := now.Add(time.Hour)
+	testdatagen.MakeShipmentOffer(suite.DB(), testdatagen.Assertions{
+		Shipment: Shipment{
+			Status: ShipmentStatusAWARDED,
+		},
+		ShipmentOffer: ShipmentOffer{
+			AcceptBy: &upcoming,
+		},
+	})
+
+	offers, err := FetchExpiredShipmentOffers(suite.DB(), now)
+	suite.NoError(err)
+	suite.Len(offers, 1)
+	suite.Equal(expiredOffer.ID, offers[0].ID)
+
+	verrs, err := ExpireShipmentOffer(suite.DB(), &offers[0], now)
+	suite.NoError(err)
+	suite.NoVerrs(verrs)
+	suite.False(*offers[0].Accepted)
+	suite.NotNil(offers[0].ExpiredAt)
+
+	// Expiring again is an invalid transition
+	_, err = ExpireShipmentOffer(suite.DB(), &offers[0], now)
+	suite.Equal(ErrInvalidTransition, errors.Cause(err))
+
+	// The TSP's performance record counts the expired offer
+	var tspp TransportationServiceProviderPerformance
+	suite.NoError(suite.DB().Find(&tspp, expiredOffer.TransportationServiceProviderPerformanceID))
+	suite.Equal(1, tspp.ExpiredOfferCount)
+	suite.Equal(0, tspp.RejectedOfferCount)
+
+	// And the shipment is back in the award queue
+	shipments, err := FetchUnofferedShipments(suite.DB())
+	suite.NoError(err)
+	suite.Len(shipments, 1)
+	suite.Equal(expiredOffer.ShipmentID, shipments[0].ID)
+	suite.Equal(ShipmentStatusSUBMITTED, shipments[0].Status)
+}
+
+func (suite *ModelSuite) TestFetchShipmentOfferByTSP() {
+	tsp := testdatagen.MakeDefaultTSP(suite.DB())
+	shipment := testdatagen.MakeShipment(suite.DB(), testdatagen.Assertions{
+		Shipment: Shipment{
+			Status: ShipmentStatusAWARDED,
+		},
+	})
+	offerAssertions := testdatagen.Assertions{
+		ShipmentOffer: ShipmentOffer{
+			Shipment:                        shipment,
+			ShipmentID:                      shipment.ID,
+			TransportationServiceProvider:   tsp,
+			TransportationServiceProviderID: tsp.ID,
+			Accepted:                        BoolPointer(false),
+		},
+	}
+	testdatagen.MakeShipmentOffer(suite.DB(), offerAssertions)
+
+	// A TSP that declined the shipment has no offer to accept or reject
+	_, err := FetchShipmentOfferByTSP(suite.DB(), tsp.ID, shipment.ID)
+	suite.Equal(ErrFetchNotFound, err)
+
+	// Until it's offered the shipment again
+	offerAssertions.ShipmentOffer.Accepted = nil
+	pendingOffer := testdatagen.MakeShipmentOffer(suite.DB(), offerAssertions)
+
+	offer, err := FetchShipmentOfferByTSP(suite.DB(), tsp.ID, shipment.ID)
+	if suite.NoError(err) {
+		suite.Equal(pendingOffer.ID, offer.ID)
+	}
+}
+
 func (suite *ModelSuite) TestAccepted() {
 	// Trying a nil slice of shipment offers.
 	var shipmentOffers ShipmentOffers
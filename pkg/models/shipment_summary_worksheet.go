@@ -233,11 +233,11 @@ func FetchDataShipmentSummaryWorksheetFormData(db *pop.Connection, session *auth
 // a PPMs remaining entitlement weight is equal to total entitlement - hhg weight
 func CalculateRemainingPPMEntitlement(move Move, totalEntitlement unit.Pound) (unit.Pound, error) {
 	var hhgActualWeight unit.Pound
-	if len(move.Shipments) > 0 {
-		if move.Shipments[0].NetWeight == nil {
-			return hhgActualWeight, errors.Errorf("Shipment %s does not have NetWeight", move.Shipments[0].ID)
+	for _, shipment := range move.Shipments {
+		if shipment.NetWeight == nil {
+			return hhgActualWeight, errors.Errorf("Shipment %s does not have NetWeight", shipment.ID)
 		}
-		hhgActualWeight = *move.Shipments[0].NetWeight
+		hhgActualWeight += *shipment.NetWeight
 	}
 
 	var ppmActualWeight unit.Pound
@@ -97,7 +97,7 @@ func (suite *ModelSuite) Test_FetchUnofferedShipments() {
 		},
 	})
 	tspp := testdatagen.MakeDefaultTSPPerformance(suite.DB())
-	CreateShipmentOffer(suite.DB(), shipment.ID, tspp.TransportationServiceProviderID, tspp.ID, false)
+	CreateShipmentOffer(suite.DB(), shipment.ID, tspp.TransportationServiceProviderID, tspp.ID, false, DefaultAwardQueuePolicy())
 	shipments, err := FetchUnofferedShipments(suite.DB())
 
 	// Expect only unassigned shipment returned
@@ -274,6 +274,23 @@ func (suite *ModelSuite) TestCurrentTransportationServiceProviderID() {
 	suite.Equal(tsp.ID, reloadShipment.CurrentTransportationServiceProviderID(), "expected ids to be equal")
 }
 
+// TestCurrentTransportationServiceProviderIDSkipsDeclinedOffers tests that a TSP that declined a shipment isn't its current TSP
+func (suite *ModelSuite) TestCurrentTransportationServiceProviderIDSkipsDeclinedOffers() {
+	declinedTSPID := uuid.Must(uuid.NewV4())
+	pendingTSPID := uuid.Must(uuid.NewV4())
+	shipment := Shipment{
+		Status: ShipmentStatusAWARDED,
+		ShipmentOffers: ShipmentOffers{
+			{TransportationServiceProviderID: pendingTSPID},
+			{TransportationServiceProviderID: declinedTSPID, Accepted: BoolPointer(false)},
+		},
+	}
+	suite.Equal(pendingTSPID, shipment.CurrentTransportationServiceProviderID())
+
+	shipment.ShipmentOffers[0].Accepted = BoolPointer(false)
+	suite.Equal(uuid.Nil, shipment.CurrentTransportationServiceProviderID())
+}
+
 // TestShipmentAssignGBLNumber tests that a GBL number is created correctly
 func (suite *ModelSuite) TestShipmentAssignGBLNumber() {
 	testData := [][]string{
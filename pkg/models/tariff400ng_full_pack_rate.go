@@ -44,6 +44,16 @@ func (t *Tariff400ngFullPackRate) Validate(tx *pop.Connection) (*validate.Errors
 // FetchTariff400ngFullPackRateCents returns the full unpack rate for a service
 // schedule and weight.
 func FetchTariff400ngFullPackRateCents(tx *pop.Connection, weight unit.Pound, schedule int, date time.Time) (unit.Cents, error) {
+	rate, err := FetchTariff400ngFullPackRate(tx, weight, schedule, date)
+	if err != nil {
+		return 0, err
+	}
+	return rate.RateCents, nil
+}
+
+// FetchTariff400ngFullPackRate returns the tariff400ng_full_pack_rates row for a service
+// schedule and weight.
+func FetchTariff400ngFullPackRate(tx *pop.Connection, weight unit.Pound, schedule int, date time.Time) (Tariff400ngFullPackRate, error) {
 	rate := Tariff400ngFullPackRate{}
 
 	sql := `SELECT
@@ -61,7 +71,7 @@ func FetchTariff400ngFullPackRateCents(tx *pop.Connection, weight unit.Pound, sc
 
 	err := tx.RawQuery(sql, schedule, weight, date).First(&rate)
 	if err != nil {
-		return 0, errors.Wrap(err, "could not find a matching Tariff400ngFullPackRate")
+		return rate, errors.Wrap(err, "could not find a matching Tariff400ngFullPackRate")
 	}
-	return rate.RateCents, nil
+	return rate, nil
 }
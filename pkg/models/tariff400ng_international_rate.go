@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/gobuffalo/validate/validators"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// Tariff400ngInternationalRate describes the port-to-port rate paid per CWT to move goods between
+// an origin and destination rate area when either is outside the continental US.
+type Tariff400ngInternationalRate struct {
+	ID                  uuid.UUID  `json:"id" db:"id"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at" db:"updated_at"`
+	OriginRateArea      string     `json:"origin_rate_area" db:"origin_rate_area"`
+	DestinationRateArea string     `json:"destination_rate_area" db:"destination_rate_area"`
+	DistanceMiles       int        `json:"distance_miles" db:"distance_miles"`
+	WeightLbsLower      unit.Pound `json:"weight_lbs_lower" db:"weight_lbs_lower"`
+	WeightLbsUpper      unit.Pound `json:"weight_lbs_upper" db:"weight_lbs_upper"`
+	RateCents           unit.Cents `json:"rate_cents" db:"rate_cents"`
+	EffectiveDateLower  time.Time  `json:"effective_date_lower" db:"effective_date_lower"`
+	EffectiveDateUpper  time.Time  `json:"effective_date_upper" db:"effective_date_upper"`
+}
+
+// Tariff400ngInternationalRates is not required by pop and may be deleted
+type Tariff400ngInternationalRates []Tariff400ngInternationalRate
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+// This method is not required and may be deleted.
+func (t *Tariff400ngInternationalRate) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.RegexMatch{Field: t.OriginRateArea, Name: "OriginRateArea", Expr: rateAreaFormat},
+		&validators.RegexMatch{Field: t.DestinationRateArea, Name: "DestinationRateArea", Expr: rateAreaFormat},
+		&validators.IntIsPresent{Field: t.DistanceMiles, Name: "DistanceMiles"},
+		&validators.IntIsGreaterThan{Field: t.RateCents.Int(), Name: "RateCents", Compared: -1},
+		&validators.IntIsLessThan{Field: t.WeightLbsLower.Int(), Name: "WeightLbsLower",
+			Compared: t.WeightLbsUpper.Int()},
+		&validators.TimeAfterTime{
+			FirstTime: t.EffectiveDateUpper, FirstName: "EffectiveDateUpper",
+			SecondTime: t.EffectiveDateLower, SecondName: "EffectiveDateLower"},
+	), nil
+}
+
+// FetchTariff400ngInternationalRate returns the port-to-port rate between two rate areas for a weight on the given date
+func FetchTariff400ngInternationalRate(tx *pop.Connection, originRateArea string, destinationRateArea string, weight unit.Pound, date time.Time) (Tariff400ngInternationalRate, error) {
+	var rate Tariff400ngInternationalRate
+	query := `
+		SELECT * from tariff400ng_international_rates
+		WHERE
+			origin_rate_area = $1
+			AND destination_rate_area = $2
+			AND weight_lbs_lower <= $3
+			AND weight_lbs_upper > $3
+			AND effective_date_lower <= $4
+			AND effective_date_upper > $4
+	`
+
+	err := tx.RawQuery(query, originRateArea, destinationRateArea, weight.Int(), date).First(&rate)
+	if err != nil {
+		return rate, errors.Wrapf(err, "could not find an international rate from %s to %s", originRateArea, destinationRateArea)
+	}
+	return rate, nil
+}
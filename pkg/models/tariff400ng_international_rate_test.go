@@ -0,0 +1,92 @@
+package models_test
+
+import (
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+func (suite *ModelSuite) Test_InternationalRateValidation() {
+	validRate := models.Tariff400ngInternationalRate{
+		OriginRateArea:      "US8190100",
+		DestinationRateArea: "US48",
+		DistanceMiles:       3400,
+		WeightLbsLower:      0,
+		WeightLbsUpper:      10000,
+		RateCents:           unit.Cents(8500),
+		EffectiveDateLower:  testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper:  testdatagen.PeakRateCycleEnd,
+	}
+	suite.MustSave(&validRate)
+
+	invalidRate := models.Tariff400ngInternationalRate{
+		OriginRateArea:      "Alaska",
+		DestinationRateArea: "US48",
+		WeightLbsLower:      10000,
+		WeightLbsUpper:      0,
+		RateCents:           unit.Cents(-1),
+		EffectiveDateLower:  testdatagen.PeakRateCycleEnd,
+		EffectiveDateUpper:  testdatagen.PeakRateCycleStart,
+	}
+	expErrors := map[string][]string{
+		"origin_rate_area":     {"OriginRateArea does not match the expected format."},
+		"distance_miles":       {"DistanceMiles can not be blank."},
+		"rate_cents":           {"-1 is not greater than -1."},
+		"weight_lbs_lower":     {"10000 is not less than 0."},
+		"effective_date_upper": {"EffectiveDateUpper must be after EffectiveDateLower."},
+	}
+	suite.verifyValidationErrors(&invalidRate, expErrors)
+}
+
+func (suite *ModelSuite) Test_FetchTariff400ngInternationalRate() {
+	rate := models.Tariff400ngInternationalRate{
+		OriginRateArea:      "US8190100",
+		DestinationRateArea: "US48",
+		DistanceMiles:       3400,
+		WeightLbsLower:      0,
+		WeightLbsUpper:      10000,
+		RateCents:           unit.Cents(8500),
+		EffectiveDateLower:  testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper:  testdatagen.PeakRateCycleEnd,
+	}
+	suite.MustSave(&rate)
+
+	fetched, err := models.FetchTariff400ngInternationalRate(suite.DB(), "US8190100", "US48", 2000, testdatagen.DateInsidePeakRateCycle)
+	if suite.NoError(err) {
+		suite.Equal(rate.ID, fetched.ID)
+		suite.Equal(3400, fetched.DistanceMiles)
+	}
+
+	// Rates are directional
+	_, err = models.FetchTariff400ngInternationalRate(suite.DB(), "US48", "US8190100", 2000, testdatagen.DateInsidePeakRateCycle)
+	suite.Error(err)
+}
+
+func (suite *ModelSuite) Test_FetchTariff400ngInternationalRateWeightBandEdge() {
+	lighter := models.Tariff400ngInternationalRate{
+		OriginRateArea:      "US8190100",
+		DestinationRateArea: "US48",
+		DistanceMiles:       3400,
+		WeightLbsLower:      0,
+		WeightLbsUpper:      5000,
+		RateCents:           unit.Cents(8500),
+		EffectiveDateLower:  testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper:  testdatagen.PeakRateCycleEnd,
+	}
+	suite.MustSave(&lighter)
+	heavier := lighter
+	heavier.WeightLbsLower = 5000
+	heavier.WeightLbsUpper = 10000
+	heavier.RateCents = unit.Cents(7500)
+	suite.MustSave(&heavier)
+
+	// Weight bands include their lower bound and exclude their upper bound
+	fetched, err := models.FetchTariff400ngInternationalRate(suite.DB(), "US8190100", "US48", 5000, testdatagen.DateInsidePeakRateCycle)
+	if suite.NoError(err) {
+		suite.Equal(heavier.ID, fetched.ID)
+	}
+	fetched, err = models.FetchTariff400ngInternationalRate(suite.DB(), "US8190100", "US48", 4999, testdatagen.DateInsidePeakRateCycle)
+	if suite.NoError(err) {
+		suite.Equal(lighter.ID, fetched.ID)
+	}
+}
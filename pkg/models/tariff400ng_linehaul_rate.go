@@ -12,6 +12,22 @@ import (
 	"github.com/transcom/mymove/pkg/unit"
 )
 
+const (
+	// LinehaulRateTypeConus is the type of linehaul rate used for household goods moving within the continental US
+	LinehaulRateTypeConus = "ConusLinehaul"
+	// LinehaulRateTypeConusUB is the type of linehaul rate used for unaccompanied baggage moving within the
+	// continental US
+	LinehaulRateTypeConusUB = "ConusUBLinehaul"
+)
+
+// LinehaulRateTypeForShipmentType returns the type of linehaul rate a shipment of the given type is priced with
+func LinehaulRateTypeForShipmentType(shipmentType ShipmentType) string {
+	if shipmentType == ShipmentTypeUB {
+		return LinehaulRateTypeConusUB
+	}
+	return LinehaulRateTypeConus
+}
+
 // Tariff400ngLinehaulRate describes the rate paids paid to transport various weights of goods
 // various distances.
 type Tariff400ngLinehaulRate struct {
@@ -60,12 +76,20 @@ func (t *Tariff400ngLinehaulRate) ValidateUpdate(tx *pop.Connection) (*validate.
 
 // FetchBaseLinehaulRate takes a move's distance and weight and queries the tariff400ng_linehaul_rates table to find a move's base linehaul rate.
 func FetchBaseLinehaulRate(tx *pop.Connection, mileage int, weight unit.Pound, date time.Time) (linehaulRate unit.Cents, err error) {
-	// TODO: change to a parameter once we're serving more move types
-	moveType := "ConusLinehaul"
-	var linehaulRates []unit.Cents
+	rate, err := FetchTariff400ngLinehaulRate(tx, LinehaulRateTypeConus, mileage, weight, date)
+	if err != nil {
+		return 0, err
+	}
+	return rate.RateCents, nil
+}
+
+// FetchTariff400ngLinehaulRate returns the tariff400ng_linehaul_rates row of the given type for a move's distance and
+// weight on the given date
+func FetchTariff400ngLinehaulRate(tx *pop.Connection, rateType string, mileage int, weight unit.Pound, date time.Time) (Tariff400ngLinehaulRate, error) {
+	var linehaulRates []Tariff400ngLinehaulRate
 
 	sql := `SELECT
-		rate_cents
+		*
 	FROM
 		tariff400ng_linehaul_rates
 	WHERE
@@ -77,15 +101,15 @@ func FetchBaseLinehaulRate(tx *pop.Connection, mileage int, weight unit.Pound, d
 	AND
 		(effective_date_lower <= $4 AND $4 < effective_date_upper);`
 
-	err = tx.RawQuery(sql, mileage, weight.Int(), moveType, date).All(&linehaulRates)
+	err := tx.RawQuery(sql, mileage, weight.Int(), rateType, date).All(&linehaulRates)
 
 	if err != nil {
-		return 0, fmt.Errorf("Error fetching linehaul rate: %s", err)
+		return Tariff400ngLinehaulRate{}, fmt.Errorf("Error fetching linehaul rate: %s", err)
 	}
 	if len(linehaulRates) != 1 {
-		return 0, fmt.Errorf("Wanted 1 rate, found %d rates for parameters: %v, %v, %v",
-			len(linehaulRates), mileage, weight, date)
+		return Tariff400ngLinehaulRate{}, fmt.Errorf("Wanted 1 rate, found %d rates for parameters: %v, %v, %v, %v",
+			len(linehaulRates), rateType, mileage, weight, date)
 	}
 
-	return linehaulRates[0], err
+	return linehaulRates[0], nil
 }
@@ -10,6 +10,11 @@ import (
 	"github.com/pkg/errors"
 )
 
+// rateAreaFormat matches tariff 400ng rate areas: "US" followed by digits for the US (including Alaska and Hawaii),
+// a two letter country code optionally followed by digits for international rate areas, or "ZIP" for zip3s
+// whose rate area depends on the full zip5
+const rateAreaFormat = "^(ZIP|[A-Z]{2}[0-9]*)$"
+
 // Tariff400ngZip3 is the first 3 numbers of a zip for Tariff400NG calculations
 type Tariff400ngZip3 struct {
 	ID            uuid.UUID `json:"id" db:"id"`
@@ -36,7 +41,7 @@ func (t *Tariff400ngZip3) Validate(tx *pop.Connection) (*validate.Errors, error)
 		&validators.StringIsPresent{Field: t.ServiceArea, Name: "ServiceArea"},
 		&validators.RegexMatch{Field: t.ServiceArea, Name: "ServiceArea", Expr: "^[0-9]+$"},
 		&validators.StringIsPresent{Field: t.RateArea, Name: "RateArea"},
-		&validators.RegexMatch{Field: t.RateArea, Name: "RateArea", Expr: "^(ZIP|US[0-9]+)$"},
+		&validators.RegexMatch{Field: t.RateArea, Name: "RateArea", Expr: rateAreaFormat},
 		&validators.StringIsPresent{Field: t.Region, Name: "Region"},
 		&validators.RegexMatch{Field: t.Region, Name: "Region", Expr: "^[0-9]+$"},
 	), nil
@@ -26,7 +26,7 @@ type Tariff400ngZip5RateAreas []Tariff400ngZip5RateArea
 func (t *Tariff400ngZip5RateArea) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	return validate.Validate(
 		&validators.StringIsPresent{Field: t.RateArea, Name: "RateArea"},
-		&validators.RegexMatch{Field: t.RateArea, Name: "RateArea", Expr: "^(ZIP|US[0-9]+)$"},
+		&validators.RegexMatch{Field: t.RateArea, Name: "RateArea", Expr: rateAreaFormat},
 		&validators.StringLengthInRange{Field: t.Zip5, Name: "Zip5", Min: 5, Max: 5},
 	), nil
 }
@@ -64,6 +64,40 @@ func FetchTDL(db *pop.Connection, rateArea string, region string, codeOfService
 	return trafficDistributionList, nil
 }
 
+// FetchTDLByID returns the TDL with the given ID
+func FetchTDLByID(db *pop.Connection, id uuid.UUID) (TrafficDistributionList, error) {
+	var trafficDistributionList TrafficDistributionList
+	err := db.Find(&trafficDistributionList, id)
+
+	if err != nil {
+		if errors.Cause(err).Error() == recordNotFoundErrorString {
+			return TrafficDistributionList{}, ErrFetchNotFound
+		}
+		return TrafficDistributionList{}, err
+	}
+
+	return trafficDistributionList, nil
+}
+
+// FetchTDLsByIDs returns the TDLs with the given IDs
+func FetchTDLsByIDs(db *pop.Connection, ids []uuid.UUID) (TrafficDistributionLists, error) {
+	var trafficDistributionLists TrafficDistributionLists
+	if len(ids) == 0 {
+		return trafficDistributionLists, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	err := db.Where("id IN (?)", args...).All(&trafficDistributionLists)
+	if err != nil {
+		return nil, errors.Wrap(err, "TDLs query failed")
+	}
+
+	return trafficDistributionLists, nil
+}
+
 // FetchOrCreateTDL attempts to return a TDL based on SourceRateArea, Region, and CodeOfService (COS)
 // and creates one to return if it doesn't already exist.
 func FetchOrCreateTDL(db *pop.Connection, rateArea string, region string, codeOfService string) (TrafficDistributionList, error) {
@@ -163,15 +163,35 @@ var domesticTransitTimes = []domesticTransitTime{
 	{110, 6751, 7000, 8000, 99999, 32},
 }
 
-// TransitDays returns the number of days it will take to move the specified weight of goods the specified distance.
-func TransitDays(weight unit.Pound, miles int) (int, error) {
+// Unaccompanied baggage moves by expedited freight, so its transit time depends only on distance
+var domesticUBTransitTimes = []domesticTransitTime{
+	{1, 1, 250, 1, 99999, 3},
+	{2, 251, 500, 1, 99999, 4},
+	{3, 501, 1000, 1, 99999, 5},
+	{4, 1001, 1500, 1, 99999, 6},
+	{5, 1501, 2000, 1, 99999, 7},
+	{6, 2001, 2500, 1, 99999, 8},
+	{7, 2501, 3000, 1, 99999, 9},
+	{8, 3001, 4000, 1, 99999, 10},
+	{9, 4001, 5500, 1, 99999, 11},
+	{10, 5501, 7000, 1, 99999, 12},
+}
+
+// TransitDays returns the number of days it will take to move the specified weight of goods the specified distance
+// for the given type of shipment.
+func TransitDays(shipmentType ShipmentType, weight unit.Pound, miles int) (int, error) {
+	transitTimes := domesticTransitTimes
+	if shipmentType == ShipmentTypeUB {
+		transitTimes = domesticUBTransitTimes
+	}
+
 	pounds := weight.Int()
-	for _, tt := range domesticTransitTimes {
+	for _, tt := range transitTimes {
 		if tt.LowWeight <= pounds && tt.HighWeight >= pounds && tt.LowMiles <= miles && tt.HighMiles >= miles {
 			return tt.TransitTime, nil
 		}
 	}
-	return 0, errors.Errorf("Could not find transit time for %d lbs and %d miles", pounds, miles)
+	return 0, errors.Errorf("Could not find %s transit time for %d lbs and %d miles", shipmentType, pounds, miles)
 }
 
 // PackDays returns the number of days it will take to pack the given weight.
@@ -6,21 +6,36 @@ import (
 )
 
 func (suite *ModelSuite) Test_TransitDaysLookup() {
-	days, err := TransitDays(unit.Pound(2500), 1100)
+	days, err := TransitDays(ShipmentTypeHHG, unit.Pound(2500), 1100)
 	suite.Nil(err)
 	suite.Equal(11, days, "wrong number of days")
 
-	days, err = TransitDays(unit.Pound(4300), 6100)
+	days, err = TransitDays(ShipmentTypeHHG, unit.Pound(4300), 6100)
 	suite.Nil(err)
 	suite.Equal(30, days, "wrong number of days")
 }
 
 func (suite *ModelSuite) Test_TransitDaysLookupFail() {
 	// Too much weight
-	_, err := TransitDays(unit.Pound(100000), 2000)
+	_, err := TransitDays(ShipmentTypeHHG, unit.Pound(100000), 2000)
 	suite.Error(err)
 
 	// Too many miles
-	_, err = TransitDays(unit.Pound(2000), 8001)
+	_, err = TransitDays(ShipmentTypeHHG, unit.Pound(2000), 8001)
+	suite.Error(err)
+}
+
+func (suite *ModelSuite) Test_TransitDaysLookupUB() {
+	days, err := TransitDays(ShipmentTypeUB, unit.Pound(300), 1100)
+	suite.Nil(err)
+	suite.Equal(6, days, "wrong number of days")
+
+	// UB is faster than HHG over the same distance
+	hhgDays, err := TransitDays(ShipmentTypeHHG, unit.Pound(300), 1100)
+	suite.Nil(err)
+	suite.True(days < hhgDays)
+
+	// Too many miles
+	_, err = TransitDays(ShipmentTypeUB, unit.Pound(300), 7001)
 	suite.Error(err)
 }
@@ -17,8 +17,6 @@ import (
 	"github.com/transcom/mymove/pkg/unit"
 )
 
-var qualityBands = []int{1, 2, 3, 4}
-
 // OffersPerQualityBand is a map of the number of shipments to be offered per round to each quality band
 // TODO: change these back to [5, 3, 2, 1] after the B&M pilot
 var OffersPerQualityBand = map[int]int{
@@ -47,6 +45,8 @@ type TransportationServiceProviderPerformance struct {
 	LinehaulRate                    unit.DiscountRate             `db:"linehaul_rate"`
 	SITRate                         unit.DiscountRate             `db:"sit_rate"`
 	OfferCount                      int                           `db:"offer_count"`
+	RejectedOfferCount              int                           `db:"rejected_offer_count"`
+	ExpiredOfferCount               int                           `db:"expired_offer_count"`
 }
 
 // TransportationServiceProviderPerformances is a handy type for multiple TransportationServiceProviderPerformance structs
@@ -97,9 +97,11 @@ func (t *TransportationServiceProviderPerformance) Validate(tx *pop.Connection)
 }
 
 // NextTSPPerformanceInQualityBand returns the TSP performance record in a given TDL
-// and Quality Band that will next be offered a shipment.
+// and Quality Band that will next be offered a shipment. TSPs that have already been
+// offered maxOfferCount shipments are skipped; a nil maxOfferCount means no limit.
+// TSPs that rejected the shipment, or let its offer expire, are skipped as well.
 func NextTSPPerformanceInQualityBand(tx *pop.Connection, tdlID uuid.UUID,
-	qualityBand int, bookDate time.Time, requestedPickupDate time.Time) (
+	qualityBand int, bookDate time.Time, requestedPickupDate time.Time, maxOfferCount *int, shipmentID uuid.UUID) (
 	TransportationServiceProviderPerformance, error) {
 
 	sql := `SELECT
@@ -119,24 +121,40 @@ func NextTSPPerformanceInQualityBand(tx *pop.Connection, tdlID uuid.UUID,
 			$4 BETWEEN tspp.rate_cycle_start AND tspp.rate_cycle_end
 			AND
 			tsp.enrolled = true
+			AND
+			($5::integer IS NULL OR tspp.offer_count < $5)
+			AND
+			tspp.transportation_service_provider_id NOT IN (
+				SELECT
+					so.transportation_service_provider_id
+				FROM
+					shipment_offers AS so
+				WHERE
+					so.shipment_id = $6
+					AND
+					so.administrative_shipment = false
+					AND
+					so.accepted = false
+			)
 		ORDER BY
 			offer_count ASC,
 			best_value_score DESC
 		`
 
 	tspp := TransportationServiceProviderPerformance{}
-	err := tx.RawQuery(sql, tdlID, qualityBand, bookDate, requestedPickupDate).First(&tspp)
+	err := tx.RawQuery(sql, tdlID, qualityBand, bookDate, requestedPickupDate, maxOfferCount, shipmentID).First(&tspp)
 
 	return tspp, err
 }
 
-// GatherNextEligibleTSPPerformances returns a map of QualityBands to their next eligible TSPPerformance.
-func GatherNextEligibleTSPPerformances(tx *pop.Connection, tdlID uuid.UUID, bookDate time.Time, requestedPickupDate time.Time) (map[int]TransportationServiceProviderPerformance, error) {
+// GatherNextEligibleTSPPerformances returns a map of QualityBands to their next eligible TSPPerformance,
+// using the number of quality bands and offer limit from the award queue policy.
+func GatherNextEligibleTSPPerformances(tx *pop.Connection, tdlID uuid.UUID, bookDate time.Time, requestedPickupDate time.Time, policy AwardQueuePolicy, shipmentID uuid.UUID) (map[int]TransportationServiceProviderPerformance, error) {
 	tspPerformances := make(map[int]TransportationServiceProviderPerformance)
 	qualityBandsWithoutTSPs := 0
 
-	for _, qualityBand := range qualityBands {
-		tspPerformance, err := NextTSPPerformanceInQualityBand(tx, tdlID, qualityBand, bookDate, requestedPickupDate)
+	for qualityBand := 1; qualityBand <= policy.NumQualityBands; qualityBand++ {
+		tspPerformance, err := NextTSPPerformanceInQualityBand(tx, tdlID, qualityBand, bookDate, requestedPickupDate, policy.MaxOffersPerTSP, shipmentID)
 		if err != nil {
 			if err.Error() == "sql: no rows in result set" {
 				// Some quality bands might not have TSPs, and that's OK. We
@@ -149,16 +167,16 @@ func GatherNextEligibleTSPPerformances(tx *pop.Connection, tdlID uuid.UUID, book
 			tspPerformances[qualityBand] = tspPerformance
 		}
 	}
-	if qualityBandsWithoutTSPs >= len(qualityBands) {
+	if qualityBandsWithoutTSPs >= policy.NumQualityBands {
 		return tspPerformances, fmt.Errorf("Could not find any TSPs to fill quality bands in TDL: %s", tdlID)
 	}
 	return tspPerformances, nil
 }
 
 // NextEligibleTSPPerformance wraps GatherNextEligibleTSPPerformances and DetermineNextTSPPerformance.
-func NextEligibleTSPPerformance(db *pop.Connection, tdlID uuid.UUID, bookDate time.Time, requestedPickupDate time.Time) (TransportationServiceProviderPerformance, error) {
+func NextEligibleTSPPerformance(db *pop.Connection, tdlID uuid.UUID, bookDate time.Time, requestedPickupDate time.Time, policy AwardQueuePolicy, shipmentID uuid.UUID) (TransportationServiceProviderPerformance, error) {
 	var tspPerformance TransportationServiceProviderPerformance
-	tspPerformances, err := GatherNextEligibleTSPPerformances(db, tdlID, bookDate, requestedPickupDate)
+	tspPerformances, err := GatherNextEligibleTSPPerformances(db, tdlID, bookDate, requestedPickupDate, policy, shipmentID)
 	if err == nil {
 		return SelectNextTSPPerformance(tspPerformances), nil
 	}
@@ -283,6 +301,27 @@ func IncrementTSPPerformanceOfferCount(db *pop.Connection, tspPerformanceID uuid
 	return tspPerformance, nil
 }
 
+// RecordDeclinedOfferOnTSPPerformance counts an offer the TSP rejected, or let expire, against its performance record.
+// OfferCount is left alone: the TSP had its turn in the rotation, so declining doesn't move it ahead of the other TSPs.
+func RecordDeclinedOfferOnTSPPerformance(db *pop.Connection, tspPerformanceID uuid.UUID, expired bool) (TransportationServiceProviderPerformance, error) {
+	var tspPerformance TransportationServiceProviderPerformance
+	if err := db.Find(&tspPerformance, tspPerformanceID); err != nil {
+		return tspPerformance, err
+	}
+	if expired {
+		tspPerformance.ExpiredOfferCount++
+	} else {
+		tspPerformance.RejectedOfferCount++
+	}
+	validationErr, databaseErr := db.ValidateAndSave(&tspPerformance)
+	if databaseErr != nil {
+		return tspPerformance, databaseErr
+	} else if validationErr.HasAny() {
+		return tspPerformance, fmt.Errorf("Validation failure: %s", validationErr)
+	}
+	return tspPerformance, nil
+}
+
 // GetRateCycle returns the start date and end dates for a rate cycle of the
 // given year and season (peak/non-peak), inclusive.
 func GetRateCycle(year int, peak bool) (start time.Time, end time.Time) {
@@ -297,6 +336,85 @@ func GetRateCycle(year int, peak bool) (start time.Time, end time.Time) {
 	return start, end
 }
 
+// FetchTSPPerformancesForTDL returns the TSP performances in a TDL whose performance period overlaps start
+// to end, with their TSPs, ordered by performance period and then the order the award queue offers them shipments.
+func FetchTSPPerformancesForTDL(db *pop.Connection, tdlID uuid.UUID, start time.Time, end time.Time) (TransportationServiceProviderPerformances, error) {
+	var perfs TransportationServiceProviderPerformances
+	err := db.Where("traffic_distribution_list_id = ?", tdlID).
+		Where("performance_period_start <= ?", end).
+		Where("performance_period_end >= ?", start).
+		Order("performance_period_start ASC, quality_band ASC, best_value_score DESC").
+		All(&perfs)
+	if err != nil {
+		return perfs, err
+	}
+
+	err = loadTransportationServiceProviders(db, perfs)
+	return perfs, err
+}
+
+// FetchTSPPerformancesForAwardQueue returns the banded performances of enrolled TSPs in the given TDLs whose
+// performance period overlaps start to end, with their TSPs. It lets the award queue load every performance
+// it might offer a batch of shipments to at once, rather than querying NextTSPPerformanceInQualityBand for
+// each shipment.
+func FetchTSPPerformancesForAwardQueue(db *pop.Connection, tdlIDs []uuid.UUID, start time.Time, end time.Time) (TransportationServiceProviderPerformances, error) {
+	var perfs TransportationServiceProviderPerformances
+	if len(tdlIDs) == 0 {
+		return perfs, nil
+	}
+
+	ids := make([]interface{}, len(tdlIDs))
+	for i, id := range tdlIDs {
+		ids[i] = id
+	}
+	err := db.Where("traffic_distribution_list_id IN (?)", ids...).
+		Where("quality_band IS NOT NULL").
+		Where("performance_period_start <= ?", end).
+		Where("performance_period_end >= ?", start).
+		Where("transportation_service_provider_id IN (SELECT id FROM transportation_service_providers WHERE enrolled = true)").
+		Order("offer_count ASC, best_value_score DESC").
+		All(&perfs)
+	if err != nil {
+		return nil, errors.Wrap(err, "TSP performances query failed")
+	}
+
+	if err := loadTransportationServiceProviders(db, perfs); err != nil {
+		return nil, err
+	}
+
+	return perfs, nil
+}
+
+// loadTransportationServiceProviders sets the TSPs of perfs with a single query. Pop's Eager loads
+// belongs_to associations with one query per record, which is slow for the hundreds of performances in a TDL.
+func loadTransportationServiceProviders(db *pop.Connection, perfs TransportationServiceProviderPerformances) error {
+	if len(perfs) == 0 {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []interface{}
+	for _, perf := range perfs {
+		if !seen[perf.TransportationServiceProviderID] {
+			seen[perf.TransportationServiceProviderID] = true
+			ids = append(ids, perf.TransportationServiceProviderID)
+		}
+	}
+
+	var tsps TransportationServiceProviders
+	if err := db.Where("id IN (?)", ids...).All(&tsps); err != nil {
+		return errors.Wrap(err, "TSPs query failed")
+	}
+	tspsByID := make(map[uuid.UUID]TransportationServiceProvider, len(tsps))
+	for _, tsp := range tsps {
+		tspsByID[tsp.ID] = tsp
+	}
+	for i := range perfs {
+		perfs[i].TransportationServiceProvider = tspsByID[perfs[i].TransportationServiceProviderID]
+	}
+	return nil
+}
+
 // FetchDiscountRates returns the discount linehaul and SIT rates for the TSP with the highest
 // BVS during the specified date, limited to those TSPs in the channel defined by the
 // originZip and destinationZip.
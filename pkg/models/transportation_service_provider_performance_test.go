@@ -211,7 +211,7 @@ func (suite *ModelSuite) Test_FetchNextQualityBandTSPPerformance() {
 	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp3, tdl, swag.Int(1), mps+2, 0, .4, .4)
 
 	tspp, err := NextTSPPerformanceInQualityBand(suite.DB(), tdl.ID, 1, testdatagen.DateInsidePerformancePeriod,
-		testdatagen.DateInsidePeakRateCycle)
+		testdatagen.DateInsidePeakRateCycle, nil, uuid.Nil)
 
 	if err != nil {
 		t.Errorf("Failed to find TSPPerformance: %v", err)
@@ -397,7 +397,7 @@ func (suite *ModelSuite) Test_GatherNextEligibleTSPPerformances() {
 	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp5, tdl, swag.Int(4), mps+1, 0, .1, .1)
 
 	tsps, err := GatherNextEligibleTSPPerformances(suite.DB(), tdl.ID, testdatagen.DateInsidePerformancePeriod,
-		testdatagen.DateInsidePeakRateCycle)
+		testdatagen.DateInsidePeakRateCycle, DefaultAwardQueuePolicy(), uuid.Nil)
 	expectedTSPorder := []uuid.UUID{tsp1.ID, tsp3.ID, tsp4.ID, tsp5.ID}
 
 	actualTSPorder := []uuid.UUID{
@@ -458,6 +458,43 @@ func (suite *ModelSuite) Test_FetchTSPPerformancesForQualityBandAssignment() {
 	}
 }
 
+func (suite *ModelSuite) Test_FetchTSPPerformancesLoadsTSPs() {
+	tdl := testdatagen.MakeDefaultTDL(suite.DB())
+	otherTDL := testdatagen.MakeTDL(suite.DB(), testdatagen.Assertions{
+		TrafficDistributionList: TrafficDistributionList{
+			SourceRateArea:    "US1",
+			DestinationRegion: "1",
+			CodeOfService:     "2",
+		},
+	})
+	tsp1 := testdatagen.MakeDefaultTSP(suite.DB())
+	tsp2 := testdatagen.MakeDefaultTSP(suite.DB())
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp1, tdl, swag.Int(1), 90, 0, .5, .5)
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp2, tdl, swag.Int(1), 50, 0, .3, .9)
+	testdatagen.MakeTSPPerformanceDeprecated(suite.DB(), tsp1, otherTDL, swag.Int(1), 70, 0, .5, .5)
+	scacs := map[uuid.UUID]string{
+		tsp1.ID: tsp1.StandardCarrierAlphaCode,
+		tsp2.ID: tsp2.StandardCarrierAlphaCode,
+	}
+
+	perfs, err := FetchTSPPerformancesForAwardQueue(suite.DB(), []uuid.UUID{tdl.ID, otherTDL.ID},
+		testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+	suite.Len(perfs, 3)
+	for _, perf := range perfs {
+		suite.Equal(perf.TransportationServiceProviderID, perf.TransportationServiceProvider.ID)
+		suite.Equal(scacs[perf.TransportationServiceProviderID], perf.TransportationServiceProvider.StandardCarrierAlphaCode)
+	}
+
+	perfs, err = FetchTSPPerformancesForTDL(suite.DB(), tdl.ID, testdatagen.PerformancePeriodStart, testdatagen.PerformancePeriodEnd)
+	suite.NoError(err)
+	suite.Len(perfs, 2)
+	for _, perf := range perfs {
+		suite.Equal(perf.TransportationServiceProviderID, perf.TransportationServiceProvider.ID)
+		suite.Equal(scacs[perf.TransportationServiceProviderID], perf.TransportationServiceProvider.StandardCarrierAlphaCode)
+	}
+}
+
 // Test_MinimumPerformanceScore ensures that TSPs whose BVS is below the MPS
 // do not enter the Award Queue process.
 func (suite *ModelSuite) Test_MinimumPerformanceScore() {
@@ -81,14 +81,19 @@ func (m MoveApproved) emails(ctx context.Context) ([]emailContent, error) {
 		ppmText = `For your “Do-it-Yourself” shipment, you can begin your move whenever you are ready. Be sure to save your weight tickets and any receipts associated with your move for when you request payment later on in the process.`
 	}
 
+	povText := ""
+	if len(move.PrivatelyOwnedVehicles) > 0 {
+		povText = `For your vehicle shipment, schedule your drop-off with the vehicle processing center. Bring your vehicle's title and registration, and upload the vehicle shipping document (DD Form 788) you receive at drop-off to your move.`
+	}
+
 	// TODO: Add the PPPO contact info
 	closingText := `If you have any questions, contact your origin PPPO.`
 
 	smEmail := emailContent{
 		recipientEmail: *serviceMember.PersonalEmail,
 		subject:        "MOVE.MIL: Your move has been approved.",
-		htmlBody:       fmt.Sprintf("%s<br/>%s<br/>%s<br/>%s", introText, nextStepsText, ppmText, closingText),
-		textBody:       fmt.Sprintf("%s\n%s\n%s\n%s", introText, nextStepsText, ppmText, closingText),
+		htmlBody:       fmt.Sprintf("%s<br/>%s<br/>%s<br/>%s<br/>%s", introText, nextStepsText, ppmText, povText, closingText),
+		textBody:       fmt.Sprintf("%s\n%s\n%s\n%s\n%s", introText, nextStepsText, ppmText, povText, closingText),
 	}
 
 	// TODO: Send email to trusted contacts when that's supported
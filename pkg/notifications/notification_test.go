@@ -56,6 +56,31 @@ func (suite *NotificationSuite) TestMoveApproved() {
 	suite.NotEmpty(email.textBody)
 }
 
+func (suite *NotificationSuite) TestMoveApprovedWithPOV() {
+	ctx := context.Background()
+	t := suite.T()
+
+	approver := testdatagen.MakeDefaultUser(suite.DB())
+	pov := testdatagen.MakeDefaultPrivatelyOwnedVehicle(suite.DB())
+	notification := MoveApproved{
+		db:     suite.DB(),
+		logger: suite.logger,
+		moveID: pov.MoveID,
+		session: &auth.Session{
+			UserID:          approver.ID,
+			ApplicationName: auth.OfficeApp,
+		},
+	}
+
+	emails, err := notification.emails(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suite.Equal(len(emails), 1)
+	suite.Contains(emails[0].textBody, "DD Form 788")
+}
+
 func (suite *NotificationSuite) TestMoveSubmitted() {
 	ctx := context.Background()
 	t := suite.T()
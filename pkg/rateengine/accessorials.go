@@ -1,6 +1,8 @@
 package rateengine
 
 import (
+	"fmt"
+
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
 
@@ -13,17 +15,14 @@ var tariff400ngItemPricing = map[string]pricer{
 	"4A": newBasicQuantityPricer(),
 	"4B": newBasicQuantityPricer(),
 
-	// Below are SIT-related codes, uncomment after SIT is implemented
 	// Attempted delivery from SIT
-	// "17A": newFlatRatePricer(),
-	// "17B": newFlatRatePricer(),
-	// Priced using base linehaul rate, needs more work
-	// "17C": newFlatRatePricer(),
-	// "17D": newMinimumQuantityHundredweightPricer(1000),
-	// "17E": newFlatRatePricer(),
-	// "17F": newFlatRatePricer(),
-	// Priced using base linehaul rate, needs more work
-	// "17G": newFlatRatePricer(),
+	"17A": newFlatRatePricer(),
+	"17B": newFlatRatePricer(),
+	"17C": newFlatRatePricer(),
+	"17D": newMinimumQuantityHundredweightPricer(1000),
+	"17E": newFlatRatePricer(),
+	"17F": newFlatRatePricer(),
+	"17G": newFlatRatePricer(),
 
 	// Extra pickups, diversions
 	"28A": newBasicQuantityPricer(),
@@ -42,13 +41,13 @@ var tariff400ngItemPricing = map[string]pricer{
 	// Debris removal
 	"105D": newBasicQuantityPricer(),
 
-	// Extra labor, waiting time
-	"120A": newBasicQuantityPricer(),
-	"120B": newBasicQuantityPricer(),
-	"120C": newBasicQuantityPricer(),
-	"120D": newBasicQuantityPricer(),
-	"120E": newBasicQuantityPricer(),
-	"120F": newBasicQuantityPricer(),
+	// Extra labor, waiting time - hours, multiplied by the number of workers when provided
+	"120A": newBasicTwoQuantityPricer(),
+	"120B": newBasicTwoQuantityPricer(),
+	"120C": newBasicTwoQuantityPricer(),
+	"120D": newBasicTwoQuantityPricer(),
+	"120E": newBasicTwoQuantityPricer(),
+	"120F": newBasicTwoQuantityPricer(),
 
 	// Shuttle service
 	"125A": newFlatRatePricer(),
@@ -68,22 +67,20 @@ var tariff400ngItemPricing = map[string]pricer{
 	"130I": newBasicQuantityPricer(),
 	"130J": newBasicQuantityPricer(),
 
-	// Overtime loading/unloading
+	// Overtime loading/unloading - rate is per hundredweight
 	// Note: this pricer doesn't allow for weights under 1,000, which the below excerpt would imply is possible
 	// "If only a portion of a shipment is loaded/unloaded a separate weight ticket MUST be provided,
 	// otherwise TSP is limited to billing 1,000 lbs."
 	"175A": newMinimumQuantityPricer(1000),
 
-	// Below are SIT-related codes, uncomment after SIT is implemented
-	// SIT
-	// "185A": newMinimumQuantityHundredweightPricer(1000),
-	// Priced using two quantities (weight and days), needs more work
-	// "185B": newMinimumQuantityHundredweightPricer(1000),
+	// SIT first day
+	"185A": newMinimumQuantityHundredweightPricer(1000),
+	// SIT additional days, priced using two quantities (days and weight)
+	"185B": newMinimumWeightTimesQuantityPricer(1000),
 
-	// Below are SIT-related codes, uncomment after SIT is implemented
 	// SIT P/D OT
-	// "210D": newFlatRatePricer(),
-	// "210E": newFlatRatePricer(),
+	"210D": newFlatRatePricer(),
+	"210E": newFlatRatePricer(),
 
 	// Pickup/delivery at third-party and self-storage warehouses
 	"225A": newFlatRatePricer(),
@@ -107,6 +104,72 @@ var tariff400ngWeightBasedItems = map[string]bool{
 	"185A": true,
 }
 
+// These codes are priced on days and weight, and will use the final measured shipment weight as the second quantity
+var tariff400ngWeightBasedQuantity2Items = map[string]bool{
+	"185B": true,
+}
+
+// SIT pickup/delivery codes are priced using the SIT P/D schedule of the service area rather than its services schedule
+var tariff400ngSITPDScheduleItems = map[string]bool{
+	"17B":  true,
+	"17F":  true,
+	"210A": true,
+	"210B": true,
+	"210D": true,
+	"210E": true,
+}
+
+// Attempted deliveries over 50 miles are priced using the base linehaul rate for the miles entered as the first quantity
+var tariff400ngLinehaulRateItems = map[string]bool{
+	"17C": true,
+	"17G": true,
+}
+
+// appliedQuantities returns the quantities a line item is priced with. Weight-based items will pull final
+// weight values from the shipment when available.
+func appliedQuantities(shipmentLineItem models.ShipmentLineItem) (unit.BaseQuantity, unit.BaseQuantity, error) {
+	itemCode := shipmentLineItem.Tariff400ngItem.Code
+	shipment := shipmentLineItem.Shipment
+
+	q1 := shipmentLineItem.Quantity1
+	q2 := shipmentLineItem.Quantity2
+	_, q1IsWeight := tariff400ngWeightBasedItems[itemCode]
+	_, q2IsWeight := tariff400ngWeightBasedQuantity2Items[itemCode]
+	if q1IsWeight || q2IsWeight {
+		if shipment.NetWeight == nil {
+			return q1, q2, errors.New("Can't price a weight-based accessorial without shipment net weight")
+		}
+		if q1IsWeight {
+			q1 = unit.BaseQuantityFromInt(shipment.NetWeight.Int())
+		}
+		if q2IsWeight {
+			q2 = unit.BaseQuantityFromInt(shipment.NetWeight.Int())
+		}
+	}
+
+	return q1, q2, nil
+}
+
+// validateQuantities checks that a line item supplies every quantity its pricer requires
+func validateQuantities(shipmentLineItem models.ShipmentLineItem) error {
+	itemCode := shipmentLineItem.Tariff400ngItem.Code
+	itemPricer, ok := tariff400ngItemPricing[itemCode]
+	if !ok {
+		return errors.New("Could not find pricing function for given code")
+	}
+
+	q1, q2, err := appliedQuantities(shipmentLineItem)
+	if err != nil {
+		return err
+	}
+
+	if missing := itemPricer.quantities().missing(q1, q2); missing != 0 {
+		return NewMissingQuantityError(itemCode, missing)
+	}
+
+	return nil
+}
+
 // ComputeShipmentLineItemCharge calculates the total charge for a supplied shipment line item and returns it and the DISCOUNTED rate
 func (re *RateEngine) ComputeShipmentLineItemCharge(shipmentLineItem models.ShipmentLineItem) (FeeAndRate, error) {
 	itemCode := shipmentLineItem.Tariff400ngItem.Code
@@ -123,35 +186,51 @@ func (re *RateEngine) ComputeShipmentLineItemCharge(shipmentLineItem models.Ship
 	}
 	shipDate := shipment.BookDate
 
-	serviceArea, err := models.FetchTariff400ngServiceAreaForZip3(re.db, zip, *shipDate)
+	serviceArea, err := re.fetchServiceArea(zip, *shipDate)
 	if err != nil {
 		return FeeAndRate{}, errors.Wrap(err, "Fetching 400ng service area from db")
 	}
 
+	// If code is priced using rate from separate code, use that
+	effectiveItemCode := itemCode
+	if mappedCode, ok := tariff400ngItemRateMap[effectiveItemCode]; ok {
+		effectiveItemCode = mappedCode
+	}
+
 	var rateCents unit.Cents
-	if itemCode == "185A" {
+	if effectiveItemCode == "185A" {
 		// Rates for SIT are stored  on the service area
 		rateCents = serviceArea.SIT185ARateCents
-	} else if itemCode == "185B" {
+		re.trace.addRateLookup("tariff400ng_service_areas", &serviceArea.ID, "SIT first day and warehouse (185A) rate", rateCents.String())
+	} else if effectiveItemCode == "185B" {
 		rateCents = serviceArea.SIT185BRateCents
+		re.trace.addRateLookup("tariff400ng_service_areas", &serviceArea.ID, "SIT additional day (185B) rate", rateCents.String())
 	} else if itemCode == "226A" {
 		// 226A is Misc charge, allow user to enter dollar amount as quantity
 		rateCents = unit.Cents(100)
 	} else if itemCode == "35A" {
 		// 35A is a Third Party Service (TPS) charge, allow user to enter dollar amount as quantity
 		rateCents = unit.Cents(100)
+	} else if _, ok := tariff400ngLinehaulRateItems[effectiveItemCode]; ok {
+		rateCents, err = re.fetchBaseLinehaulRate(
+			models.LinehaulRateTypeForShipmentType(shipment.ShipmentType),
+			shipmentLineItem.Quantity1.ToUnitInt(),
+			*shipment.NetWeight,
+			*shipDate,
+		)
+		if err != nil {
+			return FeeAndRate{}, errors.Wrap(err, "Fetching 400ng base linehaul rate from db")
+		}
 	} else {
 		// Most rates should be in the tariff400ngItemRates table though
-
-		// If code is priced using rate from separate code, use that
-		effectiveItemCode := itemCode
-		if mappedCode, ok := tariff400ngItemRateMap[effectiveItemCode]; ok {
-			effectiveItemCode = mappedCode
+		schedule := serviceArea.ServicesSchedule
+		if _, ok := tariff400ngSITPDScheduleItems[effectiveItemCode]; ok {
+			schedule = serviceArea.SITPDSchedule
 		}
 
-		rate, err := models.FetchTariff400ngItemRate(re.db,
+		rate, err := re.fetchItemRate(
 			effectiveItemCode,
-			serviceArea.ServicesSchedule,
+			schedule,
 			*shipment.NetWeight,
 			*shipDate,
 		)
@@ -161,35 +240,41 @@ func (re *RateEngine) ComputeShipmentLineItemCharge(shipmentLineItem models.Ship
 		rateCents = rate.RateCents
 	}
 
-	// Make sure we have a ShipmentOffer and TSPP if we need to apply a discount
-	hasTSPP := len(shipment.ShipmentOffers) == 0 || shipment.ShipmentOffers[0].TransportationServiceProviderPerformance.ID == uuid.Nil
-	if shipmentLineItem.Tariff400ngItem.DiscountType != models.Tariff400ngItemDiscountTypeNONE && hasTSPP {
-		return FeeAndRate{}, errors.New("No TSPP provided for Shipment, something is very wrong")
+	// Discounts come from the performance of the TSP that accepted the shipment, not a TSP that declined it
+	var acceptedTSPP models.TransportationServiceProviderPerformance
+	if shipmentLineItem.Tariff400ngItem.DiscountType != models.Tariff400ngItemDiscountTypeNONE {
+		acceptedOffer, err := shipment.AcceptedShipmentOffer()
+		if err != nil {
+			return FeeAndRate{}, errors.Wrap(err, "Fetching accepted shipment offer")
+		}
+		if acceptedOffer == nil || acceptedOffer.TransportationServiceProviderPerformance.ID == uuid.Nil {
+			return FeeAndRate{}, errors.New("No TSPP provided for Shipment, something is very wrong")
+		}
+		acceptedTSPP = acceptedOffer.TransportationServiceProviderPerformance
 	}
 
 	var discountRate *unit.DiscountRate
 	if shipmentLineItem.Tariff400ngItem.DiscountType == models.Tariff400ngItemDiscountTypeHHG || shipmentLineItem.Tariff400ngItem.DiscountType == models.Tariff400ngItemDiscountTypeHHGLINEHAUL50 {
-		discountRate = &shipment.ShipmentOffers[0].TransportationServiceProviderPerformance.LinehaulRate
+		discountRate = &acceptedTSPP.LinehaulRate
 	} else if shipmentLineItem.Tariff400ngItem.DiscountType == models.Tariff400ngItemDiscountTypeSIT {
-		discountRate = &shipment.ShipmentOffers[0].TransportationServiceProviderPerformance.SITRate
+		discountRate = &acceptedTSPP.SITRate
 	}
 
-	// Weight-based items will pull final weight values from the shipment when available
-	appliedQuantity := shipmentLineItem.Quantity1
-	if _, ok := tariff400ngWeightBasedItems[itemCode]; ok {
-		if shipment.NetWeight == nil {
-			return FeeAndRate{}, errors.New("Can't price a weight-based accessorial without shipment net weight")
-		}
-		appliedQuantity = unit.BaseQuantityFromInt(shipment.NetWeight.Int())
+	appliedQuantity, appliedQuantity2, err := appliedQuantities(shipmentLineItem)
+	if err != nil {
+		return FeeAndRate{}, err
 	}
 
 	appliedRate := rateCents
 	if discountRate != nil {
 		appliedRate = discountRate.Apply(rateCents)
+		re.trace.addDiscount(fmt.Sprintf("Item %s rate", itemCode), *discountRate, rateCents, appliedRate)
 	}
 
 	if itemPricer, ok := tariff400ngItemPricing[itemCode]; ok {
-		return FeeAndRate{Fee: itemPricer.price(rateCents, appliedQuantity, discountRate), Rate: appliedRate.ToMillicents()}, nil
+		fee := itemPricer.price(rateCents, appliedQuantity, appliedQuantity2, discountRate)
+		re.trace.addCharge(fmt.Sprintf("Item %s charge for quantities %s and %s", itemCode, appliedQuantity.String(), appliedQuantity2.String()), fee)
+		return FeeAndRate{Fee: fee, Rate: appliedRate.ToMillicents()}, nil
 	}
 
 	return FeeAndRate{}, errors.New("Could not find pricing function for given code")
@@ -214,13 +299,19 @@ func (re *RateEngine) PricePreapprovalRequestsForShipment(shipment models.Shipme
 
 // PricePreapprovalRequest computes price for given pre-approval requests and populates amount_cents field and applied_rate on those models
 func (re *RateEngine) PricePreapprovalRequest(shipmentLineItem *models.ShipmentLineItem) error {
+	err := validateQuantities(*shipmentLineItem)
+	if err != nil {
+		return err
+	}
 
+	re, trace := re.traced()
 	feeAndRate, err := re.ComputeShipmentLineItemCharge(*shipmentLineItem)
 	if err != nil {
 		return err
 	}
 	shipmentLineItem.AmountCents = &feeAndRate.Fee
 	shipmentLineItem.AppliedRate = &feeAndRate.Rate
+	shipmentLineItem.PricingTrace = trace.Steps
 
 	return nil
 }
@@ -54,11 +54,99 @@ func (suite *RateEngineSuite) TestAccessorialsPricingPackCrate() {
 	}
 }
 
+func (suite *RateEngineSuite) TestAccessorialsPricingSITAdditionalDays() {
+	shipment := suite.createShipmentWithServiceArea()
+	days := 10
+	item := testdatagen.MakeShipmentLineItem(suite.DB(), testdatagen.Assertions{
+		ShipmentLineItem: models.ShipmentLineItem{
+			Quantity1: unit.BaseQuantityFromInt(days),
+			Shipment:  shipment,
+			Status:    models.ShipmentLineItemStatusAPPROVED,
+			Location:  models.ShipmentLineItemLocationORIGIN,
+		},
+		Tariff400ngItem: models.Tariff400ngItem{
+			Code:                "185B",
+			RequiresPreApproval: true,
+			DiscountType:        models.Tariff400ngItemDiscountTypeSIT,
+		},
+	})
+
+	engine := NewRateEngine(suite.DB(), suite.logger)
+	computedPriceAndRate, err := engine.ComputeShipmentLineItemCharge(item)
+
+	if suite.NoError(err) {
+		serviceArea, err := models.FetchTariff400ngServiceAreaForZip3(suite.DB(),
+			Zip5ToZip3(shipment.PickupAddress.PostalCode), *shipment.BookDate)
+		suite.NoError(err)
+
+		// Additional days are priced on the shipment's net weight, subject to a 1000 lb minimum
+		weight := shipment.NetWeight.Int()
+		if weight < 1000 {
+			weight = 1000
+		}
+		discountRate := shipment.ShipmentOffers[0].TransportationServiceProviderPerformance.SITRate
+		expected := discountRate.Apply(serviceArea.SIT185BRateCents.MultiplyFloat64(float64(days*weight) / 100.0))
+		suite.Equal(expected, computedPriceAndRate.Fee)
+	}
+}
+
+func (suite *RateEngineSuite) TestAccessorialsPricingOvertimeLoading() {
+	itemCode := "175A"
+	rateCents := unit.Cents(12)
+	shipment := suite.createShipmentWithServiceArea()
+	item := testdatagen.MakeShipmentLineItem(suite.DB(), testdatagen.Assertions{
+		ShipmentLineItem: models.ShipmentLineItem{
+			Quantity1: unit.BaseQuantityFromInt(1),
+			Shipment:  shipment,
+			Status:    models.ShipmentLineItemStatusAPPROVED,
+			Location:  models.ShipmentLineItemLocationORIGIN,
+		},
+		Tariff400ngItem: models.Tariff400ngItem{
+			Code:                itemCode,
+			RequiresPreApproval: true,
+			DiscountType:        models.Tariff400ngItemDiscountTypeHHG,
+		},
+	})
+
+	testdatagen.MakeTariff400ngItemRate(suite.DB(), testdatagen.Assertions{
+		Tariff400ngItemRate: models.Tariff400ngItemRate{
+			Code:      itemCode,
+			RateCents: rateCents,
+		},
+	})
+
+	engine := NewRateEngine(suite.DB(), suite.logger)
+	computedPriceAndRate, err := engine.ComputeShipmentLineItemCharge(item)
+
+	if suite.NoError(err) {
+		// The rate applies to each pound of the shipment's net weight, subject to a 1000 lb minimum
+		weight := shipment.NetWeight.Int()
+		if weight < 1000 {
+			weight = 1000
+		}
+		discountRate := shipment.ShipmentOffers[0].TransportationServiceProviderPerformance.LinehaulRate
+		suite.Equal(discountRate.Apply(rateCents.Multiply(weight)), computedPriceAndRate.Fee)
+	}
+}
+
 // Iterates through all codes that have pricers and make sure they don't explode with sane values
 func (suite *RateEngineSuite) TestAccessorialsSmokeTest() {
 	rateCents := unit.Cents(100)
 	shipment := suite.createShipmentWithServiceArea()
 
+	// Codes priced on the base linehaul rate need a linehaul rate to exist
+	baseLinehaul := models.Tariff400ngLinehaulRate{
+		DistanceMilesLower: 0,
+		DistanceMilesUpper: 10000,
+		WeightLbsLower:     0,
+		WeightLbsUpper:     100000,
+		RateCents:          rateCents,
+		Type:               "ConusLinehaul",
+		EffectiveDateLower: testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper: testdatagen.NonPeakRateCycleEnd,
+	}
+	suite.MustSave(&baseLinehaul)
+
 	for code := range tariff400ngItemPricing {
 		item := testdatagen.MakeShipmentLineItem(suite.DB(), testdatagen.Assertions{
 			ShipmentLineItem: models.ShipmentLineItem{
@@ -142,5 +230,38 @@ func (suite *RateEngineSuite) TestPricePreapprovalRequest() {
 	if suite.NoError(err) {
 		suite.NotNil(item.AmountCents)
 		suite.NotNil(item.AppliedRate)
+
+		// The trace starts with the rate lookups and ends with the charge
+		if suite.NotEmpty(item.PricingTrace) {
+			suite.Equal(models.PricingTraceStepKindRATELOOKUP, item.PricingTrace[0].Kind)
+			last := item.PricingTrace[len(item.PricingTrace)-1]
+			suite.Equal(models.PricingTraceStepKindCHARGE, last.Kind)
+			suite.Equal(item.AmountCents.String(), last.Value)
+		}
+	}
+}
+
+func (suite *RateEngineSuite) TestPricePreapprovalRequestMissingQuantity() {
+	item := testdatagen.MakeCompleteShipmentLineItem(suite.DB(), testdatagen.Assertions{
+		ShipmentLineItem: models.ShipmentLineItem{
+			Status: models.ShipmentLineItemStatusSUBMITTED,
+		},
+		Tariff400ngItem: models.Tariff400ngItem{
+			Code:                "105B",
+			RequiresPreApproval: true,
+		},
+	})
+	// mergeModels skips zero values, so clear the default quantity after creation
+	item.Quantity1 = unit.BaseQuantity(0)
+
+	engine := NewRateEngine(suite.DB(), suite.logger)
+	err := engine.PricePreapprovalRequest(&item)
+
+	if suite.Error(err) {
+		rateEngineErr, ok := err.(Error)
+		if suite.True(ok) {
+			suite.Equal(MissingQuantity, rateEngineErr.Code())
+		}
+		suite.Nil(item.AmountCents)
 	}
 }
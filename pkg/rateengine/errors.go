@@ -0,0 +1,67 @@
+package rateengine
+
+import (
+	"fmt"
+)
+
+// ErrorCode contains error codes for the rateengine package
+type ErrorCode string
+
+const (
+	// MissingQuantity happens when a line item doesn't supply a quantity its pricer requires
+	MissingQuantity ErrorCode = "MISSING_QUANTITY"
+	// InternationalNotPriced happens when an international shipment needs a charge the port-to-port rates don't cover
+	InternationalNotPriced ErrorCode = "INTERNATIONAL_NOT_PRICED"
+)
+
+// Error is used for handling errors from the rateengine package
+type Error interface {
+	error
+	Code() ErrorCode
+}
+
+// baseError contains basic rateengine error functionality
+type baseError struct {
+	code ErrorCode
+}
+
+// Code returns the error code enum
+func (b *baseError) Code() ErrorCode {
+	return b.code
+}
+
+type missingQuantityError struct {
+	baseError
+	itemCode string
+	quantity int
+}
+
+// NewMissingQuantityError creates a new MissingQuantity error.
+func NewMissingQuantityError(itemCode string, quantity int) Error {
+	return &missingQuantityError{
+		baseError{MissingQuantity},
+		itemCode,
+		quantity,
+	}
+}
+
+func (e *missingQuantityError) Error() string {
+	return fmt.Sprintf("Quantity %d is required to price item %s", e.quantity, e.itemCode)
+}
+
+type internationalNotPricedError struct {
+	baseError
+	charge string
+}
+
+// NewInternationalNotPricedError creates a new InternationalNotPriced error.
+func NewInternationalNotPricedError(charge string) Error {
+	return &internationalNotPricedError{
+		baseError{InternationalNotPriced},
+		charge,
+	}
+}
+
+func (e *internationalNotPricedError) Error() string {
+	return fmt.Sprintf("%s can't be priced for international shipments, which are priced from port-to-port rates", e.charge)
+}
@@ -0,0 +1,104 @@
+package rateengine
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// internationalRate returns the port-to-port rate between the rate areas of two zips
+func (re *RateEngine) internationalRate(weight unit.Pound, originZip5 string, destinationZip5 string, date time.Time) (models.Tariff400ngInternationalRate, error) {
+	originRateArea, err := re.fetchRateArea(originZip5)
+	if err != nil {
+		return models.Tariff400ngInternationalRate{}, errors.Wrap(err, "Failed to determine origin rate area")
+	}
+	destinationRateArea, err := re.fetchRateArea(destinationZip5)
+	if err != nil {
+		return models.Tariff400ngInternationalRate{}, errors.Wrap(err, "Failed to determine destination rate area")
+	}
+
+	return re.fetchInternationalRate(originRateArea, destinationRateArea, weight, date)
+}
+
+// internationalLinehaulChargeComputation prices the linehaul of an international shipment from the port-to-port
+// rate between its origin and destination rate areas. It takes the place of the domestic base linehaul,
+// linehaul factors and shorthaul charge, and its mileage is the port-to-port distance.
+func (re *RateEngine) internationalLinehaulChargeComputation(weight unit.Pound, originZip5 string, destinationZip5 string, pickupDate time.Time) (cost LinehaulCostComputation, err error) {
+	traceStart := re.trace.len()
+
+	rate, err := re.internationalRate(weight, originZip5, destinationZip5, pickupDate)
+	if err != nil {
+		return cost, errors.Wrap(err, "Failed to determine port-to-port rate")
+	}
+
+	cost.Mileage = rate.DistanceMiles
+	cost.BaseLinehaul = rate.RateCents.Multiply(weight.ToCWT().Int())
+	cost.LinehaulChargeTotal = cost.BaseLinehaul
+	re.trace.addCharge("Linehaul charge total (port-to-port rate per CWT)", cost.LinehaulChargeTotal)
+	re.trace.tagSince(traceStart, "LHS")
+
+	re.logger.Info("International linehaul charge total calculated",
+		zap.Int("linehaul total", cost.LinehaulChargeTotal.Int()),
+		zap.Int("port-to-port miles", cost.Mileage),
+	)
+
+	return cost, nil
+}
+
+// internationalShipmentComputation prices an international shipment from the port-to-port rate between its origin
+// and destination rate areas. Port-to-port rates are single factor rates that include origin and destination
+// services, packing, unpacking and fuel, so none of those are looked up in the domestic tables and they're
+// priced at zero. The port-to-port tables have no SIT rates, so SIT is refused rather than priced domestically.
+func (re *RateEngine) internationalShipmentComputation(weight unit.Pound, originZip5 string, destinationZip5 string, pickupDate time.Time, daysInSIT int, lhDiscount unit.DiscountRate) (cost CostComputation, err error) {
+	if daysInSIT > 0 {
+		return cost, NewInternationalNotPricedError("SIT")
+	}
+
+	linehaul, err := re.internationalLinehaulChargeComputation(weight, originZip5, destinationZip5, pickupDate)
+	if err != nil {
+		return cost, err
+	}
+
+	traceStart := re.trace.len()
+	before := linehaul.LinehaulChargeTotal
+	linehaul.LinehaulChargeTotal = lhDiscount.Apply(before)
+	re.trace.addDiscount("Linehaul charge total", lhDiscount, before, linehaul.LinehaulChargeTotal)
+	re.trace.tagSince(traceStart, "LHS")
+
+	cost = CostComputation{
+		LinehaulCostComputation: linehaul,
+		GCC:                     linehaul.LinehaulChargeTotal,
+		LHDiscount:              lhDiscount,
+		Weight:                  weight,
+	}
+	return cost, nil
+}
+
+// PortToPortDistanceCalculation returns the distance calculation for an international shipment between two addresses,
+// taken from the port-to-port rate tables rather than a route planner, which can't route to or from most OCONUS
+// locations. The shipment's NetWeight and ActualPickupDate select the rate.
+func (re *RateEngine) PortToPortDistanceCalculation(shipment models.Shipment, origin models.Address, destination models.Address) (models.DistanceCalculation, error) {
+	if shipment.NetWeight == nil {
+		return models.DistanceCalculation{}, errors.New("NetWeight is nil")
+	}
+	if shipment.ActualPickupDate == nil {
+		return models.DistanceCalculation{}, errors.New("ActualPickupDate is nil")
+	}
+
+	rate, err := re.internationalRate(*shipment.NetWeight, origin.PostalCode, destination.PostalCode, *shipment.ActualPickupDate)
+	if err != nil {
+		return models.DistanceCalculation{}, err
+	}
+
+	return models.DistanceCalculation{
+		OriginAddress:        origin,
+		OriginAddressID:      origin.ID,
+		DestinationAddress:   destination,
+		DestinationAddressID: destination.ID,
+		DistanceMiles:        rate.DistanceMiles,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package rateengine
+
+import (
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+func (suite *RateEngineSuite) setupInternationalRateTest() models.Tariff400ngInternationalRate {
+	suite.setupRateEngineTest()
+	anchorageZip3 := models.Tariff400ngZip3{
+		Zip3:          "995",
+		BasepointCity: "Anchorage",
+		State:         "AK",
+		ServiceArea:   "4",
+		RateArea:      "US8190100",
+		Region:        "2",
+	}
+	suite.MustSave(&anchorageZip3)
+	rate := models.Tariff400ngInternationalRate{
+		OriginRateArea:      "US8190100",
+		DestinationRateArea: "US4964400",
+		DistanceMiles:       4560,
+		WeightLbsLower:      0,
+		WeightLbsUpper:      10000,
+		RateCents:           unit.Cents(12345),
+		EffectiveDateLower:  testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper:  testdatagen.PeakRateCycleEnd,
+	}
+	suite.MustSave(&rate)
+	return rate
+}
+
+func (suite *RateEngineSuite) TestInternationalLinehaulChargeComputation() {
+	rate := suite.setupInternationalRateTest()
+	engine, trace := NewRateEngine(suite.DB(), suite.logger).traced()
+
+	cost, err := engine.internationalLinehaulChargeComputation(2000, "99501", "33633", testdatagen.RateEngineDate)
+	suite.FatalNoError(err)
+
+	// The port-to-port rate replaces the domestic linehaul, linehaul factors and shorthaul
+	suite.Equal(unit.Cents(12345*20), cost.BaseLinehaul)
+	suite.Equal(cost.BaseLinehaul, cost.LinehaulChargeTotal)
+	suite.Equal(unit.Cents(0), cost.OriginLinehaulFactor)
+	suite.Equal(unit.Cents(0), cost.ShorthaulCharge)
+	suite.Equal(rate.DistanceMiles, cost.Mileage)
+
+	lhsSteps := trace.StepsForItemCode("LHS")
+	suite.True(suite.traceUsesTable(lhsSteps, "tariff400ng_international_rates"))
+	suite.False(suite.traceUsesTable(lhsSteps, "tariff400ng_linehaul_rates"))
+
+	// Port-to-port rates are directional
+	_, err = engine.internationalLinehaulChargeComputation(2000, "33633", "99501", testdatagen.RateEngineDate)
+	suite.Error(err)
+}
+
+func (suite *RateEngineSuite) TestPortToPortDistanceCalculation() {
+	rate := suite.setupInternationalRateTest()
+	engine := NewRateEngine(suite.DB(), suite.logger)
+
+	weight := unit.Pound(2000)
+	pickupDate := testdatagen.RateEngineDate
+	shipment := models.Shipment{NetWeight: &weight, ActualPickupDate: &pickupDate}
+	origin := models.Address{PostalCode: "99501", State: "AK"}
+	destination := models.Address{PostalCode: "33633", State: "FL"}
+
+	distanceCalculation, err := engine.PortToPortDistanceCalculation(shipment, origin, destination)
+	suite.FatalNoError(err)
+	suite.Equal(rate.DistanceMiles, distanceCalculation.DistanceMiles)
+
+	_, err = engine.PortToPortDistanceCalculation(models.Shipment{}, origin, destination)
+	suite.Error(err)
+}
+
+func (suite *RateEngineSuite) TestComputeInternationalShipment() {
+	rate := suite.setupInternationalRateTest()
+	engine := NewRateEngine(suite.DB(), suite.logger)
+
+	weight := unit.Pound(2000)
+	pickupDate := testdatagen.RateEngineDate
+	bookDate := testdatagen.RateEngineDate
+	market := models.ShipmentMarketIHHG
+	shipment := models.Shipment{
+		Market:           &market,
+		NetWeight:        &weight,
+		ActualPickupDate: &pickupDate,
+		BookDate:         &bookDate,
+	}
+	distanceCalculation := models.DistanceCalculation{
+		OriginAddress:      models.Address{PostalCode: "99501", State: "AK"},
+		DestinationAddress: models.Address{PostalCode: "33633", State: "FL"},
+		DistanceMiles:      rate.DistanceMiles,
+	}
+
+	cost, err := engine.ComputeShipment(shipment, distanceCalculation, 0, unit.DiscountRate(0.4), unit.DiscountRate(0.3))
+	suite.FatalNoError(err)
+
+	linehaul := unit.DiscountRate(0.4).Apply(unit.Cents(12345 * 20))
+	suite.Equal(linehaul, cost.LinehaulCostComputation.LinehaulChargeTotal)
+	suite.Equal(linehaul, cost.GCC)
+	suite.Equal(unit.Cents(0), cost.NonLinehaulCostComputation.OriginService.Fee)
+	suite.Equal(unit.Cents(0), cost.NonLinehaulCostComputation.Pack.Fee)
+	suite.Equal(unit.Cents(0), cost.LinehaulCostComputation.FuelSurcharge.Fee)
+	suite.Equal(unit.Cents(0), cost.SITMax)
+	suite.False(suite.traceUsesTable(cost.Trace.Steps, "tariff400ng_service_areas"))
+	suite.False(suite.traceUsesTable(cost.Trace.Steps, "fuel_eia_diesel_prices"))
+
+	// SIT isn't in the port-to-port tables, so it's refused rather than priced domestically
+	_, err = engine.ComputeShipment(shipment, distanceCalculation, 10, unit.DiscountRate(0.4), unit.DiscountRate(0.3))
+	if suite.Error(err) {
+		rateEngineErr, ok := err.(Error)
+		suite.True(ok)
+		if ok {
+			suite.Equal(InternationalNotPriced, rateEngineErr.Code())
+		}
+	}
+}
@@ -20,8 +20,8 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 	bqNetWeight := unit.BaseQuantityFromInt(shipment.NetWeight.Int())
 	now := time.Now()
 
-	// Linehaul charges ("LHS")
-	linehaulItem, err := models.FetchTariff400ngItemByCode(db, "LHS")
+	// Linehaul charges ("LHS", or "UBL" for unaccompanied baggage)
+	linehaulItem, err := models.FetchTariff400ngItemByCode(db, shipment.LinehaulItemCode())
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +38,7 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 		AmountCents:       &cost.LinehaulCostComputation.LinehaulChargeTotal,
 		AppliedRate:       &lhAppliedRate,
 		SubmittedDate:     now,
+		PricingTrace:      cost.Trace.StepsForItemCode("LHS"),
 	}
 	lineItems = append(lineItems, linehaul)
 
@@ -56,6 +57,7 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 		AmountCents:       &cost.NonLinehaulCostComputation.OriginService.Fee,
 		AppliedRate:       &cost.NonLinehaulCostComputation.OriginService.Rate,
 		SubmittedDate:     now,
+		PricingTrace:      cost.Trace.StepsForItemCode("135A"),
 	}
 	lineItems = append(lineItems, originService)
 
@@ -74,6 +76,7 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 		AmountCents:       &cost.NonLinehaulCostComputation.DestinationService.Fee,
 		AppliedRate:       &cost.NonLinehaulCostComputation.DestinationService.Rate,
 		SubmittedDate:     now,
+		PricingTrace:      cost.Trace.StepsForItemCode("135B"),
 	}
 	lineItems = append(lineItems, destinationService)
 
@@ -94,6 +97,7 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 		AmountCents:       &packFee,
 		AppliedRate:       &packRate,
 		SubmittedDate:     now,
+		PricingTrace:      cost.Trace.StepsForItemCode("105A"),
 	}
 	lineItems = append(lineItems, fullPack)
 
@@ -114,6 +118,7 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 		AmountCents:       &unpackFee,
 		AppliedRate:       &unpackRate,
 		SubmittedDate:     now,
+		PricingTrace:      cost.Trace.StepsForItemCode("105C"),
 	}
 	lineItems = append(lineItems, fullUnpack)
 
@@ -135,6 +140,7 @@ func CreateBaseShipmentLineItems(db *pop.Connection, costByShipment CostByShipme
 		AmountCents:       &cost.LinehaulCostComputation.FuelSurcharge.Fee,
 		AppliedRate:       fsAppliedRate,
 		SubmittedDate:     now,
+		PricingTrace:      cost.Trace.StepsForItemCode("16A"),
 	}
 	lineItems = append(lineItems, fuelSurcharge)
 
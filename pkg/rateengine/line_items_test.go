@@ -63,6 +63,57 @@ func (suite *RateEngineSuite) TestCreateBaseShipmentLineItems() {
 	if item105C != nil {
 		suite.validateLineItemFields(*item16A, unit.BaseQuantityFromInt(2000), unit.BaseQuantityFromInt(1044), models.ShipmentLineItemLocationORIGIN, unit.Cents(15651), unit.Millicents(320700))
 	}
+
+	// Each line item carries only the pricing trace steps that contributed to it
+	if itemLHS != nil {
+		suite.True(suite.traceUsesTable(itemLHS.PricingTrace, "tariff400ng_linehaul_rates"))
+		suite.False(suite.traceUsesTable(itemLHS.PricingTrace, "fuel_eia_diesel_prices"))
+	}
+	if item16A != nil {
+		suite.True(suite.traceUsesTable(item16A.PricingTrace, "fuel_eia_diesel_prices"))
+		suite.False(suite.traceUsesTable(item16A.PricingTrace, "tariff400ng_linehaul_rates"))
+	}
+	if item105A != nil {
+		suite.True(suite.traceUsesTable(item105A.PricingTrace, "tariff400ng_full_pack_rates"))
+		last := item105A.PricingTrace[len(item105A.PricingTrace)-1]
+		suite.Equal(models.PricingTraceStepKindDISCOUNT, last.Kind)
+		suite.Equal(item105A.AmountCents.String(), last.Value)
+	}
+}
+
+func (suite *RateEngineSuite) TestComputeUBShipment() {
+	engine := NewRateEngine(suite.DB(), suite.logger)
+
+	tspUsers, shipments, _, err := testdatagen.CreateShipmentOfferData(suite.DB(), 1, 1, []int{1}, []models.ShipmentStatus{models.ShipmentStatusINTRANSIT}, models.SelectedMoveTypeUB)
+	suite.FatalNoError(err)
+
+	tspUser := tspUsers[0]
+	shipment := shipments[0]
+
+	assertions := testdatagen.Assertions{}
+	assertions.FuelEIADieselPrice.BaselineRate = 6
+	assertions.FuelEIADieselPrice.EIAPricePerGallonMillicents = 320700
+	testdatagen.MakeFuelEIADieselPrices(suite.DB(), assertions)
+
+	dbShipment, err := models.FetchShipmentByTSP(suite.DB(), tspUser.TransportationServiceProviderID, shipment.ID)
+	suite.FatalNoError(err)
+	suite.Equal(models.ShipmentTypeUB, dbShipment.ShipmentType)
+
+	shipmentCost, err := engine.HandleRunOnShipment(*dbShipment, dbShipment.ShippingDistance)
+	suite.FatalNoError(err)
+
+	// The base linehaul comes from the UB rate for the band rather than the HHG one
+	suite.Equal(unit.Cents(579600), shipmentCost.Cost.LinehaulCostComputation.BaseLinehaul)
+	suite.True(suite.traceUsesTable(shipmentCost.Cost.Trace.Steps, "tariff400ng_linehaul_rates"))
+}
+
+func (suite *RateEngineSuite) traceUsesTable(steps models.PricingTraceSteps, table string) bool {
+	for _, step := range steps {
+		if step.RateTable != nil && *step.RateTable == table {
+			return true
+		}
+	}
+	return false
 }
 
 func (suite *RateEngineSuite) findLineItem(lineItems []models.ShipmentLineItem, itemCode string) *models.ShipmentLineItem {
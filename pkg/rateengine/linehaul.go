@@ -1,6 +1,7 @@
 package rateengine
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -42,8 +43,8 @@ func (c LinehaulCostComputation) MarshalLogObject(encoder zapcore.ObjectEncoder)
 }
 
 // Determine the Base Linehaul (BLH)
-func (re *RateEngine) baseLinehaul(mileage int, weight unit.Pound, date time.Time) (baseLinehaulChargeCents unit.Cents, err error) {
-	baseLinehaulChargeCents, err = models.FetchBaseLinehaulRate(re.db, mileage, weight, date)
+func (re *RateEngine) baseLinehaul(rateType string, mileage int, weight unit.Pound, date time.Time) (baseLinehaulChargeCents unit.Cents, err error) {
+	baseLinehaulChargeCents, err = re.fetchBaseLinehaulRate(rateType, mileage, weight, date)
 	if err != nil {
 		re.logger.Error("Base Linehaul query didn't complete: ", zap.Error(err))
 	}
@@ -53,7 +54,7 @@ func (re *RateEngine) baseLinehaul(mileage int, weight unit.Pound, date time.Tim
 
 // Determine the Linehaul Factors (OLF and DLF)
 func (re *RateEngine) linehaulFactors(cwt unit.CWT, zip3 string, date time.Time) (linehaulFactorCents unit.Cents, err error) {
-	serviceArea, err := models.FetchTariff400ngServiceAreaForZip3(re.db, zip3, date)
+	serviceArea, err := re.fetchServiceArea(zip3, date)
 	if err != nil {
 		return 0, err
 	}
@@ -69,21 +70,23 @@ func (re *RateEngine) shorthaulCharge(mileage int, cwt unit.CWT, date time.Time)
 		zap.Int("miles", mileage))
 
 	cwtMiles := mileage * cwt.Int()
-	shorthaulChargeCents, err = models.FetchShorthaulRateCents(re.db, cwtMiles, date)
+	shorthaulChargeCents, err = re.fetchShorthaulRate(cwtMiles, date)
 
 	return shorthaulChargeCents, err
 }
 
 // Determine Linehaul Charge (LC) TOTAL
 // Formula: LC= [BLH + OLF + DLF + [SH]
-func (re *RateEngine) linehaulChargeComputation(weight unit.Pound, originZip5 string, destinationZip5 string, distanceMiles int, pickupDate time.Time) (cost LinehaulCostComputation, err error) {
+// The base linehaul is looked up from the linehaul rates of the given type.
+func (re *RateEngine) linehaulChargeComputation(rateType string, weight unit.Pound, originZip5 string, destinationZip5 string, distanceMiles int, pickupDate time.Time) (cost LinehaulCostComputation, err error) {
 	cwt := weight.ToCWT()
 	originZip3 := Zip5ToZip3(originZip5)
 	destinationZip3 := Zip5ToZip3(destinationZip5)
+	traceStart := re.trace.len()
 
 	cost.Mileage = distanceMiles
 
-	cost.BaseLinehaul, err = re.baseLinehaul(distanceMiles, weight, pickupDate)
+	cost.BaseLinehaul, err = re.baseLinehaul(rateType, distanceMiles, weight, pickupDate)
 	if err != nil {
 		return cost, errors.Wrap(err, "Failed to determine base linehaul charge")
 	}
@@ -104,6 +107,8 @@ func (re *RateEngine) linehaulChargeComputation(weight unit.Pound, originZip5 st
 		cost.OriginLinehaulFactor +
 		cost.DestinationLinehaulFactor +
 		cost.ShorthaulCharge
+	re.trace.addCharge("Linehaul charge total (base linehaul + origin and destination linehaul factors + shorthaul)", cost.LinehaulChargeTotal)
+	re.trace.tagSince(traceStart, "LHS")
 
 	re.logger.Info("Linehaul charge total calculated",
 		zap.Int("linehaul total", cost.LinehaulChargeTotal.Int()),
@@ -140,8 +145,12 @@ func (re *RateEngine) fuelSurchargeComputation(totalLinehaulCost unit.Cents, boo
 	}
 
 	fuelEIADieselPrice := fuelEIADieselPriceSlice[0]
+	re.trace.addRateLookup("fuel_eia_diesel_prices", &fuelEIADieselPrice.ID,
+		fmt.Sprintf("Fuel surcharge baseline rate (percent) for book date %s", bookDateString),
+		fmt.Sprintf("%d", fuelEIADieselPrice.BaselineRate))
 	fuelSurchargePercentage := float64(fuelEIADieselPrice.BaselineRate) / 100
 	fee := totalLinehaulCost.MultiplyFloat64(fuelSurchargePercentage)
+	re.trace.addRounding("Fuel surcharge", float64(totalLinehaulCost.Int())*fuelSurchargePercentage, fee)
 
 	return FeeAndRate{Fee: unit.Cents(fee), Rate: fuelEIADieselPrice.EIAPricePerGallonMillicents}, err
 }
@@ -41,7 +41,7 @@ func (suite *RateEngineSuite) Test_CheckBaseLinehaul() {
 	weight := unit.Pound(3900)
 	date := testdatagen.DateInsidePeakRateCycle
 
-	blh, err := engine.baseLinehaul(mileage, weight, date)
+	blh, err := engine.baseLinehaul(models.LinehaulRateTypeConus, mileage, weight, date)
 	if blh != expected {
 		t.Errorf("BaseLinehaulCents should have been %d but is %d.", expected, blh)
 	}
@@ -50,6 +50,38 @@ func (suite *RateEngineSuite) Test_CheckBaseLinehaul() {
 	}
 }
 
+func (suite *RateEngineSuite) Test_CheckUBBaseLinehaul() {
+	engine := NewRateEngine(suite.DB(), suite.logger)
+
+	hhgBaseLinehaul := models.Tariff400ngLinehaulRate{
+		DistanceMilesLower: 3101,
+		DistanceMilesUpper: 3300,
+		WeightLbsLower:     3000,
+		WeightLbsUpper:     4000,
+		RateCents:          128000,
+		Type:               models.LinehaulRateTypeConus,
+		EffectiveDateLower: testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper: testdatagen.PeakRateCycleEnd,
+	}
+	ubBaseLinehaul := models.Tariff400ngLinehaulRate{
+		DistanceMilesLower: 3101,
+		DistanceMilesUpper: 3300,
+		WeightLbsLower:     3000,
+		WeightLbsUpper:     4000,
+		RateCents:          214000,
+		Type:               models.LinehaulRateTypeConusUB,
+		EffectiveDateLower: testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper: testdatagen.PeakRateCycleEnd,
+	}
+	suite.MustSave(&hhgBaseLinehaul)
+	suite.MustSave(&ubBaseLinehaul)
+
+	rateType := models.LinehaulRateTypeForShipmentType(models.ShipmentTypeUB)
+	blh, err := engine.baseLinehaul(rateType, 3200, unit.Pound(3900), testdatagen.DateInsidePeakRateCycle)
+	suite.NoError(err)
+	suite.Equal(ubBaseLinehaul.RateCents, blh)
+}
+
 func (suite *RateEngineSuite) Test_CheckLinehaulFactors() {
 	t := suite.T()
 	engine := NewRateEngine(suite.DB(), suite.logger)
@@ -187,7 +219,7 @@ func (suite *RateEngineSuite) Test_CheckLinehaulChargeTotal() {
 	suite.MustSave(&sa2)
 
 	cost, err := engine.linehaulChargeComputation(
-		weight, zip5Austin, zip5SanFrancisco, distanceMiles, testdatagen.DateInsidePeakRateCycle)
+		models.LinehaulRateTypeConus, weight, zip5Austin, zip5SanFrancisco, distanceMiles, testdatagen.DateInsidePeakRateCycle)
 	if err != nil {
 		t.Error("Unable to determine linehaulChargeTotal: ", err)
 	}
@@ -8,7 +8,6 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
-	"github.com/transcom/mymove/pkg/models"
 	"github.com/transcom/mymove/pkg/unit"
 )
 
@@ -57,7 +56,7 @@ func (s SITComputation) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 
 // serviceFeeCents returns the NON-DISCOUNTED rate in millicents with the fee
 func (re *RateEngine) serviceFeeCents(cwt unit.CWT, zip3 string, date time.Time) (FeeAndRate, error) {
-	serviceArea, err := models.FetchTariff400ngServiceAreaForZip3(re.db, zip3, date)
+	serviceArea, err := re.fetchServiceArea(zip3, date)
 	if err != nil {
 		return FeeAndRate{}, err
 	}
@@ -68,12 +67,12 @@ func (re *RateEngine) serviceFeeCents(cwt unit.CWT, zip3 string, date time.Time)
 
 // fullPackCents Returns the NON-DISCOUNTED rate in millicents with the fee
 func (re *RateEngine) fullPackCents(cwt unit.CWT, zip3 string, date time.Time) (FeeAndRate, error) {
-	serviceArea, err := models.FetchTariff400ngServiceAreaForZip3(re.db, zip3, date)
+	serviceArea, err := re.fetchServiceArea(zip3, date)
 	if err != nil {
 		return FeeAndRate{}, err
 	}
 
-	fullPackRate, err := models.FetchTariff400ngFullPackRateCents(re.db, cwt.ToPounds(), serviceArea.ServicesSchedule, date)
+	fullPackRate, err := re.fetchFullPackRate(cwt.ToPounds(), serviceArea.ServicesSchedule, date)
 	if err != nil {
 		return FeeAndRate{}, err
 	}
@@ -83,17 +82,21 @@ func (re *RateEngine) fullPackCents(cwt unit.CWT, zip3 string, date time.Time) (
 
 // fullUnpackCents Returns the NON-DISCOUNTED rate in millicents with the fee
 func (re *RateEngine) fullUnpackCents(cwt unit.CWT, zip3 string, date time.Time) (FeeAndRate, error) {
-	serviceArea, err := models.FetchTariff400ngServiceAreaForZip3(re.db, zip3, date)
+	serviceArea, err := re.fetchServiceArea(zip3, date)
 	if err != nil {
 		return FeeAndRate{}, err
 	}
 
-	fullUnpackRate, err := models.FetchTariff400ngFullUnpackRateMillicents(re.db, serviceArea.ServicesSchedule, date)
+	fullUnpackRate, err := re.fetchFullUnpackRate(serviceArea.ServicesSchedule, date)
 	if err != nil {
 		return FeeAndRate{}, err
 	}
 
-	return FeeAndRate{Fee: unit.Cents(math.Round(float64(cwt.Int()*fullUnpackRate) / 1000.0)), Rate: unit.Millicents(fullUnpackRate)}, nil
+	unroundedFee := float64(cwt.Int()*fullUnpackRate) / 1000.0
+	fee := unit.Cents(math.Round(unroundedFee))
+	re.trace.addRounding("Full unpack fee", unroundedFee, fee)
+
+	return FeeAndRate{Fee: fee, Rate: unit.Millicents(fullUnpackRate)}, nil
 }
 
 // SitCharge calculates the SIT charge based on various factors.
@@ -115,7 +118,7 @@ func (re *RateEngine) SitCharge(cwt unit.CWT, daysInSIT int, zip3 string, date t
 		}
 	}
 
-	sa, err := models.FetchTariff400ngServiceAreaForZip3(re.db, zip3, date)
+	sa, err := re.fetchServiceArea(zip3, date)
 	if err != nil {
 		return SITComputation{}, err
 	}
@@ -147,13 +150,13 @@ func (re *RateEngine) SitCharge(cwt unit.CWT, daysInSIT int, zip3 string, date t
 		//   (185B SIT additional day rate * additional days * CWT) +
 		//   210A SIT PD 30 miles or less for SIT PD schedule of service area +
 		//   225A SIT PD Self/Mini Storage for services schedule of service area
-		rate210A, err := models.FetchTariff400ngItemRate(re.db, "210A", sa.SITPDSchedule, effectiveCWT.ToPounds(), date)
+		rate210A, err := re.fetchItemRate("210A", sa.SITPDSchedule, effectiveCWT.ToPounds(), date)
 		if err != nil {
 			return SITComputation{}, errors.Wrapf(err, "No 210A rate found for schedule %v, %v pounds, date %v", sa.SITPDSchedule, effectiveCWT.ToPounds(), date)
 		}
 		sitPart = sitPart.AddCents(rate210A.RateCents)
 
-		rate225A, err := models.FetchTariff400ngItemRate(re.db, "225A", sa.ServicesSchedule, effectiveCWT.ToPounds(), date)
+		rate225A, err := re.fetchItemRate("225A", sa.ServicesSchedule, effectiveCWT.ToPounds(), date)
 		if err != nil {
 			return SITComputation{}, errors.Wrapf(err, "No 225A rate found for schedule %v, %v pounds, date %v", sa.ServicesSchedule, effectiveCWT.ToPounds(), date)
 		}
@@ -197,22 +200,30 @@ func (re *RateEngine) nonLinehaulChargeComputation(weight unit.Pound, originZip5
 	cwt := weight.ToCWT()
 	originZip3 := Zip5ToZip3(originZip5)
 	destinationZip3 := Zip5ToZip3(destinationZip5)
+	traceStart := re.trace.len()
 	cost.OriginService, err = re.serviceFeeCents(cwt, originZip3, date)
 	if err != nil {
 		return cost, errors.Wrap(err, "Failed to  determine origin service fee")
 	}
+	re.trace.tagSince(traceStart, "135A")
+	traceStart = re.trace.len()
 	cost.DestinationService, err = re.serviceFeeCents(cwt, destinationZip3, date)
 	if err != nil {
 		return cost, errors.Wrap(err, "Failed to  determine destination service fee")
 	}
+	re.trace.tagSince(traceStart, "135B")
+	traceStart = re.trace.len()
 	cost.Pack, err = re.fullPackCents(cwt, originZip3, date)
 	if err != nil {
 		return cost, errors.Wrap(err, "Failed to  determine full pack cost")
 	}
+	re.trace.tagSince(traceStart, "105A")
+	traceStart = re.trace.len()
 	cost.Unpack, err = re.fullUnpackCents(cwt, destinationZip3, date)
 	if err != nil {
 		return cost, errors.Wrap(err, "Failed to  determine full unpack cost")
 	}
+	re.trace.tagSince(traceStart, "105C")
 
 	re.logger.Info("Non-Linehaul charge total calculated",
 		zap.Int("origin service fee", cost.OriginService.Fee.Int()),
@@ -0,0 +1,71 @@
+package rateengine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// ntsTraceItemCode groups the storage steps of an NTS pricing trace
+const ntsTraceItemCode = "NTS"
+
+// NTSStorageComputation represents the cost of keeping an NTS shipment's goods in storage
+type NTSStorageComputation struct {
+	DaysInStorage int
+	StorageFee    unit.Cents
+	SITDiscount   unit.DiscountRate
+	Trace         PricingTrace
+}
+
+// ComputeNTSStorage calculates the cost of storing a weight of goods at a warehouse for a number of days.
+// Storage is priced from the warehouse's service area like SIT: the first day rate (185A) plus the
+// additional day rate (185B) for every other day, with the SIT discount applied.
+func (re *RateEngine) ComputeNTSStorage(weight unit.Pound, warehouseZip5 string, daysInStorage int, storageStartDate time.Time, sitDiscount unit.DiscountRate) (cost NTSStorageComputation, err error) {
+	re, trace := re.traced()
+
+	sitComputation, err := re.SitCharge(weight.ToCWT(), daysInStorage, Zip5ToZip3(warehouseZip5), storageStartDate, false)
+	if err != nil {
+		return cost, errors.Wrap(err, "Failed to compute storage charge")
+	}
+
+	storageFee := sitDiscount.Apply(sitComputation.SITPart)
+	trace.addCharge(fmt.Sprintf("Storage fee for %d days, discounted by %.4f", daysInStorage, sitDiscount.Float64()), storageFee)
+	trace.tagSince(0, ntsTraceItemCode)
+
+	cost = NTSStorageComputation{
+		DaysInStorage: daysInStorage,
+		StorageFee:    storageFee,
+		SITDiscount:   sitDiscount,
+		Trace:         *trace,
+	}
+
+	re.logger.Info("ComputeNTSStorage() cost computation",
+		zap.Int("days", daysInStorage),
+		zap.Int("storage fee", storageFee.Int()))
+
+	return cost, nil
+}
+
+// HandleRunOnNonTemporaryStorage prices the time an NTS shipment's goods spent in storage, up to its release
+// or the given date if it hasn't been released yet
+func (re *RateEngine) HandleRunOnNonTemporaryStorage(shipment models.Shipment, nts models.NonTemporaryStorage, asOf time.Time) (NTSStorageComputation, error) {
+	if shipment.NetWeight == nil {
+		return NTSStorageComputation{}, errors.New("NetWeight is nil")
+	}
+
+	acceptedOffer, err := shipment.AcceptedShipmentOffer()
+	if err != nil {
+		return NTSStorageComputation{}, errors.Wrap(err, "Error retrieving ACCEPTED ShipmentOffer in rateengine")
+	}
+	if acceptedOffer == nil {
+		return NTSStorageComputation{}, errors.New("Shipment has no ACCEPTED ShipmentOffer")
+	}
+
+	sitDiscount := acceptedOffer.TransportationServiceProviderPerformance.SITRate
+	return re.ComputeNTSStorage(*shipment.NetWeight, nts.WarehouseAddress.PostalCode, nts.DaysInStorage(asOf), nts.StorageStartDate, sitDiscount)
+}
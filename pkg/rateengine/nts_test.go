@@ -0,0 +1,48 @@
+package rateengine
+
+import (
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+func (suite *RateEngineSuite) TestComputeNTSStorage() {
+	engine := NewRateEngine(suite.DB(), suite.logger)
+
+	z := models.Tariff400ngZip3{
+		Zip3:          "395",
+		BasepointCity: "Saucier",
+		State:         "MS",
+		ServiceArea:   "428",
+		RateArea:      "US48",
+		Region:        "11",
+	}
+	suite.MustSave(&z)
+
+	sa := models.Tariff400ngServiceArea{
+		Name:               "Tampa, FL",
+		ServiceArea:        "428",
+		LinehaulFactor:     69,
+		ServiceChargeCents: 663,
+		ServicesSchedule:   1,
+		EffectiveDateLower: testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper: testdatagen.PeakRateCycleEnd,
+		SIT185ARateCents:   unit.Cents(2324),
+		SIT185BRateCents:   unit.Cents(431),
+		SITPDSchedule:      1,
+	}
+	suite.MustSave(&sa)
+
+	discount := unit.DiscountRate(0.5)
+	cost, err := engine.ComputeNTSStorage(unit.Pound(2000), "39574", 30, testdatagen.DateInsidePeakRateCycle, discount)
+	suite.NoError(err)
+
+	// 20 CWT * (185A + 185B * 29 additional days), discounted by half
+	expected := discount.Apply(unit.Cents(2324*20 + 431*29*20))
+	suite.Equal(30, cost.DaysInStorage)
+	suite.Equal(expected, cost.StorageFee)
+
+	steps := cost.Trace.StepsForItemCode(ntsTraceItemCode)
+	suite.NotEmpty(steps)
+	suite.True(suite.traceUsesTable(steps, "tariff400ng_service_areas"))
+}
@@ -0,0 +1,101 @@
+package rateengine
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// PPMIncentiveFactor is the share of the GCC a service member is paid as a PPM incentive
+const PPMIncentiveFactor = 0.95
+
+// MaxPPMIncentiveMatrixCells limits the number of move date and weight combinations priced in one call
+const MaxPPMIncentiveMatrixCells = 500
+
+// PPMIncentiveCell is the PPM incentive for a single move date and weight
+type PPMIncentiveCell struct {
+	MoveDate      time.Time
+	Weight        unit.Pound
+	PeakRateCycle bool
+	GCC           unit.Cents
+	Incentive     unit.Cents
+}
+
+// PPMIncentiveMatrix holds PPM incentives with a row per move date and a column per weight
+type PPMIncentiveMatrix struct {
+	MoveDates []time.Time
+	Weights   []unit.Pound
+	Cells     [][]PPMIncentiveCell
+}
+
+// isPeakRateCycle returns true if the date falls in the peak rate cycle of its year
+func isPeakRateCycle(date time.Time) bool {
+	start, end := models.GetRateCycle(date.Year(), true)
+	return !date.Before(start) && !date.After(end)
+}
+
+// ComputePPMIncentiveMatrix calculates the PPM incentive (excluding SIT) for every combination of move date and weight.
+// Discounts are looked up once per move date and, unless the engine already uses a RateCache, rate rows are
+// cached for the duration of the call so each is only queried the first time it is used.
+func (re *RateEngine) ComputePPMIncentiveMatrix(originZip5 string, destinationZip5 string, distanceMiles int, moveDates []time.Time, weights []unit.Pound) (matrix PPMIncentiveMatrix, err error) {
+	if len(moveDates)*len(weights) > MaxPPMIncentiveMatrixCells {
+		return matrix, errors.Errorf("Incentive matrix of %d move dates and %d weights is larger than %d cells",
+			len(moveDates), len(weights), MaxPPMIncentiveMatrixCells)
+	}
+
+	cache, ok := re.rates.(*RateCache)
+	if !ok {
+		cache = NewRateCache(re.rates, DefaultRateCacheCheckInterval, DefaultRateCacheSize)
+		re = re.WithRateFetcher(cache)
+	}
+	hitsBefore, missesBefore := cache.Stats()
+	matrix.MoveDates = moveDates
+	matrix.Weights = weights
+	matrix.Cells = make([][]PPMIncentiveCell, len(moveDates))
+
+	for i, moveDate := range moveDates {
+		lhDiscount, _, err := re.fetchPPMDiscounts(originZip5, destinationZip5, moveDate)
+		if err != nil {
+			return PPMIncentiveMatrix{}, errors.Wrapf(err, "Fetching discounts for move date %s", moveDate.Format(traceDateFormat))
+		}
+		peak := isPeakRateCycle(moveDate)
+
+		matrix.Cells[i] = make([]PPMIncentiveCell, len(weights))
+		for j, weight := range weights {
+			cost, err := re.ComputePPM(weight,
+				originZip5,
+				destinationZip5,
+				distanceMiles,
+				moveDate,
+				0, // We don't want any SIT charges
+				lhDiscount,
+				0.0,
+			)
+			if err != nil {
+				return PPMIncentiveMatrix{}, errors.Wrapf(err, "Computing incentive for %d lbs on %s", weight.Int(), moveDate.Format(traceDateFormat))
+			}
+
+			matrix.Cells[i][j] = PPMIncentiveCell{
+				MoveDate:      moveDate,
+				Weight:        weight,
+				PeakRateCycle: peak,
+				GCC:           cost.GCC,
+				Incentive:     cost.GCC.MultiplyFloat64(PPMIncentiveFactor),
+			}
+		}
+	}
+
+	hits, misses := cache.Stats()
+	re.logger.Info("PPM incentive matrix computed",
+		zap.Int("move dates", len(moveDates)),
+		zap.Int("weights", len(weights)),
+		zap.Int("rate cache hits", hits-hitsBefore),
+		zap.Int("rate cache misses", misses-missesBefore),
+	)
+
+	return matrix, nil
+}
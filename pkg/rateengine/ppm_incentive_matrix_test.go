@@ -0,0 +1,55 @@
+package rateengine
+
+import (
+	"time"
+
+	"github.com/transcom/mymove/pkg/route"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+func (suite *RateEngineSuite) TestComputePPMIncentiveMatrix() {
+	originZip := "39574"
+	destinationZip := "33633"
+	weight := unit.Pound(2000)
+	cost, err := suite.computePPMIncludingLHRates(originZip, destinationZip, weight, suite.logger, route.NewTestingPlanner(1044))
+	suite.FatalNoError(err)
+
+	moveDates := []time.Time{testdatagen.RateEngineDate, testdatagen.RateEngineDate.AddDate(0, 0, 7)}
+	weights := []unit.Pound{weight}
+
+	engine := NewRateEngine(suite.DB(), suite.logger)
+	matrix, err := engine.ComputePPMIncentiveMatrix(originZip, destinationZip, 1044, moveDates, weights)
+	suite.FatalNoError(err)
+
+	suite.Equal(moveDates, matrix.MoveDates)
+	suite.Equal(weights, matrix.Weights)
+	if suite.Len(matrix.Cells, 2) {
+		for i, row := range matrix.Cells {
+			if suite.Len(row, 1) {
+				// Each cell matches pricing the move date and weight on its own
+				suite.Equal(moveDates[i], row[0].MoveDate)
+				suite.Equal(weight, row[0].Weight)
+				suite.True(row[0].PeakRateCycle)
+				suite.Equal(cost.GCC, row[0].GCC)
+				suite.Equal(cost.GCC.MultiplyFloat64(PPMIncentiveFactor), row[0].Incentive)
+			}
+		}
+	}
+}
+
+func (suite *RateEngineSuite) TestComputePPMIncentiveMatrixTooLarge() {
+	moveDates := make([]time.Time, MaxPPMIncentiveMatrixCells+1)
+	weights := []unit.Pound{unit.Pound(2000)}
+
+	engine := NewRateEngine(suite.DB(), suite.logger)
+	_, err := engine.ComputePPMIncentiveMatrix("39574", "33633", 1044, moveDates, weights)
+	suite.Error(err)
+}
+
+func (suite *RateEngineSuite) TestIsPeakRateCycle() {
+	suite.True(isPeakRateCycle(testdatagen.PeakRateCycleStart))
+	suite.True(isPeakRateCycle(testdatagen.PeakRateCycleEnd))
+	suite.False(isPeakRateCycle(testdatagen.NonPeakRateCycleStart))
+	suite.False(isPeakRateCycle(time.Date(testdatagen.TestYear, time.January, 15, 0, 0, 0, 0, time.UTC)))
+}
@@ -5,64 +5,75 @@ import (
 )
 
 type pricer interface {
-	price(rate unit.Cents, q1 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents
+	quantities() pricerQuantities
+	price(rate unit.Cents, q1 unit.BaseQuantity, q2 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents
 }
 
-// Basic pricer, multiplies the rate against the provided quantity
-type basicQuantityPricer struct{}
-
-func newBasicQuantityPricer() basicQuantityPricer {
-	return basicQuantityPricer{}
+// quantitySpec declares how a pricer consumes one of a line item's quantities
+type quantitySpec struct {
+	// A required quantity must be supplied before the item can be priced. An optional
+	// quantity that isn't supplied leaves the rate unscaled.
+	required bool
+	// Quantities below the minimum are priced as the minimum
+	min int
+	// Hundredweight quantities are divided by 100 before being applied, i.e. pounds to CWT
+	hundredweight bool
 }
 
-func (m basicQuantityPricer) price(rate unit.Cents, q1 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents {
-	calculatedRate := rate.MultiplyFloat64(q1.ToUnitFloat())
-
-	if discount != nil {
-		calculatedRate = discount.Apply(calculatedRate)
+func (s quantitySpec) factor(q unit.BaseQuantity) float64 {
+	value := q.ToUnitFloat()
+	if !s.required && value == 0 {
+		return 1.0
 	}
 
-	return calculatedRate
-}
+	if value < float64(s.min) {
+		value = float64(s.min)
+	}
+	if s.hundredweight {
+		value = value / 100.0
+	}
 
-// Like the basic pricer, but enforces a minimum value for the quantity
-type minimumQuantityPricer struct {
-	min int
+	return value
 }
 
-func newMinimumQuantityPricer(min int) minimumQuantityPricer {
-	return minimumQuantityPricer{min}
+// pricerQuantities declares which of the two line item quantities a pricer consumes. A nil spec
+// means the quantity is ignored.
+type pricerQuantities struct {
+	q1 *quantitySpec
+	q2 *quantitySpec
 }
 
-func (m minimumQuantityPricer) price(rate unit.Cents, q1 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents {
-	if qConv := q1.ToUnitFloat(); qConv < float64(m.min) {
-		q1 = unit.BaseQuantityFromInt(m.min)
+// missing returns the number (1 or 2) of the first required quantity that is not supplied, or 0 if none are missing
+func (p pricerQuantities) missing(q1 unit.BaseQuantity, q2 unit.BaseQuantity) int {
+	if p.q1 != nil && p.q1.required && q1 <= 0 {
+		return 1
 	}
-
-	calculatedRate := rate.MultiplyFloat64(q1.ToUnitFloat())
-
-	if discount != nil {
-		calculatedRate = discount.Apply(calculatedRate)
+	if p.q2 != nil && p.q2.required && q2 <= 0 {
+		return 2
 	}
 
-	return calculatedRate
+	return 0
 }
 
-// Line the min quantity pricer, but multiplies rate by quantity / 100
-type minimumQuantityHundredweightPricer struct {
-	min int
+// Multiplies the rate against each quantity it consumes, then applies the discount
+type quantityPricer struct {
+	pricerQuantities
 }
 
-func newMinimumQuantityHundredweightPricer(min int) minimumQuantityHundredweightPricer {
-	return minimumQuantityHundredweightPricer{min}
+func (m quantityPricer) quantities() pricerQuantities {
+	return m.pricerQuantities
 }
 
-func (m minimumQuantityHundredweightPricer) price(rate unit.Cents, q1 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents {
-	if qConv := q1.ToUnitFloat(); qConv < float64(m.min) {
-		q1 = unit.BaseQuantityFromInt(m.min)
+func (m quantityPricer) price(rate unit.Cents, q1 unit.BaseQuantity, q2 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents {
+	factor := 1.0
+	if m.q1 != nil {
+		factor *= m.q1.factor(q1)
+	}
+	if m.q2 != nil {
+		factor *= m.q2.factor(q2)
 	}
 
-	calculatedRate := rate.MultiplyFloat64(q1.ToUnitFloat() / 100.0)
+	calculatedRate := rate.MultiplyFloat64(factor)
 
 	if discount != nil {
 		calculatedRate = discount.Apply(calculatedRate)
@@ -71,19 +82,45 @@ func (m minimumQuantityHundredweightPricer) price(rate unit.Cents, q1 unit.BaseQ
 	return calculatedRate
 }
 
-// Ignores quantity, just returns rate with discount applied
-type flatRatePricer struct{}
+// Basic pricer, multiplies the rate against the provided quantity
+func newBasicQuantityPricer() quantityPricer {
+	return quantityPricer{pricerQuantities{
+		q1: &quantitySpec{required: true},
+	}}
+}
+
+// Like the basic pricer, but also multiplies by the second quantity when one is provided (e.g. hours * number of workers)
+func newBasicTwoQuantityPricer() quantityPricer {
+	return quantityPricer{pricerQuantities{
+		q1: &quantitySpec{required: true},
+		q2: &quantitySpec{},
+	}}
+}
 
-func newFlatRatePricer() flatRatePricer {
-	return flatRatePricer{}
+// Like the basic pricer, but enforces a minimum value for the quantity
+func newMinimumQuantityPricer(min int) quantityPricer {
+	return quantityPricer{pricerQuantities{
+		q1: &quantitySpec{required: true, min: min},
+	}}
 }
 
-func (m flatRatePricer) price(rate unit.Cents, q1 unit.BaseQuantity, discount *unit.DiscountRate) unit.Cents {
-	calculatedRate := rate
+// Like the min quantity pricer, but multiplies rate by quantity / 100
+func newMinimumQuantityHundredweightPricer(min int) quantityPricer {
+	return quantityPricer{pricerQuantities{
+		q1: &quantitySpec{required: true, min: min, hundredweight: true},
+	}}
+}
 
-	if discount != nil {
-		calculatedRate = discount.Apply(calculatedRate)
-	}
+// Like the min quantity hundredweight pricer, but applies the weight minimum to the second quantity
+// and multiplies by the first quantity, such as a number of days
+func newMinimumWeightTimesQuantityPricer(min int) quantityPricer {
+	return quantityPricer{pricerQuantities{
+		q1: &quantitySpec{required: true},
+		q2: &quantitySpec{required: true, min: min, hundredweight: true},
+	}}
+}
 
-	return calculatedRate
+// Ignores quantity, just returns rate with discount applied
+func newFlatRatePricer() quantityPricer {
+	return quantityPricer{}
 }
@@ -7,11 +7,12 @@ import (
 )
 
 type pricerTestCase struct {
-	pricer   pricer
-	rate     unit.Cents
-	quantity unit.BaseQuantity
-	discount *unit.DiscountRate
-	expected unit.Cents
+	pricer    pricer
+	rate      unit.Cents
+	quantity  unit.BaseQuantity
+	quantity2 unit.BaseQuantity
+	discount  *unit.DiscountRate
+	expected  unit.Cents
 }
 
 func discountPtr(d float64) *unit.DiscountRate {
@@ -20,26 +21,55 @@ func discountPtr(d float64) *unit.DiscountRate {
 }
 
 var pricersTestCases = []pricerTestCase{
-	pricerTestCase{newBasicQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(1), nil, unit.Cents(100)},
-	pricerTestCase{newBasicQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(1), discountPtr(0.5), unit.Cents(50)},
+	pricerTestCase{newBasicQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(1), unit.BaseQuantity(0), nil, unit.Cents(100)},
+	pricerTestCase{newBasicQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(1), unit.BaseQuantity(0), discountPtr(0.5), unit.Cents(50)},
 
-	pricerTestCase{newMinimumQuantityPricer(10), unit.Cents(100), unit.BaseQuantityFromInt(100), nil, unit.Cents(10000)},
-	pricerTestCase{newMinimumQuantityPricer(10), unit.Cents(100), unit.BaseQuantityFromInt(5), nil, unit.Cents(1000)},
-	pricerTestCase{newMinimumQuantityPricer(10), unit.Cents(100), unit.BaseQuantityFromInt(100), discountPtr(0.5), unit.Cents(5000)},
+	pricerTestCase{newMinimumQuantityPricer(10), unit.Cents(100), unit.BaseQuantityFromInt(100), unit.BaseQuantity(0), nil, unit.Cents(10000)},
+	pricerTestCase{newMinimumQuantityPricer(10), unit.Cents(100), unit.BaseQuantityFromInt(5), unit.BaseQuantity(0), nil, unit.Cents(1000)},
+	pricerTestCase{newMinimumQuantityPricer(10), unit.Cents(100), unit.BaseQuantityFromInt(100), unit.BaseQuantity(0), discountPtr(0.5), unit.Cents(5000)},
 
-	pricerTestCase{newMinimumQuantityHundredweightPricer(100), unit.Cents(100), unit.BaseQuantityFromInt(1000), nil, unit.Cents(1000)},
-	pricerTestCase{newMinimumQuantityHundredweightPricer(100), unit.Cents(100), unit.BaseQuantityFromInt(50), nil, unit.Cents(100)},
-	pricerTestCase{newMinimumQuantityHundredweightPricer(100), unit.Cents(100), unit.BaseQuantityFromInt(1000), discountPtr(0.5), unit.Cents(500)},
+	pricerTestCase{newMinimumQuantityHundredweightPricer(100), unit.Cents(100), unit.BaseQuantityFromInt(1000), unit.BaseQuantity(0), nil, unit.Cents(1000)},
+	pricerTestCase{newMinimumQuantityHundredweightPricer(100), unit.Cents(100), unit.BaseQuantityFromInt(50), unit.BaseQuantity(0), nil, unit.Cents(100)},
+	pricerTestCase{newMinimumQuantityHundredweightPricer(100), unit.Cents(100), unit.BaseQuantityFromInt(1000), unit.BaseQuantity(0), discountPtr(0.5), unit.Cents(500)},
 
-	pricerTestCase{newFlatRatePricer(), unit.Cents(100), unit.BaseQuantityFromInt(9999), nil, unit.Cents(100)},
-	pricerTestCase{newFlatRatePricer(), unit.Cents(100), unit.BaseQuantityFromInt(9999), discountPtr(0.5), unit.Cents(50)},
+	pricerTestCase{newFlatRatePricer(), unit.Cents(100), unit.BaseQuantityFromInt(9999), unit.BaseQuantity(0), nil, unit.Cents(100)},
+	pricerTestCase{newFlatRatePricer(), unit.Cents(100), unit.BaseQuantityFromInt(9999), unit.BaseQuantity(0), discountPtr(0.5), unit.Cents(50)},
+
+	pricerTestCase{newMinimumWeightTimesQuantityPricer(1000), unit.Cents(100), unit.BaseQuantityFromInt(5), unit.BaseQuantityFromInt(2000), nil, unit.Cents(10000)},
+	pricerTestCase{newMinimumWeightTimesQuantityPricer(1000), unit.Cents(100), unit.BaseQuantityFromInt(5), unit.BaseQuantityFromInt(500), nil, unit.Cents(5000)},
+	pricerTestCase{newMinimumWeightTimesQuantityPricer(1000), unit.Cents(100), unit.BaseQuantityFromInt(5), unit.BaseQuantityFromInt(2000), discountPtr(0.5), unit.Cents(5000)},
+
+	pricerTestCase{newBasicTwoQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(2), unit.BaseQuantityFromInt(3), nil, unit.Cents(600)},
+	pricerTestCase{newBasicTwoQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(2), unit.BaseQuantity(0), nil, unit.Cents(200)},
+	pricerTestCase{newBasicTwoQuantityPricer(), unit.Cents(100), unit.BaseQuantityFromInt(2), unit.BaseQuantityFromInt(3), discountPtr(0.5), unit.Cents(300)},
+
+	// 175A is priced per pound of the shipment's weight, with a 1000 lb minimum
+	pricerTestCase{tariff400ngItemPricing["175A"], unit.Cents(100), unit.BaseQuantityFromInt(2000), unit.BaseQuantity(0), nil, unit.Cents(200000)},
+	pricerTestCase{tariff400ngItemPricing["175A"], unit.Cents(100), unit.BaseQuantityFromInt(500), unit.BaseQuantity(0), nil, unit.Cents(100000)},
 }
 
 func (suite *RateEngineSuite) TestPricersTestCases() {
 	for i, testCase := range pricersTestCases {
-		result := testCase.pricer.price(testCase.rate, testCase.quantity, testCase.discount)
+		result := testCase.pricer.price(testCase.rate, testCase.quantity, testCase.quantity2, testCase.discount)
 		if !suite.Equal(result, testCase.expected) {
 			fmt.Printf("Failure on test case %d (0 indexed)\n", i)
 		}
 	}
 }
+
+func (suite *RateEngineSuite) TestPricerMissingQuantities() {
+	zero := unit.BaseQuantity(0)
+	one := unit.BaseQuantityFromInt(1)
+
+	suite.Equal(0, newFlatRatePricer().quantities().missing(zero, zero))
+	suite.Equal(1, newBasicQuantityPricer().quantities().missing(zero, zero))
+	suite.Equal(0, newBasicQuantityPricer().quantities().missing(one, zero))
+
+	// The second quantity is optional for the basic two quantity pricer
+	suite.Equal(0, newBasicTwoQuantityPricer().quantities().missing(one, zero))
+
+	// Both quantities are required for the weight times quantity pricer
+	suite.Equal(1, newMinimumWeightTimesQuantityPricer(1000).quantities().missing(zero, one))
+	suite.Equal(2, newMinimumWeightTimesQuantityPricer(1000).quantities().missing(one, zero))
+	suite.Equal(0, newMinimumWeightTimesQuantityPricer(1000).quantities().missing(one, one))
+}
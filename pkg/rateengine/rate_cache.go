@@ -0,0 +1,260 @@
+package rateengine
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// DefaultRateCacheCheckInterval is how often a RateCache checks whether the rate tables have been loaded again
+const DefaultRateCacheCheckInterval = time.Minute
+
+// DefaultRateCacheSize is the number of lookup keys a RateCache holds before evicting the least recently used
+const DefaultRateCacheSize = 10000
+
+// ratesUpdatedAter is implemented by rate fetchers that can tell when the rate tables last changed
+type ratesUpdatedAter interface {
+	RatesUpdatedAt() (time.Time, error)
+}
+
+// cachedRates are the rows cached for a lookup key
+type cachedRates struct {
+	key   string
+	rates []cachedRate
+}
+
+// cachedRate is a tariff 400ng row along with the dates it is effective for
+type cachedRate struct {
+	effectiveDateLower time.Time
+	effectiveDateUpper time.Time
+	row                interface{}
+}
+
+// RateCache is a read-through cache of tariff 400ng rows in front of another RateFetcher.
+// Rows are keyed by table and lookup key, and a cached row is used for any date within its effective date window.
+// Lookup keys include exact distances and weights, so the cache holds at most maxKeys of them, evicting the least
+// recently used. It is safe for concurrent use.
+type RateCache struct {
+	source        RateFetcher
+	checkInterval time.Duration
+	maxKeys       int
+	now           func() time.Time
+
+	mutex     sync.RWMutex
+	rates     map[string]*list.Element
+	recent    *list.List
+	checkedAt time.Time
+	updatedAt time.Time
+	hits      int
+	misses    int
+}
+
+// NewRateCache creates a new RateCache that reads through to source, holding up to maxKeys lookup keys. If source
+// can tell when the rate tables last changed, the cache is invalidated when they change, checking at most once
+// per checkInterval.
+func NewRateCache(source RateFetcher, checkInterval time.Duration, maxKeys int) *RateCache {
+	if maxKeys < 1 {
+		maxKeys = DefaultRateCacheSize
+	}
+	return &RateCache{
+		source:        source,
+		checkInterval: checkInterval,
+		maxKeys:       maxKeys,
+		now:           time.Now,
+		rates:         map[string]*list.Element{},
+		recent:        list.New(),
+	}
+}
+
+// Invalidate empties the cache, such as after new rates have been loaded
+func (c *RateCache) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.clear()
+}
+
+// clear empties the cache. The caller must hold the write lock.
+func (c *RateCache) clear() {
+	c.rates = map[string]*list.Element{}
+	c.recent = list.New()
+}
+
+// Stats returns the number of lookups answered from the cache and from the source
+func (c *RateCache) Stats() (hits int, misses int) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.hits, c.misses
+}
+
+// checkForRateLoad invalidates the cache if the source's rate tables have changed since the last check
+func (c *RateCache) checkForRateLoad() {
+	source, ok := c.source.(ratesUpdatedAter)
+	if !ok {
+		return
+	}
+
+	c.mutex.RLock()
+	due := c.now().Sub(c.checkedAt) >= c.checkInterval
+	c.mutex.RUnlock()
+	if !due {
+		return
+	}
+
+	updatedAt, err := source.RatesUpdatedAt()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.checkedAt = c.now()
+	if err != nil {
+		// Without knowing whether the rates changed, don't trust what's cached
+		c.clear()
+		return
+	}
+	if !updatedAt.Equal(c.updatedAt) {
+		c.clear()
+		c.updatedAt = updatedAt
+	}
+}
+
+// get returns the cached row for the key that is effective on the date, if any
+func (c *RateCache) get(key string, date time.Time) (interface{}, bool) {
+	c.checkForRateLoad()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.rates[key]; ok {
+		for _, rate := range element.Value.(*cachedRates).rates {
+			if !date.Before(rate.effectiveDateLower) && date.Before(rate.effectiveDateUpper) {
+				c.recent.MoveToFront(element)
+				c.hits++
+				return rate.row, true
+			}
+		}
+	}
+	c.misses++
+	return nil, false
+}
+
+func (c *RateCache) put(key string, lower time.Time, upper time.Time, row interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	rate := cachedRate{
+		effectiveDateLower: lower,
+		effectiveDateUpper: upper,
+		row:                row,
+	}
+	if element, ok := c.rates[key]; ok {
+		entry := element.Value.(*cachedRates)
+		entry.rates = append(entry.rates, rate)
+		c.recent.MoveToFront(element)
+		return
+	}
+
+	c.rates[key] = c.recent.PushFront(&cachedRates{key: key, rates: []cachedRate{rate}})
+	if c.recent.Len() > c.maxKeys {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.rates, oldest.Value.(*cachedRates).key)
+	}
+}
+
+// FetchServiceAreaForZip3 returns the service area for a zip3 on the given date
+func (c *RateCache) FetchServiceAreaForZip3(zip3 string, date time.Time) (models.Tariff400ngServiceArea, error) {
+	key := fmt.Sprintf("tariff400ng_service_areas:%s", zip3)
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngServiceArea), nil
+	}
+
+	serviceArea, err := c.source.FetchServiceAreaForZip3(zip3, date)
+	if err == nil {
+		c.put(key, serviceArea.EffectiveDateLower, serviceArea.EffectiveDateUpper, serviceArea)
+	}
+	return serviceArea, err
+}
+
+// FetchItemRate returns the item rate for a code, schedule and weight on the given date
+func (c *RateCache) FetchItemRate(code string, schedule int, weight unit.Pound, date time.Time) (models.Tariff400ngItemRate, error) {
+	key := fmt.Sprintf("tariff400ng_item_rates:%s:%d:%d", code, schedule, weight.Int())
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngItemRate), nil
+	}
+
+	rate, err := c.source.FetchItemRate(code, schedule, weight, date)
+	if err == nil {
+		c.put(key, rate.EffectiveDateLower, rate.EffectiveDateUpper, rate)
+	}
+	return rate, err
+}
+
+// FetchLinehaulRate returns the linehaul rate of the given type for a distance and weight on the given date
+func (c *RateCache) FetchLinehaulRate(rateType string, mileage int, weight unit.Pound, date time.Time) (models.Tariff400ngLinehaulRate, error) {
+	key := fmt.Sprintf("tariff400ng_linehaul_rates:%s:%d:%d", rateType, mileage, weight.Int())
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngLinehaulRate), nil
+	}
+
+	rate, err := c.source.FetchLinehaulRate(rateType, mileage, weight, date)
+	if err == nil {
+		c.put(key, rate.EffectiveDateLower, rate.EffectiveDateUpper, rate)
+	}
+	return rate, err
+}
+
+// FetchShorthaulRate returns the shorthaul rate for a number of CWT-miles on the given date
+func (c *RateCache) FetchShorthaulRate(cwtMiles int, date time.Time) (models.Tariff400ngShorthaulRate, error) {
+	key := fmt.Sprintf("tariff400ng_shorthaul_rates:%d", cwtMiles)
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngShorthaulRate), nil
+	}
+
+	rate, err := c.source.FetchShorthaulRate(cwtMiles, date)
+	if err == nil {
+		c.put(key, rate.EffectiveDateLower, rate.EffectiveDateUpper, rate)
+	}
+	return rate, err
+}
+
+// FetchFullPackRate returns the full pack rate for a weight and schedule on the given date
+func (c *RateCache) FetchFullPackRate(weight unit.Pound, schedule int, date time.Time) (models.Tariff400ngFullPackRate, error) {
+	key := fmt.Sprintf("tariff400ng_full_pack_rates:%d:%d", weight.Int(), schedule)
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngFullPackRate), nil
+	}
+
+	rate, err := c.source.FetchFullPackRate(weight, schedule, date)
+	if err == nil {
+		c.put(key, rate.EffectiveDateLower, rate.EffectiveDateUpper, rate)
+	}
+	return rate, err
+}
+
+// FetchFullUnpackRate returns the full unpack rate for a schedule on the given date
+func (c *RateCache) FetchFullUnpackRate(schedule int, date time.Time) (models.Tariff400ngFullUnpackRate, error) {
+	key := fmt.Sprintf("tariff400ng_full_unpack_rates:%d", schedule)
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngFullUnpackRate), nil
+	}
+
+	rate, err := c.source.FetchFullUnpackRate(schedule, date)
+	if err == nil {
+		c.put(key, rate.EffectiveDateLower, rate.EffectiveDateUpper, rate)
+	}
+	return rate, err
+}
+
+// FetchInternationalRate returns the port-to-port rate between two rate areas for a weight on the given date
+func (c *RateCache) FetchInternationalRate(originRateArea string, destinationRateArea string, weight unit.Pound, date time.Time) (models.Tariff400ngInternationalRate, error) {
+	key := fmt.Sprintf("tariff400ng_international_rates:%s:%s:%d", originRateArea, destinationRateArea, weight.Int())
+	if row, ok := c.get(key, date); ok {
+		return row.(models.Tariff400ngInternationalRate), nil
+	}
+
+	rate, err := c.source.FetchInternationalRate(originRateArea, destinationRateArea, weight, date)
+	if err == nil {
+		c.put(key, rate.EffectiveDateLower, rate.EffectiveDateUpper, rate)
+	}
+	return rate, err
+}
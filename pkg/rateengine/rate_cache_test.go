@@ -0,0 +1,131 @@
+package rateengine
+
+import (
+	"time"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// countingRateFetcher counts the service area and linehaul rate lookups that reach the database
+type countingRateFetcher struct {
+	DBRateFetcher
+	serviceAreaFetches int
+	linehaulFetches    int
+}
+
+func (f *countingRateFetcher) FetchLinehaulRate(rateType string, mileage int, weight unit.Pound, date time.Time) (models.Tariff400ngLinehaulRate, error) {
+	f.linehaulFetches++
+	return f.DBRateFetcher.FetchLinehaulRate(rateType, mileage, weight, date)
+}
+
+func (f *countingRateFetcher) FetchServiceAreaForZip3(zip3 string, date time.Time) (models.Tariff400ngServiceArea, error) {
+	f.serviceAreaFetches++
+	return f.DBRateFetcher.FetchServiceAreaForZip3(zip3, date)
+}
+
+func (suite *RateEngineSuite) TestRateCacheReadsThrough() {
+	suite.setupRateEngineTest()
+	source := &countingRateFetcher{DBRateFetcher: NewDBRateFetcher(suite.DB())}
+	cache := NewRateCache(source, time.Hour, DefaultRateCacheSize)
+
+	serviceArea, err := cache.FetchServiceAreaForZip3("395", testdatagen.RateEngineDate)
+	suite.FatalNoError(err)
+	suite.Equal("428", serviceArea.ServiceArea)
+	suite.Equal(1, source.serviceAreaFetches)
+
+	// Any date within the row's effective dates is answered from the cache
+	again, err := cache.FetchServiceAreaForZip3("395", testdatagen.RateEngineDate.AddDate(0, 0, 7))
+	suite.FatalNoError(err)
+	suite.Equal(serviceArea.ID, again.ID)
+	suite.Equal(1, source.serviceAreaFetches)
+
+	hits, misses := cache.Stats()
+	suite.Equal(1, hits)
+	suite.Equal(1, misses)
+
+	// Dates outside of them go to the source, and failed lookups aren't cached
+	_, err = cache.FetchServiceAreaForZip3("395", testdatagen.PeakRateCycleEnd)
+	suite.Error(err)
+	_, err = cache.FetchServiceAreaForZip3("395", testdatagen.PeakRateCycleEnd)
+	suite.Error(err)
+	suite.Equal(3, source.serviceAreaFetches)
+}
+
+func (suite *RateEngineSuite) TestRateCacheInvalidatesOnRateLoad() {
+	suite.setupRateEngineTest()
+	source := &countingRateFetcher{DBRateFetcher: NewDBRateFetcher(suite.DB())}
+	cache := NewRateCache(source, 0, DefaultRateCacheSize)
+
+	_, err := cache.FetchServiceAreaForZip3("395", testdatagen.RateEngineDate)
+	suite.FatalNoError(err)
+	_, err = cache.FetchServiceAreaForZip3("395", testdatagen.RateEngineDate)
+	suite.FatalNoError(err)
+	suite.Equal(1, source.serviceAreaFetches)
+
+	// Loading a new rate invalidates everything that was cached
+	newRate := models.Tariff400ngShorthaulRate{
+		CwtMilesLower:      100000,
+		CwtMilesUpper:      200000,
+		RateCents:          unit.Cents(1234),
+		EffectiveDateLower: testdatagen.PeakRateCycleStart,
+		EffectiveDateUpper: testdatagen.PeakRateCycleEnd,
+	}
+	suite.MustSave(&newRate)
+
+	_, err = cache.FetchServiceAreaForZip3("395", testdatagen.RateEngineDate)
+	suite.FatalNoError(err)
+	suite.Equal(2, source.serviceAreaFetches)
+
+	// As does an explicit invalidation
+	cache.Invalidate()
+	_, err = cache.FetchServiceAreaForZip3("395", testdatagen.RateEngineDate)
+	suite.FatalNoError(err)
+	suite.Equal(3, source.serviceAreaFetches)
+}
+
+func (suite *RateEngineSuite) TestRateEngineUsesRateFetcher() {
+	suite.setupRateEngineTest()
+	cache := NewRateCache(NewDBRateFetcher(suite.DB()), time.Hour, DefaultRateCacheSize)
+	engine := NewRateEngine(suite.DB(), suite.logger).WithRateFetcher(cache)
+
+	uncached, err := NewRateEngine(suite.DB(), suite.logger).ComputePPM(2000, "39574", "33633", 1234, testdatagen.RateEngineDate, 1, 0.4, 0.3)
+	suite.FatalNoError(err)
+
+	first, err := engine.ComputePPM(2000, "39574", "33633", 1234, testdatagen.RateEngineDate, 1, 0.4, 0.3)
+	suite.FatalNoError(err)
+	_, misses := cache.Stats()
+
+	second, err := engine.ComputePPM(2000, "39574", "33633", 1234, testdatagen.RateEngineDate, 1, 0.4, 0.3)
+	suite.FatalNoError(err)
+	hits, missesAfter := cache.Stats()
+
+	suite.Equal(uncached.GCC, first.GCC)
+	suite.Equal(first.GCC, second.GCC)
+	suite.Equal(misses, missesAfter)
+	suite.True(hits > 0)
+}
+
+func (suite *RateEngineSuite) TestRateCacheEvictsLeastRecentlyUsed() {
+	suite.setupRateEngineTest()
+	source := &countingRateFetcher{DBRateFetcher: NewDBRateFetcher(suite.DB())}
+	cache := NewRateCache(source, time.Hour, 2)
+
+	fetch := func(mileage int) {
+		_, err := cache.FetchLinehaulRate("ConusLinehaul", mileage, 2000, testdatagen.RateEngineDate)
+		suite.FatalNoError(err)
+	}
+
+	fetch(100)
+	fetch(200)
+	fetch(100)
+	suite.Equal(2, source.linehaulFetches)
+
+	// A third key evicts the least recently used one
+	fetch(300)
+	fetch(100)
+	suite.Equal(3, source.linehaulFetches)
+	fetch(200)
+	suite.Equal(4, source.linehaulFetches)
+}
@@ -0,0 +1,87 @@
+package rateengine
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// RateFetcher looks up the tariff 400ng rows used by the rate engine
+type RateFetcher interface {
+	FetchServiceAreaForZip3(zip3 string, date time.Time) (models.Tariff400ngServiceArea, error)
+	FetchItemRate(code string, schedule int, weight unit.Pound, date time.Time) (models.Tariff400ngItemRate, error)
+	FetchLinehaulRate(rateType string, mileage int, weight unit.Pound, date time.Time) (models.Tariff400ngLinehaulRate, error)
+	FetchShorthaulRate(cwtMiles int, date time.Time) (models.Tariff400ngShorthaulRate, error)
+	FetchFullPackRate(weight unit.Pound, schedule int, date time.Time) (models.Tariff400ngFullPackRate, error)
+	FetchFullUnpackRate(schedule int, date time.Time) (models.Tariff400ngFullUnpackRate, error)
+	FetchInternationalRate(originRateArea string, destinationRateArea string, weight unit.Pound, date time.Time) (models.Tariff400ngInternationalRate, error)
+}
+
+// DBRateFetcher looks up tariff 400ng rows directly from the database
+type DBRateFetcher struct {
+	db *pop.Connection
+}
+
+// NewDBRateFetcher creates a new DBRateFetcher
+func NewDBRateFetcher(db *pop.Connection) DBRateFetcher {
+	return DBRateFetcher{db: db}
+}
+
+// FetchServiceAreaForZip3 returns the service area for a zip3 on the given date
+func (f DBRateFetcher) FetchServiceAreaForZip3(zip3 string, date time.Time) (models.Tariff400ngServiceArea, error) {
+	return models.FetchTariff400ngServiceAreaForZip3(f.db, zip3, date)
+}
+
+// FetchItemRate returns the item rate for a code, schedule and weight on the given date
+func (f DBRateFetcher) FetchItemRate(code string, schedule int, weight unit.Pound, date time.Time) (models.Tariff400ngItemRate, error) {
+	return models.FetchTariff400ngItemRate(f.db, code, schedule, weight, date)
+}
+
+// FetchLinehaulRate returns the linehaul rate of the given type for a distance and weight on the given date
+func (f DBRateFetcher) FetchLinehaulRate(rateType string, mileage int, weight unit.Pound, date time.Time) (models.Tariff400ngLinehaulRate, error) {
+	return models.FetchTariff400ngLinehaulRate(f.db, rateType, mileage, weight, date)
+}
+
+// FetchShorthaulRate returns the shorthaul rate for a number of CWT-miles on the given date
+func (f DBRateFetcher) FetchShorthaulRate(cwtMiles int, date time.Time) (models.Tariff400ngShorthaulRate, error) {
+	return models.FetchTariff400ngShorthaulRate(f.db, cwtMiles, date)
+}
+
+// FetchFullPackRate returns the full pack rate for a weight and schedule on the given date
+func (f DBRateFetcher) FetchFullPackRate(weight unit.Pound, schedule int, date time.Time) (models.Tariff400ngFullPackRate, error) {
+	return models.FetchTariff400ngFullPackRate(f.db, weight, schedule, date)
+}
+
+// FetchFullUnpackRate returns the full unpack rate for a schedule on the given date
+func (f DBRateFetcher) FetchFullUnpackRate(schedule int, date time.Time) (models.Tariff400ngFullUnpackRate, error) {
+	return models.FetchTariff400ngFullUnpackRate(f.db, schedule, date)
+}
+
+// FetchInternationalRate returns the port-to-port rate between two rate areas for a weight on the given date
+func (f DBRateFetcher) FetchInternationalRate(originRateArea string, destinationRateArea string, weight unit.Pound, date time.Time) (models.Tariff400ngInternationalRate, error) {
+	return models.FetchTariff400ngInternationalRate(f.db, originRateArea, destinationRateArea, weight, date)
+}
+
+// RatesUpdatedAt returns the last time any row of the rate tables read by the rate engine was created or updated
+func (f DBRateFetcher) RatesUpdatedAt() (time.Time, error) {
+	var updatedAt []time.Time
+	sql := `SELECT COALESCE(MAX(updated_at), 'epoch') AS updated_at FROM (
+			SELECT updated_at FROM tariff400ng_service_areas
+			UNION ALL SELECT updated_at FROM tariff400ng_zip3s
+			UNION ALL SELECT updated_at FROM tariff400ng_item_rates
+			UNION ALL SELECT updated_at FROM tariff400ng_linehaul_rates
+			UNION ALL SELECT updated_at FROM tariff400ng_shorthaul_rates
+			UNION ALL SELECT updated_at FROM tariff400ng_full_pack_rates
+			UNION ALL SELECT updated_at FROM tariff400ng_full_unpack_rates
+			UNION ALL SELECT updated_at FROM tariff400ng_international_rates
+		) AS rates;`
+
+	err := f.db.RawQuery(sql).All(&updatedAt)
+	if err != nil || len(updatedAt) == 0 {
+		return time.Time{}, err
+	}
+	return updatedAt[0], nil
+}
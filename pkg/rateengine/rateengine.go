@@ -1,6 +1,7 @@
 package rateengine
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -18,10 +19,15 @@ import (
 // MaxSITDays is the maximum number of days of SIT that will be reimbursed.
 const MaxSITDays = 90
 
+// sitTraceItemCode groups the SIT estimate steps of a pricing trace, which don't belong to a base line item
+const sitTraceItemCode = "SIT"
+
 // RateEngine encapsulates the TSP rate engine process
 type RateEngine struct {
 	db     *pop.Connection
 	logger Logger
+	trace  *PricingTrace
+	rates  RateFetcher
 }
 
 // CostComputation represents the results of a computation.
@@ -36,6 +42,7 @@ type CostComputation struct {
 	SITDiscount unit.DiscountRate
 	Weight      unit.Pound
 	ShipmentID  uuid.UUID
+	Trace       PricingTrace
 }
 
 // Scale scales a cost computation by a multiplicative factor
@@ -91,15 +98,19 @@ func (re *RateEngine) ComputePPM(
 	lhDiscount unit.DiscountRate,
 	sitDiscount unit.DiscountRate) (cost CostComputation, err error) {
 
+	// Record the lookups made by this computation to a new pricing trace
+	re, trace := re.traced()
+
 	// Weights below 1000lbs are prorated to the 1000lb rate
 	prorateFactor := 1.0
 	if weight.Int() < 1000 {
 		prorateFactor = weight.Float64() / 1000.0
+		trace.addProrate(weight, prorateFactor)
 		weight = unit.Pound(1000)
 	}
 
 	// Linehaul charges
-	linehaulCostComputation, err := re.linehaulChargeComputation(weight, originZip5, destinationZip5, distanceMiles, date)
+	linehaulCostComputation, err := re.linehaulChargeComputation(models.LinehaulRateTypeConus, weight, originZip5, destinationZip5, distanceMiles, date)
 	if err != nil {
 		re.logger.Error("Failed to compute linehaul cost", zap.Error(err))
 		return
@@ -113,21 +124,19 @@ func (re *RateEngine) ComputePPM(
 	}
 
 	// Apply linehaul discounts
-	linehaulCostComputation.LinehaulChargeTotal = lhDiscount.Apply(linehaulCostComputation.LinehaulChargeTotal)
-	nonLinehaulCostComputation.OriginService.Fee = lhDiscount.Apply(nonLinehaulCostComputation.OriginService.Fee)
-	nonLinehaulCostComputation.DestinationService.Fee = lhDiscount.Apply(nonLinehaulCostComputation.DestinationService.Fee)
-	nonLinehaulCostComputation.Pack.Fee = lhDiscount.Apply(nonLinehaulCostComputation.Pack.Fee)
-	nonLinehaulCostComputation.Unpack.Fee = lhDiscount.Apply(nonLinehaulCostComputation.Unpack.Fee)
+	re.applyLinehaulDiscount(lhDiscount, &linehaulCostComputation, &nonLinehaulCostComputation)
 
 	// SIT
 	// Note that SIT has a different discount rate than [non]linehaul charges
 	destinationZip3 := Zip5ToZip3(destinationZip5)
+	traceStart := trace.len()
 	sitComputation, err := re.SitCharge(weight.ToCWT(), daysInSIT, destinationZip3, date, true)
 	if err != nil {
 		re.logger.Info("Can't calculate sit")
 		return
 	}
 	sitFee := sitComputation.ApplyDiscount(lhDiscount, sitDiscount)
+	trace.addCharge(fmt.Sprintf("SIT fee for %d days, SIT part discounted by %.4f and linehaul part by %.4f", daysInSIT, sitDiscount.Float64(), lhDiscount.Float64()), sitFee)
 
 	/// Max SIT
 	maxSITComputation, err := re.SitCharge(weight.ToCWT(), MaxSITDays, destinationZip3, date, true)
@@ -137,6 +146,8 @@ func (re *RateEngine) ComputePPM(
 	}
 	// Note that SIT has a different discount rate than [non]linehaul charges
 	maxSITFee := maxSITComputation.ApplyDiscount(lhDiscount, sitDiscount)
+	trace.addCharge(fmt.Sprintf("Max SIT fee for %d days", MaxSITDays), maxSITFee)
+	trace.tagSince(traceStart, sitTraceItemCode)
 
 	// Totals
 	gcc := linehaulCostComputation.LinehaulChargeTotal +
@@ -158,6 +169,8 @@ func (re *RateEngine) ComputePPM(
 
 	// Finally, scale by prorate factor
 	cost.Scale(prorateFactor)
+	trace.addCharge("GCC (linehaul + origin and destination service + pack + unpack)", cost.GCC)
+	cost.Trace = *trace
 
 	re.logger.Info("PPM cost computation", zap.Object("cost", cost))
 
@@ -167,12 +180,7 @@ func (re *RateEngine) ComputePPM(
 //ComputePPMIncludingLHDiscount Calculates the cost of a PPM move using zip + date derived linehaul discount
 func (re *RateEngine) ComputePPMIncludingLHDiscount(weight unit.Pound, originZip5 string, destinationZip5 string, distanceMiles int, date time.Time, daysInSIT int) (cost CostComputation, err error) {
 
-	lhDiscount, sitDiscount, err := models.PPMDiscountFetch(re.db,
-		re.logger,
-		originZip5,
-		destinationZip5,
-		date,
-	)
+	lhDiscount, sitDiscount, err := re.fetchPPMDiscounts(originZip5, destinationZip5, date)
 	if err != nil {
 		re.logger.Error("Failed to compute linehaul cost", zap.Error(err))
 		return
@@ -203,11 +211,15 @@ func (re *RateEngine) ComputeShipment(
 	lhDiscount unit.DiscountRate,
 	sitDiscount unit.DiscountRate) (cost CostComputation, err error) {
 
+	// Record the lookups made by this computation to a new pricing trace
+	re, trace := re.traced()
+
 	// Weights below 1000lbs are prorated to the 1000lb rate
 	prorateFactor := 1.0
 	weight := *shipment.NetWeight
 	if weight.Int() < 1000 {
 		prorateFactor = weight.Float64() / 1000.0
+		trace.addProrate(weight, prorateFactor)
 		weight = unit.Pound(1000)
 	}
 
@@ -218,8 +230,23 @@ func (re *RateEngine) ComputeShipment(
 	destinationZip := distanceCalculation.DestinationAddress.PostalCode
 	distanceMiles := distanceCalculation.DistanceMiles
 
+	// International shipments are priced entirely from port-to-port rates between rate areas
+	if shipment.IsInternational() {
+		cost, err = re.internationalShipmentComputation(weight, originZip, destinationZip, pickupDate, daysInSIT, lhDiscount)
+		if err != nil {
+			re.logger.Error("Failed to compute international shipment cost", zap.Error(err))
+			return
+		}
+		cost.SITDiscount = sitDiscount
+		cost.ShipmentID = shipment.ID
+		cost.Scale(prorateFactor)
+		cost.Trace = *trace
+		return cost, nil
+	}
+
 	// Linehaul charges
-	linehaulCostComputation, err := re.linehaulChargeComputation(weight, originZip, destinationZip, distanceMiles, pickupDate)
+	rateType := models.LinehaulRateTypeForShipmentType(shipment.ShipmentType)
+	linehaulCostComputation, err := re.linehaulChargeComputation(rateType, weight, originZip, destinationZip, distanceMiles, pickupDate)
 	if err != nil {
 		re.logger.Error("Failed to compute linehaul cost", zap.Error(err))
 		return
@@ -233,11 +260,7 @@ func (re *RateEngine) ComputeShipment(
 	}
 
 	// Apply linehaul discounts to fee
-	linehaulCostComputation.LinehaulChargeTotal = lhDiscount.Apply(linehaulCostComputation.LinehaulChargeTotal)
-	nonLinehaulCostComputation.OriginService.Fee = lhDiscount.Apply(nonLinehaulCostComputation.OriginService.Fee)
-	nonLinehaulCostComputation.DestinationService.Fee = lhDiscount.Apply(nonLinehaulCostComputation.DestinationService.Fee)
-	nonLinehaulCostComputation.Pack.Fee = lhDiscount.Apply(nonLinehaulCostComputation.Pack.Fee)
-	nonLinehaulCostComputation.Unpack.Fee = lhDiscount.Apply(nonLinehaulCostComputation.Unpack.Fee)
+	re.applyLinehaulDiscount(lhDiscount, &linehaulCostComputation, &nonLinehaulCostComputation)
 
 	// Apply linehaul discount to rate
 	// For rates with retrieved tariff rates in cents, must use ApplyToMillicents by dividing by 1000 to maintain cent level accuracy (and avoid millicent accuracy)
@@ -249,22 +272,26 @@ func (re *RateEngine) ComputeShipment(
 	// Calculate FuelSurcharge (FeeAndRate struct) and log it.
 	// We've applied the linehaul discount to the linehaulCostComputation.LinehaulChargeTotal object, so we don't need
 	// to worry about applying it again here.
+	traceStart := trace.len()
 	linehaulCostComputation.FuelSurcharge, err = re.fuelSurchargeComputation(linehaulCostComputation.LinehaulChargeTotal, bookDate)
 	if err != nil {
 		return cost, errors.Wrap(err, "Failed to calculate fuel surcharge")
 	}
+	trace.tagSince(traceStart, "16A")
 	re.logger.Info("Fuel Surcharge Calculated",
 		zap.Any("Fee and Rate", linehaulCostComputation.FuelSurcharge))
 
 	// SIT
 	// Note that SIT has a different discount rate than [non]linehaul charges
 	destinationZip3 := Zip5ToZip3(distanceCalculation.DestinationAddress.PostalCode)
+	traceStart = trace.len()
 	sitComputation, err := re.SitCharge(weight.ToCWT(), daysInSIT, destinationZip3, pickupDate, true)
 	if err != nil {
 		re.logger.Info("Can't calculate sit")
 		return
 	}
 	sitFee := sitDiscount.Apply(sitComputation.SITPart) + lhDiscount.Apply(sitComputation.LinehaulPart)
+	trace.addCharge(fmt.Sprintf("SIT fee for %d days, SIT part discounted by %.4f and linehaul part by %.4f", daysInSIT, sitDiscount.Float64(), lhDiscount.Float64()), sitFee)
 
 	/// Max SIT
 	maxSITComputation, err := re.SitCharge(weight.ToCWT(), MaxSITDays, destinationZip3, pickupDate, true)
@@ -274,6 +301,8 @@ func (re *RateEngine) ComputeShipment(
 	}
 	// Note that SIT has a different discount rate than [non]linehaul charges
 	maxSITFee := sitDiscount.Apply(maxSITComputation.SITPart) + lhDiscount.Apply(maxSITComputation.LinehaulPart)
+	trace.addCharge(fmt.Sprintf("Max SIT fee for %d days", MaxSITDays), maxSITFee)
+	trace.tagSince(traceStart, sitTraceItemCode)
 
 	// Totals
 	gcc := linehaulCostComputation.LinehaulChargeTotal +
@@ -298,12 +327,37 @@ func (re *RateEngine) ComputeShipment(
 
 	// Finally, scale by prorate factor
 	cost.Scale(prorateFactor)
+	cost.Trace = *trace
 
 	re.logger.Info("ComputeShipment() cost computation", zap.Object("cost", cost))
 
 	return cost, nil
 }
 
+// applyLinehaulDiscount applies the linehaul discount to the linehaul and non-linehaul fees, recording each to the trace
+func (re *RateEngine) applyLinehaulDiscount(lhDiscount unit.DiscountRate, linehaul *LinehaulCostComputation, nonLinehaul *NonLinehaulCostComputation) {
+	discounts := []struct {
+		itemCode    string
+		description string
+		fee         *unit.Cents
+	}{
+		{"LHS", "Linehaul charge total", &linehaul.LinehaulChargeTotal},
+		{"135A", "Origin service fee", &nonLinehaul.OriginService.Fee},
+		{"135B", "Destination service fee", &nonLinehaul.DestinationService.Fee},
+		{"105A", "Full pack fee", &nonLinehaul.Pack.Fee},
+		{"105C", "Full unpack fee", &nonLinehaul.Unpack.Fee},
+	}
+
+	for _, d := range discounts {
+		before := *d.fee
+		*d.fee = lhDiscount.Apply(before)
+
+		traceStart := re.trace.len()
+		re.trace.addDiscount(d.description, lhDiscount, before, *d.fee)
+		re.trace.tagSince(traceStart, d.itemCode)
+	}
+}
+
 // CostByShipment struct containing shipment and cost
 type CostByShipment struct {
 	Shipment models.Shipment
@@ -374,5 +428,16 @@ func (re *RateEngine) HandleRunOnShipment(shipment models.Shipment, distanceCalc
 
 // NewRateEngine creates a new RateEngine
 func NewRateEngine(db *pop.Connection, logger Logger) *RateEngine {
-	return &RateEngine{db: db, logger: logger}
+	return &RateEngine{db: db, logger: logger, rates: NewDBRateFetcher(db)}
+}
+
+// WithRateFetcher returns a copy of the rate engine that looks up tariff 400ng rates with the given
+// RateFetcher, such as a shared RateCache. A nil fetcher leaves the engine unchanged.
+func (re *RateEngine) WithRateFetcher(rates RateFetcher) *RateEngine {
+	if rates == nil {
+		return re
+	}
+	engine := *re
+	engine.rates = rates
+	return &engine
 }
@@ -0,0 +1,120 @@
+package rateengine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// The methods below wrap the tariff 400ng rate lookups made by the rate engine through its RateFetcher and
+// record each row used to the engine's pricing trace, if any.
+
+const traceDateFormat = "2006-01-02"
+
+func (re *RateEngine) fetchServiceArea(zip3 string, date time.Time) (models.Tariff400ngServiceArea, error) {
+	serviceArea, err := re.rates.FetchServiceAreaForZip3(zip3, date)
+	if err != nil {
+		return models.Tariff400ngServiceArea{}, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_service_areas", &serviceArea.ID,
+		fmt.Sprintf("Service area for zip3 %s on %s", zip3, date.Format(traceDateFormat)),
+		serviceArea.ServiceArea)
+
+	return serviceArea, nil
+}
+
+func (re *RateEngine) fetchItemRate(code string, schedule int, weight unit.Pound, date time.Time) (models.Tariff400ngItemRate, error) {
+	rate, err := re.rates.FetchItemRate(code, schedule, weight, date)
+	if err != nil {
+		return models.Tariff400ngItemRate{}, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_item_rates", &rate.ID,
+		fmt.Sprintf("Item %s rate for schedule %d, %d lbs on %s", code, schedule, weight.Int(), date.Format(traceDateFormat)),
+		rate.RateCents.String())
+
+	return rate, nil
+}
+
+func (re *RateEngine) fetchBaseLinehaulRate(rateType string, mileage int, weight unit.Pound, date time.Time) (unit.Cents, error) {
+	rate, err := re.rates.FetchLinehaulRate(rateType, mileage, weight, date)
+	if err != nil {
+		return 0, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_linehaul_rates", &rate.ID,
+		fmt.Sprintf("Base linehaul (%s) for %d miles, %d lbs on %s", rateType, mileage, weight.Int(), date.Format(traceDateFormat)),
+		rate.RateCents.String())
+
+	return rate.RateCents, nil
+}
+
+func (re *RateEngine) fetchShorthaulRate(cwtMiles int, date time.Time) (unit.Cents, error) {
+	rate, err := re.rates.FetchShorthaulRate(cwtMiles, date)
+	if err != nil {
+		return 0, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_shorthaul_rates", &rate.ID,
+		fmt.Sprintf("Shorthaul for %d CWT-miles on %s", cwtMiles, date.Format(traceDateFormat)),
+		rate.RateCents.String())
+
+	return rate.RateCents, nil
+}
+
+func (re *RateEngine) fetchFullPackRate(weight unit.Pound, schedule int, date time.Time) (unit.Cents, error) {
+	rate, err := re.rates.FetchFullPackRate(weight, schedule, date)
+	if err != nil {
+		return 0, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_full_pack_rates", &rate.ID,
+		fmt.Sprintf("Full pack rate for schedule %d, %d lbs on %s", schedule, weight.Int(), date.Format(traceDateFormat)),
+		rate.RateCents.String())
+
+	return rate.RateCents, nil
+}
+
+func (re *RateEngine) fetchFullUnpackRate(schedule int, date time.Time) (int, error) {
+	rate, err := re.rates.FetchFullUnpackRate(schedule, date)
+	if err != nil {
+		return 0, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_full_unpack_rates", &rate.ID,
+		fmt.Sprintf("Full unpack rate (millicents) for schedule %d on %s", schedule, date.Format(traceDateFormat)),
+		fmt.Sprintf("%d", rate.RateMillicents))
+
+	return rate.RateMillicents, nil
+}
+
+func (re *RateEngine) fetchRateArea(zip5 string) (string, error) {
+	rateArea, err := models.FetchRateAreaForZip5(re.db, zip5)
+	if err != nil {
+		return "", err
+	}
+
+	re.trace.addRateLookup("tariff400ng_zip3s", nil, fmt.Sprintf("Rate area for zip %s", zip5), rateArea)
+
+	return rateArea, nil
+}
+
+func (re *RateEngine) fetchInternationalRate(originRateArea string, destinationRateArea string, weight unit.Pound, date time.Time) (models.Tariff400ngInternationalRate, error) {
+	rate, err := re.rates.FetchInternationalRate(originRateArea, destinationRateArea, weight, date)
+	if err != nil {
+		return models.Tariff400ngInternationalRate{}, err
+	}
+
+	re.trace.addRateLookup("tariff400ng_international_rates", &rate.ID,
+		fmt.Sprintf("Port-to-port rate from %s to %s, %d lbs on %s", originRateArea, destinationRateArea, weight.Int(), date.Format(traceDateFormat)),
+		rate.RateCents.String())
+
+	return rate, nil
+}
+
+func (re *RateEngine) fetchPPMDiscounts(originZip5 string, destinationZip5 string, date time.Time) (lhDiscount unit.DiscountRate, sitDiscount unit.DiscountRate, err error) {
+	return models.PPMDiscountFetch(re.db, re.logger, originZip5, destinationZip5, date)
+}
@@ -0,0 +1,107 @@
+package rateengine
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+// PricingTrace records each rate table row, discount, prorate factor and rounding step used in a computation,
+// so that a price can be explained after the fact without re-running the queries by hand.
+type PricingTrace struct {
+	Steps models.PricingTraceSteps
+}
+
+// traced returns a copy of the rate engine that records its lookups and calculations to a new pricing trace
+func (re *RateEngine) traced() (*RateEngine, *PricingTrace) {
+	trace := &PricingTrace{}
+	tracedEngine := *re
+	tracedEngine.trace = trace
+	return &tracedEngine, trace
+}
+
+// All methods below are safe to call on a nil trace, in which case nothing is recorded
+
+func (t *PricingTrace) add(step models.PricingTraceStep) {
+	if t == nil {
+		return
+	}
+	step.Position = len(t.Steps)
+	t.Steps = append(t.Steps, step)
+}
+
+func (t *PricingTrace) len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.Steps)
+}
+
+// tagSince marks every step recorded since the start position as contributing to the given item code
+func (t *PricingTrace) tagSince(start int, itemCode string) {
+	if t == nil {
+		return
+	}
+	for i := start; i < len(t.Steps); i++ {
+		if t.Steps[i].ItemCode == "" {
+			t.Steps[i].ItemCode = itemCode
+		}
+	}
+}
+
+func (t *PricingTrace) addRateLookup(table string, rowID *uuid.UUID, description string, value string) {
+	t.add(models.PricingTraceStep{
+		Kind:        models.PricingTraceStepKindRATELOOKUP,
+		Description: description,
+		RateTable:   &table,
+		RowID:       rowID,
+		Value:       value,
+	})
+}
+
+func (t *PricingTrace) addDiscount(description string, discount unit.DiscountRate, before unit.Cents, after unit.Cents) {
+	t.add(models.PricingTraceStep{
+		Kind:        models.PricingTraceStepKindDISCOUNT,
+		Description: fmt.Sprintf("%s: %d cents discounted by %.4f", description, before.Int(), discount.Float64()),
+		Value:       after.String(),
+	})
+}
+
+func (t *PricingTrace) addProrate(weight unit.Pound, factor float64) {
+	t.add(models.PricingTraceStep{
+		Kind:        models.PricingTraceStepKindPRORATE,
+		Description: fmt.Sprintf("Weight of %d lbs is under 1000 lbs, charges are computed at 1000 lbs and prorated", weight.Int()),
+		Value:       fmt.Sprintf("%.4f", factor),
+	})
+}
+
+func (t *PricingTrace) addRounding(description string, before float64, after unit.Cents) {
+	t.add(models.PricingTraceStep{
+		Kind:        models.PricingTraceStepKindROUNDING,
+		Description: fmt.Sprintf("%s: %.4f rounded to nearest cent", description, before),
+		Value:       after.String(),
+	})
+}
+
+func (t *PricingTrace) addCharge(description string, charge unit.Cents) {
+	t.add(models.PricingTraceStep{
+		Kind:        models.PricingTraceStepKindCHARGE,
+		Description: description,
+		Value:       charge.String(),
+	})
+}
+
+// StepsForItemCode returns the steps that contributed to the given item code, along with any steps
+// that apply to the computation as a whole
+func (t PricingTrace) StepsForItemCode(itemCode string) models.PricingTraceSteps {
+	steps := models.PricingTraceSteps{}
+	for _, step := range t.Steps {
+		if step.ItemCode == "" || step.ItemCode == itemCode {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
@@ -0,0 +1,52 @@
+package rateengine
+
+import (
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/unit"
+)
+
+func (suite *RateEngineSuite) TestPricingTraceStepsForItemCode() {
+	trace := &PricingTrace{}
+	trace.addProrate(unit.Pound(500), 0.5)
+
+	start := trace.len()
+	trace.addRateLookup("tariff400ng_linehaul_rates", nil, "Base linehaul", "1000")
+	trace.addCharge("Linehaul charge total", unit.Cents(1000))
+	trace.tagSince(start, "LHS")
+
+	start = trace.len()
+	trace.addRounding("Fuel surcharge", 60.4, unit.Cents(60))
+	trace.tagSince(start, "16A")
+
+	suite.Len(trace.Steps, 4)
+	for i, step := range trace.Steps {
+		suite.Equal(i, step.Position)
+	}
+
+	// Untagged steps apply to every item code
+	steps := trace.StepsForItemCode("LHS")
+	if suite.Len(steps, 3) {
+		suite.Equal(models.PricingTraceStepKindPRORATE, steps[0].Kind)
+		suite.Equal(models.PricingTraceStepKindRATELOOKUP, steps[1].Kind)
+		suite.Equal(models.PricingTraceStepKindCHARGE, steps[2].Kind)
+	}
+
+	steps = trace.StepsForItemCode("16A")
+	if suite.Len(steps, 2) {
+		suite.Equal(models.PricingTraceStepKindROUNDING, steps[1].Kind)
+		suite.Equal("60", steps[1].Value)
+	}
+}
+
+func (suite *RateEngineSuite) TestPricingTraceNil() {
+	// An engine that isn't tracing records nothing and doesn't panic
+	engine := NewRateEngine(suite.DB(), suite.logger)
+	suite.Nil(engine.trace)
+	engine.trace.addCharge("Ignored", unit.Cents(1))
+	suite.Equal(0, engine.trace.len())
+
+	traced, trace := engine.traced()
+	traced.trace.addCharge("Recorded", unit.Cents(1))
+	suite.Equal(1, trace.len())
+	suite.Nil(engine.trace)
+}
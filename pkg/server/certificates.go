@@ -1,11 +1,25 @@
 package server
 
 import (
+	"bytes"
+	"crypto/tls"
 	"crypto/x509"
+	"fmt"
+	"io/ioutil"
 
+	"github.com/pkg/errors"
 	"go.mozilla.org/pkcs7"
 )
 
+// ErrInvalidPKCS7 is returned when the package of DoD CA certificates is missing or empty
+type ErrInvalidPKCS7 struct {
+	Path string
+}
+
+func (e *ErrInvalidPKCS7) Error() string {
+	return fmt.Sprintf("invalid DER encoded PKCS7 package: %s", e.Path)
+}
+
 // LoadCertPoolFromPkcs7Package reads the certificates in a DER-encoded PKCS7
 // package and returns a newly-created x509.CertPool with those Certificates
 // added.
@@ -20,3 +34,54 @@ func LoadCertPoolFromPkcs7Package(pkcs7Package []byte) (*x509.CertPool, error) {
 	}
 	return certPool, nil
 }
+
+// LoadDODCertificates returns the key pair for the DoD-signed move.mil TLS certificate, chained with the DoD CA
+// certificate that signed it, and a pool of the DoD root and intermediate CAs read from the PKCS#7 package at
+// pathToPackage. The certificates and key are PEM encoded, as passed to the command line tools that talk to GEX.
+func LoadDODCertificates(tlsCert string, caCert string, tlsKey string, pathToPackage string) ([]tls.Certificate, *x509.CertPool, error) {
+	if len(tlsCert) == 0 {
+		return make([]tls.Certificate, 0), nil, errors.Errorf("%s is missing", "move-mil-dod-tls-cert")
+	}
+
+	if len(caCert) == 0 {
+		return make([]tls.Certificate, 0), nil, errors.Errorf("%s is missing", "move-mil-dod-ca-cert")
+	}
+
+	//Append move.mil cert with CA certificate chain
+	cert := bytes.Join(
+		[][]byte{
+			[]byte(tlsCert),
+			[]byte(caCert),
+		},
+		[]byte("\n"),
+	)
+
+	if len(tlsKey) == 0 {
+		return make([]tls.Certificate, 0), nil, errors.Errorf("%s is missing", "move-mil-dod-tls-key")
+	}
+
+	keyPair, err := tls.X509KeyPair(cert, []byte(tlsKey))
+	if err != nil {
+		return make([]tls.Certificate, 0), nil, errors.Wrap(err, "failed to parse DOD keypair for server")
+	}
+
+	if len(pathToPackage) == 0 {
+		return make([]tls.Certificate, 0), nil, errors.Wrap(&ErrInvalidPKCS7{Path: pathToPackage}, fmt.Sprintf("%s is missing", "dod-ca-package"))
+	}
+
+	pkcs7Package, err := ioutil.ReadFile(pathToPackage) // #nosec
+	if err != nil {
+		return make([]tls.Certificate, 0), nil, errors.Wrap(err, fmt.Sprintf("%s is invalid", "dod-ca-package"))
+	}
+
+	if len(pkcs7Package) == 0 {
+		return make([]tls.Certificate, 0), nil, errors.Wrap(&ErrInvalidPKCS7{Path: pathToPackage}, fmt.Sprintf("%s is an empty file", "dod-ca-package"))
+	}
+
+	dodCACertPool, err := LoadCertPoolFromPkcs7Package(pkcs7Package)
+	if err != nil {
+		return make([]tls.Certificate, 0), dodCACertPool, errors.Wrap(err, "Failed to parse DoD CA certificate package")
+	}
+
+	return []tls.Certificate{keyPair}, dodCACertPool, nil
+}
@@ -6,9 +6,11 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
 
@@ -186,3 +188,37 @@ func (suite *serverSuite) TestHTTPServerConfig() {
 	suite.Equal(httpsServer.Addr, "127.0.0.1:8080")
 	suite.Equal(suite.httpHandler, httpsServer.Handler)
 }
+
+func (suite *serverSuite) TestLoadDODCertificatesMissingValues() {
+	tlsCert := string(suite.readFile("localhost.pem"))
+	caCert := string(suite.readFile("ca.pem"))
+	tlsKey := string(suite.readFile("localhost.key"))
+
+	_, _, err := LoadDODCertificates("", caCert, tlsKey, "dod.p7b")
+	suite.EqualError(err, "move-mil-dod-tls-cert is missing")
+
+	_, _, err = LoadDODCertificates(tlsCert, "", tlsKey, "dod.p7b")
+	suite.EqualError(err, "move-mil-dod-ca-cert is missing")
+
+	_, _, err = LoadDODCertificates(tlsCert, caCert, "", "dod.p7b")
+	suite.EqualError(err, "move-mil-dod-tls-key is missing")
+}
+
+func (suite *serverSuite) TestLoadDODCertificatesInvalidPackage() {
+	tlsCert := string(suite.readFile("localhost.pem"))
+	caCert := string(suite.readFile("ca.pem"))
+	tlsKey := string(suite.readFile("localhost.key"))
+
+	_, _, err := LoadDODCertificates(tlsCert, caCert, tlsKey, "")
+	suite.IsType(&ErrInvalidPKCS7{}, errors.Cause(err))
+
+	emptyPackage, err := ioutil.TempFile("", "dod-ca-package")
+	suite.NoError(err)
+	defer os.Remove(emptyPackage.Name())
+	emptyPackage.Close()
+
+	certificates, rootCAs, err := LoadDODCertificates(tlsCert, caCert, tlsKey, emptyPackage.Name())
+	suite.IsType(&ErrInvalidPKCS7{}, errors.Cause(err))
+	suite.Empty(certificates)
+	suite.Nil(rootCAs)
+}
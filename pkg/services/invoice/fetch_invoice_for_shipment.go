@@ -7,6 +7,16 @@ import (
 	"github.com/transcom/mymove/pkg/models"
 )
 
+// invoiceShipmentAssociations are the shipment associations required to generate an invoice
+var invoiceShipmentAssociations = []string{
+	"PickupAddress",
+	"Move.Orders.NewDutyStation.Address",
+	"Move.Orders.NewDutyStation.TransportationOffice",
+	"ServiceMember.DutyStation.TransportationOffice",
+	"ShipmentOffers.TransportationServiceProviderPerformance.TransportationServiceProvider",
+	"ShipmentOffers.TransportationServiceProviderPerformance",
+}
+
 // FetchShipmentForInvoice is a service object for fetching a shipment with the fields required for an invoice
 // This struct should contain dependencies
 type FetchShipmentForInvoice struct {
@@ -16,36 +26,35 @@ type FetchShipmentForInvoice struct {
 // Call queries a shipment for a given ID along with required associations
 // Conditions for adding line items are:
 // - must be approved or not require preapproval
-// - must NOT have an existing invoice association (ie. has been invoiced already)
+// - must NOT have an existing invoice association (ie. has been invoiced already), unless the invoice was rejected
 // - must be associated with the passed shipment ID
 func (f FetchShipmentForInvoice) Call(shipmentID uuid.UUID) (models.Shipment, error) {
 	var shipment models.Shipment
 	err := f.DB.
-		Eager(
-			"PickupAddress",
-			"Move.Orders.NewDutyStation.Address",
-			"Move.Orders.NewDutyStation.TransportationOffice",
-			"ServiceMember.DutyStation.TransportationOffice",
-			"ShipmentOffers.TransportationServiceProviderPerformance.TransportationServiceProvider",
-			"ShipmentOffers.TransportationServiceProviderPerformance",
-		).
+		Eager(invoiceShipmentAssociations...).
 		Find(&shipment, shipmentID)
 	if err != nil {
 		return shipment, err
 	}
 
+	lineItems, err := fetchInvoiceableLineItems(f.DB, shipmentID)
+	shipment.ShipmentLineItems = lineItems
+	return shipment, err
+}
+
+// fetchInvoiceableLineItems queries the line items of the given shipments that can be invoiced, in a single query
+func fetchInvoiceableLineItems(db *pop.Connection, shipmentIDs ...interface{}) (models.ShipmentLineItems, error) {
 	var lineItems models.ShipmentLineItems
-	err = f.DB.Q().
+	err := db.Q().
 		Eager("Tariff400ngItem").
 		LeftJoin("tariff400ng_items as ti", "shipment_line_items.tariff400ng_item_id = ti.id").
 		Where("(shipment_line_items.status=? OR ti.requires_pre_approval = false)",
 			models.ShipmentLineItemStatusAPPROVED).
-		Where("shipment_line_items.invoice_id IS NULL").
-		Where("shipment_line_items.shipment_id=?", shipmentID).
+		Where("(shipment_line_items.invoice_id IS NULL OR shipment_line_items.invoice_id IN (SELECT id FROM invoices WHERE status = ?))",
+			models.InvoiceStatusREJECTED).
+		Where("shipment_line_items.shipment_id IN (?)", shipmentIDs...).
 		All(&lineItems)
-	lineItemsFiltered := (filter35AItems(lineItems))
-	shipment.ShipmentLineItems = lineItemsFiltered
-	return shipment, err
+	return filter35AItems(lineItems), err
 }
 
 // filter35AItems: 35A items are invoiced if they have an `actual_amount_cents` value
@@ -100,6 +100,30 @@ func (suite *InvoiceServiceSuite) TestFetchInvoiceForShipmentCall() {
 
 		suite.Equal(tariffItem.ID, actualShipment.ShipmentLineItems[0].Tariff400ngItem.ID)
 	})
+
+	suite.T().Run("line items on a rejected invoice", func(t *testing.T) {
+		shipment := testdatagen.MakeDefaultShipment(suite.DB())
+		for _, status := range []models.InvoiceStatus{models.InvoiceStatusACCEPTED, models.InvoiceStatusREJECTED} {
+			invoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+				Invoice: models.Invoice{Status: status, Shipment: shipment},
+			})
+			testdatagen.MakeCompleteShipmentLineItem(suite.DB(), testdatagen.Assertions{
+				ShipmentLineItem: models.ShipmentLineItem{
+					Shipment:   shipment,
+					ShipmentID: shipment.ID,
+					Status:     models.ShipmentLineItemStatusAPPROVED,
+					InvoiceID:  &invoice.ID,
+				},
+			})
+		}
+
+		f := FetchShipmentForInvoice{suite.DB()}
+		actualShipment, err := f.Call(shipment.ID)
+		suite.NoError(err)
+
+		// Only the rejected invoice's line item can be invoiced again
+		suite.Equal(1, len(actualShipment.ShipmentLineItems))
+	})
 }
 
 func (suite *InvoiceServiceSuite) TestFetchInvoiceWith35AValid() {
@@ -0,0 +1,64 @@
+package invoice
+
+import (
+	"github.com/gobuffalo/pop"
+	"github.com/gofrs/uuid"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// FetchShipmentsToInvoiceForTSP is a service object for fetching the shipments a TSP is ready to be paid for,
+// so they can be invoiced together in a single batch
+type FetchShipmentsToInvoiceForTSP struct {
+	DB *pop.Connection
+}
+
+// Call queries the shipments whose accepted offer is from the given TSP, along with the fields required for an
+// invoice. Conditions for including a shipment are:
+// - must be delivered or completed
+// - must NOT have an invoice, other than ones that failed to be submitted or were rejected
+// - must have line items that can be invoiced (see FetchShipmentForInvoice)
+func (f FetchShipmentsToInvoiceForTSP) Call(tspID uuid.UUID) (models.Shipments, error) {
+	var candidates models.Shipments
+	err := f.DB.Q().
+		Eager(invoiceShipmentAssociations...).
+		Join("shipment_offers", "shipment_offers.shipment_id = shipments.id").
+		Where("shipment_offers.transportation_service_provider_id = ?", tspID).
+		Where("shipment_offers.accepted = true").
+		Where("shipments.status IN (?, ?)", models.ShipmentStatusDELIVERED, models.ShipmentStatusCOMPLETED).
+		Where("NOT EXISTS (SELECT 1 FROM invoices WHERE invoices.shipment_id = shipments.id AND invoices.status NOT IN (?, ?))",
+			models.InvoiceStatusSUBMISSIONFAILURE, models.InvoiceStatusREJECTED).
+		Order("shipments.created_at").
+		All(&candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	shipments := models.Shipments{}
+	if len(candidates) == 0 {
+		return shipments, nil
+	}
+
+	// Load the line items for every candidate at once, rather than fetching each shipment again
+	var ids []interface{}
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.ID)
+	}
+	lineItems, err := fetchInvoiceableLineItems(f.DB, ids...)
+	if err != nil {
+		return nil, err
+	}
+	lineItemsByShipmentID := make(map[uuid.UUID]models.ShipmentLineItems)
+	for _, lineItem := range lineItems {
+		lineItemsByShipmentID[lineItem.ShipmentID] = append(lineItemsByShipmentID[lineItem.ShipmentID], lineItem)
+	}
+
+	for _, candidate := range candidates {
+		candidate.ShipmentLineItems = lineItemsByShipmentID[candidate.ID]
+		if len(candidate.ShipmentLineItems) == 0 {
+			continue
+		}
+		shipments = append(shipments, candidate)
+	}
+	return shipments, nil
+}
@@ -0,0 +1,48 @@
+package invoice
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *InvoiceServiceSuite) TestFetchShipmentsToInvoiceForTSPCall() {
+	shipment := helperDeliveredShipmentUsingScac(suite, "ABBV")
+	// A shipment delivered by another TSP
+	helperDeliveredShipmentUsingScac(suite, "BACD")
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+
+	acceptedOffer, err := shipment.AcceptedShipmentOffer()
+	suite.FatalNoError(err)
+	suite.FatalFalse(acceptedOffer == nil)
+	tspID := acceptedOffer.TransportationServiceProviderID
+
+	fetcher := FetchShipmentsToInvoiceForTSP{DB: suite.DB()}
+
+	// Only the TSP's own shipment is ready to invoice
+	shipments, err := fetcher.Call(tspID)
+	suite.NoError(err)
+	if suite.Len(shipments, 1) {
+		suite.Equal(shipment.ID, shipments[0].ID)
+		suite.NotEmpty(shipments[0].ShipmentLineItems)
+		// Along with the associations the invoice needs
+		suite.NotEmpty(shipments[0].ShipmentOffers)
+		suite.NotEqual(uuid.Nil, shipments[0].ServiceMember.DutyStation.ID)
+	}
+
+	// A failed invoice doesn't stop the shipment from being invoiced again
+	invoice := helperCreateInvoiceForShipment(suite, shipment, officeUser)
+	invoice.Status = models.InvoiceStatusSUBMISSIONFAILURE
+	suite.MustSave(&invoice)
+	shipments, err = fetcher.Call(tspID)
+	suite.NoError(err)
+	suite.Len(shipments, 1)
+
+	// But a submitted one does
+	invoice.Status = models.InvoiceStatusSUBMITTED
+	suite.MustSave(&invoice)
+	shipments, err = fetcher.Call(tspID)
+	suite.NoError(err)
+	suite.Empty(shipments)
+}
@@ -83,3 +83,24 @@ func helperShipmentUsingScac(suite *InvoiceServiceSuite, scac string) models.Shi
 
 	return shipment
 }
+
+// helperDeliveredShipmentUsingScac makes a delivered shipment with a line item to invoice, fetched with
+// everything an invoice needs
+func helperDeliveredShipmentUsingScac(suite *InvoiceServiceSuite, scac string) models.Shipment {
+	shipment := helperShipmentUsingScac(suite, scac)
+	shipment.Status = models.ShipmentStatusDELIVERED
+	suite.MustSave(&shipment)
+
+	amountCents := unit.Cents(12325)
+	testdatagen.MakeShipmentLineItem(suite.DB(), testdatagen.Assertions{
+		ShipmentLineItem: models.ShipmentLineItem{
+			Shipment:    shipment,
+			Quantity1:   unit.BaseQuantityFromInt(2000),
+			AmountCents: &amountCents,
+		},
+	})
+
+	shipment, err := FetchShipmentForInvoice{DB: suite.DB()}.Call(shipment.ID)
+	suite.FatalNoError(err)
+	return shipment
+}
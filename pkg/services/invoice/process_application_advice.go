@@ -0,0 +1,64 @@
+package invoice
+
+import (
+	"strings"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/pkg/errors"
+
+	ediapplicationadvice "github.com/transcom/mymove/pkg/edi/applicationadvice"
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// ProcessApplicationAdvice is a service object to record the invoices Syncada rejected in an 824 application advice
+type ProcessApplicationAdvice struct {
+	DB *pop.Connection
+}
+
+// Call marks each invoice the 824 rejects as rejected, storing Syncada's notes on why. Invoices are matched to
+// the 824 by the invoice number their 858C carried in its N9 segment. Invoices the 824 accepts are left as they
+// are. If any rejection can't be applied, none of them are.
+func (p ProcessApplicationAdvice) Call(advice ediapplicationadvice.ApplicationAdvice824) (models.Invoices, *validate.Errors, error) {
+	var invoices models.Invoices
+	verrs := validate.NewErrors()
+	var applyErr error
+
+	transactionErr := p.DB.Transaction(func(tx *pop.Connection) error {
+		transactionError := errors.New("Rollback the transaction")
+
+		for _, transaction := range advice.OriginalTransactions() {
+			if !transaction.Rejected() {
+				continue
+			}
+			invoiceNumber, err := transaction.InvoiceNumber()
+			if err != nil {
+				applyErr = err
+				return transactionError
+			}
+			invoice, err := models.FetchInvoiceByInvoiceNumber(tx, invoiceNumber)
+			if err != nil {
+				applyErr = errors.Wrapf(err, "Could not find invoice %s", invoiceNumber)
+				return transactionError
+			}
+
+			if err := invoice.RejectApplication(strings.Join(transaction.Notes(), "\n")); err != nil {
+				applyErr = errors.Wrapf(err, "Invoice %s has status %s", invoice.InvoiceNumber, invoice.Status)
+				return transactionError
+			}
+
+			verrs, err = tx.ValidateAndSave(invoice)
+			if err != nil || verrs.HasAny() {
+				applyErr = err
+				return transactionError
+			}
+			invoices = append(invoices, *invoice)
+		}
+
+		return nil
+	})
+	if transactionErr != nil {
+		return nil, verrs, applyErr
+	}
+	return invoices, verrs, nil
+}
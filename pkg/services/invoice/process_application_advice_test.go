@@ -0,0 +1,86 @@
+package invoice
+
+import (
+	"os"
+	"testing"
+
+	ediapplicationadvice "github.com/transcom/mymove/pkg/edi/applicationadvice"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *InvoiceServiceSuite) helperParse824() ediapplicationadvice.ApplicationAdvice824 {
+	file, err := os.Open("../../edi/applicationadvice/testdata/rejected_invoice.edi.824")
+	suite.NoError(err)
+	defer file.Close()
+
+	advice, err := ediapplicationadvice.Parse824(file)
+	suite.NoError(err)
+	return advice
+}
+
+func (suite *InvoiceServiceSuite) TestProcessApplicationAdviceCall() {
+	processAdvice := ProcessApplicationAdvice{DB: suite.DB()}
+
+	suite.T().Run("rejections are recorded with their notes", func(t *testing.T) {
+		acceptedInvoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:        models.InvoiceStatusACCEPTED,
+				InvoiceNumber: "ABBV190001",
+			},
+		})
+		rejectedInvoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:        models.InvoiceStatusACCEPTED,
+				InvoiceNumber: "ABBV190002",
+			},
+		})
+
+		invoices, verrs, err := processAdvice.Call(suite.helperParse824())
+		suite.Empty(verrs.Errors)
+		suite.NoError(err)
+		if suite.Len(invoices, 1) {
+			suite.Equal(rejectedInvoice.ID, invoices[0].ID)
+		}
+
+		suite.NoError(suite.DB().Find(&acceptedInvoice, acceptedInvoice.ID))
+		suite.Equal(models.InvoiceStatusACCEPTED, acceptedInvoice.Status)
+		suite.Nil(acceptedInvoice.RejectionNotes)
+
+		suite.NoError(suite.DB().Find(&rejectedInvoice, rejectedInvoice.ID))
+		suite.Equal(models.InvoiceStatusREJECTED, rejectedInvoice.Status)
+		if suite.NotNil(rejectedInvoice.RejectionNotes) {
+			suite.Contains(*rejectedInvoice.RejectionNotes, "TAC NOT VALID FOR FISCAL YEAR")
+			suite.Contains(*rejectedInvoice.RejectionNotes, "Correct the TAC and resubmit")
+		}
+	})
+
+	suite.T().Run("nothing is applied when a rejected invoice can't be found", func(t *testing.T) {
+		suite.DB().TruncateAll()
+		invoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:        models.InvoiceStatusACCEPTED,
+				InvoiceNumber: "ABBV190001",
+			},
+		})
+
+		_, _, err := processAdvice.Call(suite.helperParse824())
+		suite.Error(err)
+
+		suite.NoError(suite.DB().Find(&invoice, invoice.ID))
+		suite.Equal(models.InvoiceStatusACCEPTED, invoice.Status)
+	})
+
+	suite.T().Run("invoices that weren't submitted can't be rejected", func(t *testing.T) {
+		suite.DB().TruncateAll()
+		testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:        models.InvoiceStatusINPROCESS,
+				InvoiceNumber: "ABBV190002",
+			},
+		})
+
+		_, _, err := processAdvice.Call(suite.helperParse824())
+		suite.Error(err)
+	})
+}
@@ -0,0 +1,90 @@
+package invoice
+
+import (
+	"strings"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/pkg/errors"
+
+	ediacknowledgement "github.com/transcom/mymove/pkg/edi/acknowledgement"
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// ProcessFunctionalAcknowledgement is a service object to record Syncada's 997 acknowledgements of invoices
+type ProcessFunctionalAcknowledgement struct {
+	DB    *pop.Connection
+	Clock clock.Clock
+}
+
+// Call marks each invoice acknowledged in the 997 as accepted or rejected, storing the errors Syncada reported.
+// Invoices are matched to acknowledgements by interchange control number, and, since an interchange can hold a
+// batch of invoices, to their own transaction set by transaction set control number. If any acknowledgement
+// can't be applied, none of them are.
+func (p ProcessFunctionalAcknowledgement) Call(ack ediacknowledgement.FunctionalAcknowledgement997) (models.Invoices, *validate.Errors, error) {
+	var invoices models.Invoices
+	verrs := validate.NewErrors()
+	var applyErr error
+	acknowledgedAt := p.Clock.Now()
+
+	transactionErr := p.DB.Transaction(func(tx *pop.Connection) error {
+		transactionError := errors.New("Rollback the transaction")
+
+		for _, groupAck := range ack.GroupAcknowledgements() {
+			interchangeControlNumber := groupAck.AK1.GroupControlNumber
+			groupInvoices, err := models.FetchInvoicesByInterchangeControlNumber(tx, interchangeControlNumber)
+			if err == nil && len(groupInvoices) == 0 {
+				err = models.ErrFetchNotFound
+			}
+			if err != nil {
+				applyErr = errors.Wrapf(err, "Could not find invoice with interchange control number %d", interchangeControlNumber)
+				return transactionError
+			}
+
+			for i := range groupInvoices {
+				invoice := &groupInvoices[i]
+				if err := acknowledgeInvoice(invoice, groupAck, acknowledgedAt); err != nil {
+					applyErr = errors.Wrapf(err, "Invoice %s has status %s", invoice.InvoiceNumber, invoice.Status)
+					return transactionError
+				}
+
+				verrs, err = tx.ValidateAndSave(invoice)
+				if err != nil || verrs.HasAny() {
+					applyErr = err
+					return transactionError
+				}
+				invoices = append(invoices, *invoice)
+			}
+		}
+
+		return nil
+	})
+	if transactionErr != nil {
+		return nil, verrs, applyErr
+	}
+	return invoices, verrs, nil
+}
+
+// acknowledgeInvoice accepts or rejects the invoice according to the acknowledgement of its functional group.
+// Invoices sent before interchanges held batches don't have a transaction set control number, so the group's
+// acknowledgement applies to them as a whole.
+func acknowledgeInvoice(invoice *models.Invoice, groupAck ediacknowledgement.GroupAcknowledgement, acknowledgedAt time.Time) error {
+	accepted := groupAck.Accepted()
+	errs := groupAck.Errors()
+	if invoice.TransactionSetControlNumber != nil {
+		accepted = groupAck.TransactionSetAccepted(*invoice.TransactionSetControlNumber)
+		errs = groupAck.TransactionSetErrors(*invoice.TransactionSetControlNumber)
+	}
+
+	var acknowledgementErrors *string
+	if len(errs) > 0 {
+		joined := strings.Join(errs, "\n")
+		acknowledgementErrors = &joined
+	}
+	if accepted {
+		return invoice.Accept(acknowledgedAt, acknowledgementErrors)
+	}
+	return invoice.Reject(acknowledgedAt, acknowledgementErrors)
+}
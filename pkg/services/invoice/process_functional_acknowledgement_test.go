@@ -0,0 +1,128 @@
+package invoice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/facebookgo/clock"
+	"github.com/go-openapi/swag"
+
+	ediacknowledgement "github.com/transcom/mymove/pkg/edi/acknowledgement"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *InvoiceServiceSuite) helperParse997(name string) ediacknowledgement.FunctionalAcknowledgement997 {
+	file, err := os.Open(filepath.Join("../../edi/acknowledgement/testdata", name))
+	suite.NoError(err)
+	defer file.Close()
+
+	ack, err := ediacknowledgement.Parse997(file)
+	suite.NoError(err)
+	return ack
+}
+
+func (suite *InvoiceServiceSuite) TestProcessFunctionalAcknowledgementCall() {
+	processAck := ProcessFunctionalAcknowledgement{DB: suite.DB(), Clock: clock.NewMock()}
+
+	suite.T().Run("acknowledgements accept and reject invoices", func(t *testing.T) {
+		acceptedInvoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:                   models.InvoiceStatusSUBMITTED,
+				InterchangeControlNumber: swag.Int64(1001),
+			},
+		})
+		rejectedInvoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:                   models.InvoiceStatusUPDATEFAILURE,
+				InterchangeControlNumber: swag.Int64(1002),
+			},
+		})
+
+		invoices, verrs, err := processAck.Call(suite.helperParse997("rejected_invoice.edi.997"))
+		suite.Empty(verrs.Errors) // Using Errors instead of HasAny for more descriptive output
+		suite.NoError(err)
+		suite.Len(invoices, 2)
+
+		suite.NoError(suite.DB().Find(&acceptedInvoice, acceptedInvoice.ID))
+		suite.Equal(models.InvoiceStatusACCEPTED, acceptedInvoice.Status)
+		suite.Nil(acceptedInvoice.AcknowledgementErrors)
+		suite.NotNil(acceptedInvoice.AcknowledgedAt)
+
+		suite.NoError(suite.DB().Find(&rejectedInvoice, rejectedInvoice.ID))
+		suite.Equal(models.InvoiceStatusREJECTED, rejectedInvoice.Status)
+		if suite.NotNil(rejectedInvoice.AcknowledgementErrors) {
+			suite.Contains(*rejectedInvoice.AcknowledgementErrors, "segment N4 at position 7")
+		}
+	})
+
+	suite.T().Run("nothing is applied when an invoice can't be found", func(t *testing.T) {
+		suite.DB().TruncateAll()
+		invoice := testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:                   models.InvoiceStatusSUBMITTED,
+				InterchangeControlNumber: swag.Int64(1001),
+			},
+		})
+
+		_, _, err := processAck.Call(suite.helperParse997("rejected_invoice.edi.997"))
+		suite.Error(err)
+
+		suite.NoError(suite.DB().Find(&invoice, invoice.ID))
+		suite.Equal(models.InvoiceStatusSUBMITTED, invoice.Status)
+	})
+
+	suite.T().Run("invoices that weren't submitted can't be acknowledged", func(t *testing.T) {
+		suite.DB().TruncateAll()
+		testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:                   models.InvoiceStatusACCEPTED,
+				InterchangeControlNumber: swag.Int64(1001),
+			},
+		})
+		testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+			Invoice: models.Invoice{
+				Status:                   models.InvoiceStatusSUBMITTED,
+				InterchangeControlNumber: swag.Int64(1002),
+			},
+		})
+
+		_, _, err := processAck.Call(suite.helperParse997("rejected_invoice.edi.997"))
+		suite.Error(err)
+	})
+
+	suite.T().Run("invoices batched in one interchange are acknowledged by transaction set", func(t *testing.T) {
+		suite.DB().TruncateAll()
+		var batch models.Invoices
+		for _, controlNumber := range []string{"0001", "0002", "0003"} {
+			batch = append(batch, testdatagen.MakeInvoice(suite.DB(), testdatagen.Assertions{
+				Invoice: models.Invoice{
+					Status:                      models.InvoiceStatusSUBMITTED,
+					InterchangeControlNumber:    swag.Int64(1003),
+					TransactionSetControlNumber: swag.String(controlNumber),
+				},
+			}))
+		}
+
+		invoices, verrs, err := processAck.Call(suite.helperParse997("partially_accepted_batch.edi.997"))
+		suite.Empty(verrs.Errors)
+		suite.NoError(err)
+		suite.Len(invoices, 3)
+
+		expectedStatuses := []models.InvoiceStatus{
+			models.InvoiceStatusACCEPTED,
+			models.InvoiceStatusREJECTED,
+			models.InvoiceStatusACCEPTED, // Left out of the 997
+		}
+		for i, invoice := range batch {
+			suite.NoError(suite.DB().Find(&invoice, invoice.ID))
+			suite.Equal(expectedStatuses[i], invoice.Status)
+		}
+
+		suite.NoError(suite.DB().Find(&batch[1], batch[1].ID))
+		if suite.NotNil(batch[1].AcknowledgementErrors) {
+			suite.Contains(*batch[1].AcknowledgementErrors, "Transaction set 858 0002")
+		}
+	})
+}
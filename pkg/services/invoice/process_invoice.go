@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/transcom/mymove/pkg/db/sequence"
+	"github.com/transcom/mymove/pkg/edi"
 	ediinvoice "github.com/transcom/mymove/pkg/edi/invoice"
 	"github.com/transcom/mymove/pkg/models"
 	"github.com/transcom/mymove/pkg/services"
@@ -27,7 +28,7 @@ func (p ProcessInvoice) Call(invoice *models.Invoice, shipment models.Shipment)
 	ediString, err := p.generateAndSendInvoiceData(invoice, shipment)
 	if err != nil {
 		// The invoice submission has failed, so we record the failure.
-		verrs, err := p.updateInvoiceFailed(invoice, models.InvoiceStatusSUBMISSIONFAILURE, validate.NewErrors(), err)
+		verrs, err := updateInvoiceFailed(p.DB, invoice, models.InvoiceStatusSUBMISSIONFAILURE, validate.NewErrors(), err)
 		return ediString, verrs, err
 	}
 
@@ -36,7 +37,7 @@ func (p ProcessInvoice) Call(invoice *models.Invoice, shipment models.Shipment)
 	if err != nil || verrs.HasAny() {
 		// Updating as submitted failed (although the invoice submission succeeded), so we try to mark it as a
 		// status update failure to prevent the invoice from being submitted again.
-		verrs, err := p.updateInvoiceFailed(invoice, models.InvoiceStatusUPDATEFAILURE, verrs, err)
+		verrs, err := updateInvoiceFailed(p.DB, invoice, models.InvoiceStatusUPDATEFAILURE, verrs, err)
 		return ediString, verrs, err
 	}
 
@@ -50,6 +51,16 @@ func (p ProcessInvoice) generateAndSendInvoiceData(invoice *models.Invoice, ship
 	if err != nil {
 		return nil, err
 	}
+	// Keep the control numbers so the 997 acknowledging the invoice can be matched to it
+	interchangeControlNumber := invoice858C.ISA.InterchangeControlNumber
+	invoice.InterchangeControlNumber = &interchangeControlNumber
+	transactionSetControlNumber := invoice858C.TransactionSetControlNumber()
+	invoice.TransactionSetControlNumber = &transactionSetControlNumber
+
+	// Catch malformed EDI before GEX passes it on to Syncada, which only rejects it hours later in a 997
+	if err := edi.Validate(invoice858C.Segments()); err != nil {
+		return nil, errors.Wrap(err, "Generated 858C failed validation")
+	}
 
 	// send edi through gex post api
 	transactionName := "placeholder"
@@ -70,10 +81,12 @@ func (p ProcessInvoice) generateAndSendInvoiceData(invoice *models.Invoice, ship
 	return &invoice858CString, nil
 }
 
-func (p ProcessInvoice) updateInvoiceFailed(invoice *models.Invoice, invoiceStatus models.InvoiceStatus, causeVerrs *validate.Errors, cause error) (*validate.Errors, error) {
+// updateInvoiceFailed records that processing the invoice failed, returning the failure's cause along with any
+// errors saving the invoice
+func updateInvoiceFailed(db *pop.Connection, invoice *models.Invoice, invoiceStatus models.InvoiceStatus, causeVerrs *validate.Errors, cause error) (*validate.Errors, error) {
 	// Update invoice record as failed
 	invoice.Status = invoiceStatus
-	verrs, err := p.DB.ValidateAndSave(invoice)
+	verrs, err := db.ValidateAndSave(invoice)
 	if err != nil || verrs.HasAny() {
 		verrs.Append(causeVerrs)
 		if err != nil {
@@ -0,0 +1,99 @@
+package invoice
+
+import (
+	"github.com/facebookgo/clock"
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/db/sequence"
+	"github.com/transcom/mymove/pkg/edi"
+	ediinvoice "github.com/transcom/mymove/pkg/edi/invoice"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/services"
+)
+
+// ProcessInvoiceBatch is a service object to generate/send/record the invoices for a batch of shipments, sent to
+// GEX in a single 858C interchange.
+type ProcessInvoiceBatch struct {
+	DB                    *pop.Connection
+	Logger                Logger
+	GexSender             services.GexSender
+	SendProductionInvoice bool
+	ICNSequencer          sequence.Sequencer
+}
+
+// Call processes the invoices by generating an interchange with a transaction set for each shipment, sending it
+// to GEX, and recording the status of each invoice. invoices[i] is the invoice for shipments[i], and is updated
+// in place. If the interchange can't be generated or sent, every invoice is marked as failed; otherwise each
+// invoice is recorded as submitted on its own.
+func (p ProcessInvoiceBatch) Call(invoices models.Invoices, shipments models.Shipments) (*string, *validate.Errors, error) {
+	ediString, err := p.generateAndSendBatchData(invoices, shipments)
+	if err != nil {
+		// The batch submission has failed, so we record the failure on every invoice.
+		verrs := validate.NewErrors()
+		for i := range invoices {
+			invoiceVerrs, updateErr := updateInvoiceFailed(p.DB, &invoices[i], models.InvoiceStatusSUBMISSIONFAILURE, validate.NewErrors(), nil)
+			verrs.Append(invoiceVerrs)
+			if updateErr != nil {
+				err = multierror.Append(err, updateErr)
+			}
+		}
+		return ediString, verrs, err
+	}
+
+	// Update each invoice record as submitted
+	verrs := validate.NewErrors()
+	var updateErrs error
+	for i := range invoices {
+		invoiceVerrs, err := UpdateInvoiceSubmitted{DB: p.DB}.Call(&invoices[i], shipments[i].ShipmentLineItems)
+		if err != nil || invoiceVerrs.HasAny() {
+			// Updating as submitted failed (although the batch submission succeeded), so we try to mark it as a
+			// status update failure to prevent the invoice from being submitted again.
+			invoiceVerrs, err = updateInvoiceFailed(p.DB, &invoices[i], models.InvoiceStatusUPDATEFAILURE, invoiceVerrs, err)
+			verrs.Append(invoiceVerrs)
+			if err != nil {
+				updateErrs = multierror.Append(updateErrs, err)
+			}
+		}
+	}
+
+	return ediString, verrs, updateErrs
+}
+
+func (p ProcessInvoiceBatch) generateAndSendBatchData(invoices models.Invoices, shipments models.Shipments) (*string, error) {
+	batch, err := ediinvoice.Generate858CBatch(shipments, invoices, p.DB, p.SendProductionInvoice, p.ICNSequencer, clock.New(), p.Logger)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the control numbers so the 997 acknowledging the batch can be matched to each invoice
+	interchangeControlNumber := batch.ISA.InterchangeControlNumber
+	for i := range invoices {
+		transactionSetControlNumber := batch.TransactionSetControlNumber(i)
+		invoices[i].InterchangeControlNumber = &interchangeControlNumber
+		invoices[i].TransactionSetControlNumber = &transactionSetControlNumber
+	}
+
+	if err := edi.Validate(batch.Segments()); err != nil {
+		return nil, errors.Wrap(err, "Generated 858C batch failed validation")
+	}
+
+	// send edi through gex post api
+	transactionName := "placeholder"
+	batchString, err := batch.EDIString()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.GexSender.SendToGex(batchString, transactionName)
+	if err != nil {
+		return &batchString, err
+	}
+
+	if resp != nil && resp.StatusCode != 200 {
+		return &batchString, errors.Errorf("Invoice batch POST request to GEX failed: response status code %d", resp.StatusCode)
+	}
+
+	return &batchString, nil
+}
@@ -0,0 +1,70 @@
+package invoice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/transcom/mymove/pkg/db/sequence"
+	ediinvoice "github.com/transcom/mymove/pkg/edi/invoice"
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/testdatagen"
+)
+
+func (suite *InvoiceServiceSuite) TestProcessInvoiceBatchCall() {
+	shipments := models.Shipments{helperDeliveredShipmentUsingScac(suite, "ABBV"), helperDeliveredShipmentUsingScac(suite, "ABBV")}
+	officeUser := testdatagen.MakeDefaultOfficeUser(suite.DB())
+
+	processInvoiceBatch := ProcessInvoiceBatch{
+		DB:                    suite.DB(),
+		Logger:                suite.logger,
+		SendProductionInvoice: false,
+		ICNSequencer:          sequence.NewDatabaseSequencer(suite.DB(), ediinvoice.ICNSequenceName),
+		// GexSender set by each test below.
+	}
+
+	helperCreateInvoices := func() models.Invoices {
+		var invoices models.Invoices
+		for _, shipment := range shipments {
+			invoices = append(invoices, helperCreateInvoiceForShipment(suite, shipment, officeUser))
+		}
+		return invoices
+	}
+
+	suite.T().Run("process invoice batch fails due to GEX error", func(t *testing.T) {
+		invoices := helperCreateInvoices()
+
+		processInvoiceBatch.GexSender = &TestGexSender{errors.New("test error")}
+		_, verrs, err := processInvoiceBatch.Call(invoices, shipments)
+		suite.Empty(verrs.Errors)
+		suite.Error(err)
+
+		// Make sure every invoice notes the failure, and no line items are linked to them.
+		for i, invoice := range invoices {
+			helperCheckInvoiceStatus(suite, invoice.ID, models.InvoiceStatusSUBMISSIONFAILURE)
+			helperCheckLineItemInvoiceID(suite, &shipments[i].ID, nil)
+		}
+	})
+
+	suite.T().Run("process invoice batch succeeds", func(t *testing.T) {
+		invoices := helperCreateInvoices()
+
+		processInvoiceBatch.GexSender = &TestGexSender{nil}
+		ediString, verrs, err := processInvoiceBatch.Call(invoices, shipments)
+		suite.Empty(verrs.Errors)
+		suite.NoError(err)
+		suite.NotEmpty(ediString)
+
+		// Both invoices were sent in the same interchange, each in its own transaction set.
+		for i, invoice := range invoices {
+			helperCheckInvoiceStatus(suite, invoice.ID, models.InvoiceStatusSUBMITTED)
+			helperCheckLineItemInvoiceID(suite, &shipments[i].ID, &invoice.ID)
+
+			var saved models.Invoice
+			suite.NoError(suite.DB().Find(&saved, invoice.ID))
+			if suite.NotNil(saved.InterchangeControlNumber) && suite.NotNil(saved.TransactionSetControlNumber) {
+				suite.Equal(*invoices[0].InterchangeControlNumber, *saved.InterchangeControlNumber)
+				suite.Equal([]string{"0001", "0002"}[i], *saved.TransactionSetControlNumber)
+			}
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/facebookgo/clock"
+	"github.com/go-openapi/swag"
 	"github.com/gofrs/uuid"
 
 	"github.com/transcom/mymove/pkg/db/sequence"
@@ -76,6 +77,26 @@ func (suite *InvoiceServiceSuite) TestProcessInvoiceCall() {
 
 	})
 
+	suite.T().Run("process invoice fails when the generated 858C is invalid", func(t *testing.T) {
+		invoice := helperCreateInvoiceForShipment(suite, shipment, officeUser)
+
+		// BX04 can be at most 30 characters long
+		savedGBLNumber := shipment.GBLNumber
+		shipment.GBLNumber = swag.String("KKFA70000010000000000000000000001")
+
+		processInvoice.GexSender = &gexSenderSuccess
+		_, verrs, err := processInvoice.Call(&invoice, shipment)
+		suite.Empty(verrs.Errors)
+		if suite.Error(err) {
+			suite.Contains(err.Error(), "BX04")
+		}
+
+		shipment.GBLNumber = savedGBLNumber
+
+		helperCheckInvoiceStatus(suite, invoice.ID, models.InvoiceStatusSUBMISSIONFAILURE)
+		helperCheckLineItemInvoiceID(suite, &shipment.ID, nil)
+	})
+
 	suite.T().Run("process invoice fails due to invoice number validation failure", func(t *testing.T) {
 		invoice := helperCreateInvoiceForShipment(suite, shipment, officeUser)
 
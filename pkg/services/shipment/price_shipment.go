@@ -44,7 +44,15 @@ func (c PriceShipment) Call(shipment *models.Shipment, price PricingType) (*vali
 		return validate.NewErrors(), errors.New("Destination address not provided")
 	}
 
-	distanceCalculation, err := models.NewDistanceCalculation(c.Planner, *origin, destination)
+	// International shipments are priced port-to-port, so their distance comes from the rate tables
+	// rather than the route planner
+	var distanceCalculation models.DistanceCalculation
+	var err error
+	if shipment.IsInternational() {
+		distanceCalculation, err = c.Engine.PortToPortDistanceCalculation(*shipment, *origin, destination)
+	} else {
+		distanceCalculation, err = models.NewDistanceCalculation(c.Planner, *origin, destination)
+	}
 	if err != nil {
 		return validate.NewErrors(), errors.Wrap(err, "Error creating DistanceCalculation model")
 	}
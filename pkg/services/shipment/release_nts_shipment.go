@@ -0,0 +1,38 @@
+package shipment
+
+import (
+	"time"
+
+	"github.com/gobuffalo/pop"
+	"github.com/gobuffalo/validate"
+	"github.com/pkg/errors"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/rateengine"
+)
+
+// ReleaseNTSShipment is a service object to release an NTS shipment's goods from storage and price the storage period
+type ReleaseNTSShipment struct {
+	DB     *pop.Connection
+	Engine *rateengine.RateEngine
+}
+
+// Call releases the goods in storage for an NTS shipment and records the fee for the time they were stored
+func (c ReleaseNTSShipment) Call(releaseDate time.Time, shipment *models.Shipment, nts *models.NonTemporaryStorage) (*validate.Errors, error) {
+	if shipment.ShipmentType != models.ShipmentTypeNTS {
+		return validate.NewErrors(), errors.Errorf("Shipment %s is not an NTS shipment", shipment.ID)
+	}
+
+	err := nts.Release(releaseDate)
+	if err != nil {
+		return validate.NewErrors(), err
+	}
+
+	storageCost, err := c.Engine.HandleRunOnNonTemporaryStorage(*shipment, *nts, releaseDate)
+	if err != nil {
+		return validate.NewErrors(), err
+	}
+	nts.StorageFeeCents = &storageCost.StorageFee
+
+	return models.SaveNonTemporaryStorageAndAddresses(c.DB, nts)
+}
@@ -0,0 +1,91 @@
+package shipment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"github.com/transcom/mymove/pkg/models"
+	"github.com/transcom/mymove/pkg/rateengine"
+	"github.com/transcom/mymove/pkg/testdatagen"
+	"github.com/transcom/mymove/pkg/testingsuite"
+)
+
+func (suite *ReleaseNTSShipmentSuite) TestReleaseNTSShipmentCall() {
+	numTspUsers := 1
+	numShipments := 1
+	numShipmentOfferSplit := []int{1}
+	status := []models.ShipmentStatus{models.ShipmentStatusINTRANSIT}
+	_, shipments, _, err := testdatagen.CreateShipmentOfferData(suite.DB(), numTspUsers, numShipments, numShipmentOfferSplit, status, models.SelectedMoveTypeNTS)
+	suite.FatalNoError(err)
+
+	shipment := shipments[0]
+	suite.Equal(models.ShipmentTypeNTS, shipment.ShipmentType)
+
+	// Store the goods near the pickup address, which already has rate data
+	nts := testdatagen.MakeNonTemporaryStorage(suite.DB(), testdatagen.Assertions{
+		NonTemporaryStorage: models.NonTemporaryStorage{
+			Shipment:         shipment,
+			ShipmentID:       shipment.ID,
+			WarehouseAddress: *shipment.PickupAddress,
+			StorageStartDate: *shipment.ActualPickupDate,
+		},
+	})
+
+	releaseAddress := testdatagen.MakeDefaultAddress(suite.DB())
+	releaseDate := shipment.ActualPickupDate.AddDate(0, 0, 29)
+	suite.NoError(nts.RequestRelease(releaseDate, &releaseAddress))
+
+	engine := rateengine.NewRateEngine(suite.DB(), suite.logger)
+	verrs, err := ReleaseNTSShipment{
+		DB:     suite.DB(),
+		Engine: engine,
+	}.Call(releaseDate, &shipment, &nts)
+	suite.FatalNoError(err)
+	suite.FatalFalse(verrs.HasAny())
+
+	fetched, err := models.FetchNonTemporaryStorageForShipment(suite.DB(), shipment.ID)
+	suite.FatalNoError(err)
+	suite.Equal(models.NonTemporaryStorageStatusRELEASED, fetched.Status)
+	suite.Equal(30, fetched.DaysInStorage(releaseDate))
+	suite.NotNil(fetched.StorageFeeCents)
+	suite.True(fetched.StorageFeeCents.Int() > 0)
+}
+
+func (suite *ReleaseNTSShipmentSuite) TestReleaseNTSShipmentRejectsOtherShipmentTypes() {
+	nts := testdatagen.MakeNonTemporaryStorage(suite.DB(), testdatagen.Assertions{
+		Shipment: models.Shipment{
+			ShipmentType: models.ShipmentTypeHHG,
+		},
+	})
+	suite.NoError(nts.RequestRelease(nts.StorageStartDate, nil))
+
+	engine := rateengine.NewRateEngine(suite.DB(), suite.logger)
+	_, err := ReleaseNTSShipment{
+		DB:     suite.DB(),
+		Engine: engine,
+	}.Call(nts.StorageStartDate, &nts.Shipment, &nts)
+	suite.Error(err)
+	suite.Equal(models.NonTemporaryStorageStatusRELEASEREQUESTED, nts.Status)
+}
+
+type ReleaseNTSShipmentSuite struct {
+	testingsuite.PopTestSuite
+	logger Logger
+}
+
+func (suite *ReleaseNTSShipmentSuite) SetupTest() {
+	suite.DB().TruncateAll()
+}
+
+func TestReleaseNTSShipmentSuite(t *testing.T) {
+	// Use a no-op logger during testing
+	logger := zap.NewNop()
+
+	hs := &ReleaseNTSShipmentSuite{
+		PopTestSuite: testingsuite.NewPopTestSuite(),
+		logger:       logger,
+	}
+	suite.Run(t, hs)
+}
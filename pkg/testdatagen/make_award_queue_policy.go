@@ -0,0 +1,32 @@
+package testdatagen
+
+import (
+	"github.com/gobuffalo/pop"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// MakeAwardQueuePolicy creates a single AwardQueuePolicy for the peak rate cycle
+func MakeAwardQueuePolicy(db *pop.Connection, assertions Assertions) models.AwardQueuePolicy {
+	policy := models.AwardQueuePolicy{
+		RateCycleStart:          PeakRateCycleStart,
+		RateCycleEnd:            PeakRateCycleEnd,
+		Version:                 1,
+		MinimumPerformanceScore: 0,
+		NumQualityBands:         4,
+		BandDistribution:        models.BandDistributionTOPDOWN,
+		OfferAcceptanceHours:    48,
+	}
+
+	// Overwrite values with those from assertions
+	mergeModels(&policy, assertions.AwardQueuePolicy)
+
+	mustCreate(db, &policy)
+
+	return policy
+}
+
+// MakeDefaultAwardQueuePolicy makes a single AwardQueuePolicy with default values
+func MakeDefaultAwardQueuePolicy(db *pop.Connection) models.AwardQueuePolicy {
+	return MakeAwardQueuePolicy(db, Assertions{})
+}
@@ -0,0 +1,50 @@
+package testdatagen
+
+import (
+	"github.com/go-openapi/swag"
+	"github.com/gobuffalo/pop"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// MakeNonTemporaryStorage creates a single NonTemporaryStorage with an NTS shipment and warehouse address
+func MakeNonTemporaryStorage(db *pop.Connection, assertions Assertions) models.NonTemporaryStorage {
+	shipment := assertions.NonTemporaryStorage.Shipment
+	if isZeroUUID(shipment.ID) {
+		if assertions.Shipment.ShipmentType == "" {
+			assertions.Shipment.ShipmentType = models.ShipmentTypeNTS
+		}
+		shipment = MakeShipment(db, assertions)
+	}
+
+	address := assertions.NonTemporaryStorage.WarehouseAddress
+	if isZeroUUID(address.ID) {
+		address = MakeAddress(db, assertions)
+	}
+
+	// Filled in dummy data.
+	nts := models.NonTemporaryStorage{
+		ShipmentID:         shipment.ID,
+		Status:             models.NonTemporaryStorageStatusINSTORAGE,
+		StorageStartDate:   NextValidMoveDate,
+		WarehouseID:        "000451",
+		WarehouseName:      "Long Haul Storage",
+		WarehouseAddressID: address.ID,
+		WarehousePhone:     swag.String("(703) 555-0143"),
+		WarehouseEmail:     swag.String("storage@longhaulstorage.com"),
+		Shipment:           shipment,
+		WarehouseAddress:   address,
+	}
+
+	// Overwrite values with those from assertions
+	mergeModels(&nts, assertions.NonTemporaryStorage)
+
+	mustCreate(db, &nts)
+
+	return nts
+}
+
+// MakeDefaultNonTemporaryStorage makes a single NonTemporaryStorage with default values
+func MakeDefaultNonTemporaryStorage(db *pop.Connection) models.NonTemporaryStorage {
+	return MakeNonTemporaryStorage(db, Assertions{})
+}
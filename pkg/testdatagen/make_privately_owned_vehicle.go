@@ -0,0 +1,74 @@
+package testdatagen
+
+import (
+	"github.com/gobuffalo/pop"
+
+	"github.com/transcom/mymove/pkg/models"
+)
+
+// MakeVehicleProcessingCenter creates a single VehicleProcessingCenter and its address
+func MakeVehicleProcessingCenter(db *pop.Connection, assertions Assertions) models.VehicleProcessingCenter {
+	address := assertions.VehicleProcessingCenter.Address
+	if isZeroUUID(assertions.VehicleProcessingCenter.AddressID) {
+		address = MakeAddress(db, Assertions{
+			Address: models.Address{
+				StreetAddress1: "2351 E Ocean Blvd",
+				City:           "Long Beach",
+				State:          "CA",
+				PostalCode:     "90803",
+			},
+		})
+	}
+
+	center := models.VehicleProcessingCenter{
+		Name:      "Long Beach VPC",
+		Code:      "LGB",
+		AddressID: address.ID,
+		Address:   address,
+	}
+
+	mergeModels(&center, assertions.VehicleProcessingCenter)
+
+	mustCreate(db, &center)
+
+	return center
+}
+
+// MakePrivatelyOwnedVehicle creates a single POV shipment and its associated Move and Orders
+func MakePrivatelyOwnedVehicle(db *pop.Connection, assertions Assertions) models.PrivatelyOwnedVehicle {
+	move := assertions.PrivatelyOwnedVehicle.Move
+	// ID is required because it must be populated for Eager saving to work.
+	if isZeroUUID(assertions.PrivatelyOwnedVehicle.MoveID) {
+		if assertions.Move.SelectedMoveType == nil {
+			povMoveType := models.SelectedMoveTypePOV
+			assertions.Move.SelectedMoveType = &povMoveType
+		}
+		move = MakeMove(db, assertions)
+	}
+
+	pov := models.PrivatelyOwnedVehicle{
+		Move:                 move,
+		MoveID:               move.ID,
+		Status:               models.POVStatusDRAFT,
+		VIN:                  "1HGCM82633A004352",
+		Make:                 "Honda",
+		Model:                "Accord",
+		Year:                 2003,
+		RequestedDropOffDate: models.TimePointer(NextValidMoveDate),
+	}
+
+	// Overwrite values with those from assertions
+	mergeModels(&pov, assertions.PrivatelyOwnedVehicle)
+
+	mustCreate(db, &pov)
+
+	// Add the pov we just created to the move's POVs
+	pov.Move.PrivatelyOwnedVehicles = append(pov.Move.PrivatelyOwnedVehicles, pov)
+
+	return pov
+}
+
+// MakeDefaultPrivatelyOwnedVehicle makes a POV shipment with default values
+func MakeDefaultPrivatelyOwnedVehicle(db *pop.Connection) models.PrivatelyOwnedVehicle {
+	return MakePrivatelyOwnedVehicle(db, Assertions{})
+}
@@ -86,6 +86,14 @@ func CreateShipmentOfferData(db *pop.Connection, numTspUsers int, numShipments i
 		return tspUserList, shipmentList, shipmentOfferList, err
 	}
 
+	shipmentType := models.ShipmentTypeHHG
+	switch moveType {
+	case models.SelectedMoveTypeUB:
+		shipmentType = models.ShipmentTypeUB
+	case models.SelectedMoveTypeNTS:
+		shipmentType = models.ShipmentTypeNTS
+	}
+
 	// Create TSP Users
 	for i := 1; i <= numTspUsers; i++ {
 		email := fmt.Sprintf("leo_spaceman_tsp_%d@example.com", i)
@@ -192,6 +200,7 @@ func CreateShipmentOfferData(db *pop.Connection, numTspUsers int, numShipments i
 				Status:           moveStatus,
 			},
 			Shipment: models.Shipment{
+				ShipmentType:            shipmentType,
 				TrafficDistributionList: &tdl,
 				SourceGBLOC:             &sourceGBLOC,
 				DestinationGBLOC:        &destinationGBLOC,
@@ -344,6 +353,19 @@ func createTariffDataForRateEngine(db *pop.Connection, shipment models.Shipment)
 	}
 	mustSave(db, &baseLinehaul)
 
+	// Unaccompanied baggage is priced from its own linehaul rates
+	ubBaseLinehaul := models.Tariff400ngLinehaulRate{
+		DistanceMilesLower: 1001,
+		DistanceMilesUpper: 1101,
+		WeightLbsLower:     weightLower,
+		WeightLbsUpper:     weightUpper,
+		RateCents:          579600,
+		Type:               models.LinehaulRateTypeConusUB,
+		EffectiveDateLower: PerformancePeriodStart,
+		EffectiveDateUpper: PerformancePeriodEnd,
+	}
+	mustSave(db, &ubBaseLinehaul)
+
 	// Create Service Area entries for Zip3s (which were already created)
 
 	// Create fees for service areas
@@ -427,6 +449,18 @@ func createTariffDataForRateEngine(db *pop.Connection, shipment models.Shipment)
 	}
 	mustSave(db, &codeLHS)
 
+	codeUBL := models.Tariff400ngItem{
+		Code:                "UBL",
+		Item:                "Unaccompanied Baggage Linehaul Transportation",
+		DiscountType:        models.Tariff400ngItemDiscountTypeHHG,
+		AllowedLocation:     models.Tariff400ngItemAllowedLocationORIGIN,
+		MeasurementUnit1:    models.Tariff400ngItemMeasurementUnitFLATRATE,
+		MeasurementUnit2:    models.Tariff400ngItemMeasurementUnitNONE,
+		RateRefCode:         models.Tariff400ngItemRateRefCodeTARIFFSECTION,
+		RequiresPreApproval: false,
+	}
+	mustSave(db, &codeUBL)
+
 	code135A := models.Tariff400ngItem{
 		Code:                "135A",
 		Item:                "Origin Service Charge",
@@ -65,6 +65,7 @@ func MakeShipment(db *pop.Connection, assertions Assertions) models.Shipment {
 
 	shipment := models.Shipment{
 		Status:           status,
+		ShipmentType:     models.ShipmentTypeHHG,
 		SourceGBLOC:      sourceGBLOC,
 		DestinationGBLOC: destinationGBLOC,
 		GBLNumber:        nil,
@@ -22,6 +22,7 @@ import (
 // Assertions defines assertions about what the data contains
 type Assertions struct {
 	Address                                  models.Address
+	AwardQueuePolicy                         models.AwardQueuePolicy
 	BackupContact                            models.BackupContact
 	BlackoutDate                             models.BlackoutDate
 	DistanceCalculation                      models.DistanceCalculation
@@ -34,9 +35,11 @@ type Assertions struct {
 	Move                                     models.Move
 	MoveDocument                             models.MoveDocument
 	MovingExpenseDocument                    models.MovingExpenseDocument
+	NonTemporaryStorage                      models.NonTemporaryStorage
 	OfficeUser                               models.OfficeUser
 	Order                                    models.Order
 	PersonallyProcuredMove                   models.PersonallyProcuredMove
+	PrivatelyOwnedVehicle                    models.PrivatelyOwnedVehicle
 	Reimbursement                            models.Reimbursement
 	ServiceAgent                             models.ServiceAgent
 	ServiceMember                            models.ServiceMember
@@ -57,6 +60,7 @@ type Assertions struct {
 	Upload                                   models.Upload
 	Uploader                                 *uploader.Uploader
 	User                                     models.User
+	VehicleProcessingCenter                  models.VehicleProcessingCenter
 }
 
 func stringPointer(s string) *string {